@@ -31,9 +31,9 @@ const (
 	FileName = "omp.cache"
 )
 
-var SessionFileName = fmt.Sprintf("%s.%s", FileName, sessionID())
+var SessionFileName = fmt.Sprintf("%s.%s", FileName, SessionID())
 
-func sessionID() string {
+func SessionID() string {
 	pid := os.Getenv("POSH_SESSION_ID")
 	if len(pid) == 0 {
 		log.Debug("POSH_SESSION_ID not set, using PID")
@@ -49,6 +49,7 @@ const (
 	PROMPTCOUNTCACHE = "prompt_count_cache"
 	ENGINECACHE      = "engine_cache"
 	FONTLISTCACHE    = "font_list_cache"
+	TERMINALBGCACHE  = "terminal_background_cache"
 )
 
 type Entry struct {
@@ -1,22 +1,118 @@
 package cache
 
 import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
 	"github.com/jandedobbeleer/oh-my-posh/src/maps"
 )
 
+var (
+	commandHits   atomic.Int64
+	commandMisses atomic.Int64
+)
+
+// CommandStats returns how many command lookups were restored from the persisted cache,
+// and how many needed a search through PATH
+func CommandStats() (hits, misses int64) {
+	return commandHits.Load(), commandMisses.Load()
+}
+
 type Command struct {
 	Commands *maps.Concurrent
+	store    Cache
+	pathHash string
+}
+
+// Persist keeps the resolved command paths in the store for the given PATH, changing
+// the PATH invalidates them as the cache key contains a hash of its value.
+func (c *Command) Persist(store Cache, path string) {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(path))
+
+	c.store = store
+	c.pathHash = strconv.FormatUint(hash.Sum64(), 16)
 }
 
 func (c *Command) Set(command, path string) {
 	c.Commands.Set(command, path)
+
+	if c.store == nil {
+		return
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+
+	c.store.Set(c.key(command), fmt.Sprintf("%s|%d", path, info.ModTime().UnixNano()), ONEWEEK)
 }
 
 func (c *Command) Get(command string) (string, bool) {
-	cacheCommand, found := c.Commands.Get(command)
+	path, ok, _ := c.get(command)
+	return path, ok
+}
+
+// Lookup is Get for resolving the path of a command, only these count towards the CommandStats
+// as other callers also try commands that are never resolved through PATH
+func (c *Command) Lookup(command string) (string, bool) {
+	path, ok, restored := c.get(command)
+
+	switch {
+	case restored:
+		commandHits.Add(1)
+	case !ok && c.store != nil:
+		commandMisses.Add(1)
+	}
+
+	return path, ok
+}
+
+func (c *Command) get(command string) (path string, ok, restored bool) {
+	if cacheCommand, found := c.Commands.Get(command); found {
+		path, ok = cacheCommand.(string)
+		return path, ok, false
+	}
+
+	if c.store == nil {
+		return "", false, false
+	}
+
+	path, ok = c.restore(command)
+	if !ok {
+		return "", false, false
+	}
+
+	c.Commands.Set(command, path)
+
+	return path, true, true
+}
+
+// restore returns the persisted path of the command when the binary didn't change since
+func (c *Command) restore(command string) (string, bool) {
+	key := c.key(command)
+
+	value, found := c.store.Get(key)
 	if !found {
 		return "", false
 	}
-	command, ok := cacheCommand.(string)
-	return command, ok
+
+	path, modTime, _ := strings.Cut(value, "|")
+
+	info, err := os.Stat(path)
+	if err != nil || strconv.FormatInt(info.ModTime().UnixNano(), 10) != modTime {
+		c.store.Delete(key)
+		return "", false
+	}
+
+	return path, true
+}
+
+func (c *Command) key(command string) string {
+	return fmt.Sprintf("command_path_%s_%s", c.pathHash, command)
 }
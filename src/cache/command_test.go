@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/maps"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandPersist(t *testing.T) {
+	folder := t.TempDir()
+	binary := filepath.Join(folder, "go")
+	_ = os.WriteFile(binary, []byte("binary"), 0o755)
+
+	store := &Store{}
+	store.Init(filepath.Join(folder, FileName), false)
+
+	newCommand := func(path string) *Command {
+		command := &Command{Commands: maps.NewConcurrent()}
+		command.Persist(store, path)
+		return command
+	}
+
+	hits, misses := CommandStats()
+
+	_, ok := newCommand(folder).Get("go")
+	assert.False(t, ok, "not counted")
+
+	_, ok = newCommand(folder).Lookup("go")
+	assert.False(t, ok, "nothing persisted")
+
+	newCommand(folder).Set("go", binary)
+
+	path, ok := newCommand(folder).Lookup("go")
+	assert.True(t, ok, "persisted")
+	assert.Equal(t, binary, path)
+
+	_, ok = newCommand("/usr/bin").Lookup("go")
+	assert.False(t, ok, "PATH changed")
+
+	future := time.Now().Add(time.Hour)
+	_ = os.Chtimes(binary, future, future)
+
+	_, ok = newCommand(folder).Lookup("go")
+	assert.False(t, ok, "binary changed")
+
+	_, ok = newCommand(folder).Lookup("go")
+	assert.False(t, ok, "entry removed")
+
+	newHits, newMisses := CommandStats()
+	assert.Equal(t, int64(1), newHits-hits)
+	assert.Equal(t, int64(4), newMisses-misses)
+}
@@ -3,6 +3,7 @@ package cache
 type Config struct {
 	Duration Duration `json:"duration,omitempty" toml:"duration,omitempty"`
 	Strategy Strategy `json:"strategy,omitempty" toml:"strategy,omitempty"`
+	Watch    []string `json:"watch,omitempty" toml:"watch,omitempty"`
 }
 
 type Strategy string
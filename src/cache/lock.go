@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+const (
+	lockTimeout  = time.Second
+	lockInterval = 10 * time.Millisecond
+)
+
+var errLockTimeout = errors.New("timed out waiting for the cache lock")
+
+// lockFile takes an exclusive advisory lock on the given file, creating it when needed.
+// The returned function releases the lock.
+func lockFile(lockFilePath string) (func(), error) {
+	file, err := os.OpenFile(lockFilePath, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(lockTimeout)
+
+	for {
+		if err = tryLock(file); err == nil {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			file.Close()
+			return nil, errLockTimeout
+		}
+
+		time.Sleep(lockInterval)
+	}
+
+	return func() {
+		_ = unlock(file)
+		file.Close()
+	}, nil
+}
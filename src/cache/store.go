@@ -0,0 +1,251 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/maps"
+)
+
+// Store is a file backed cache which can be shared between processes.
+// Only the keys changed by this process are written back, merged with
+// the content on disk at that moment while holding an exclusive lock.
+type Store struct {
+	cache         *maps.Concurrent
+	updated       *maps.Concurrent
+	deleted       *maps.Concurrent
+	cacheFilePath string
+	dirty         atomic.Bool
+	persist       bool
+}
+
+type Statistics struct {
+	Path    string
+	Size    int64
+	Entries int
+	Expired int
+}
+
+func (s *Store) Init(cacheFilePath string, persist bool) {
+	defer log.Trace(time.Now(), cacheFilePath)
+
+	s.cache = maps.NewConcurrent()
+	s.updated = maps.NewConcurrent()
+	s.deleted = maps.NewConcurrent()
+	s.cacheFilePath = cacheFilePath
+	s.persist = persist
+
+	log.Debug("loading cache file:", s.cacheFilePath)
+
+	list, err := readEntries(s.cacheFilePath)
+	if err != nil {
+		// set to dirty so we create it on close
+		s.dirty.Store(true)
+		log.Error(err)
+		return
+	}
+
+	for key, co := range list {
+		if co.Expired() {
+			continue
+		}
+
+		log.Debug("loading cache key:", key)
+		s.cache.Set(key, co)
+	}
+}
+
+func (s *Store) Close() {
+	if !s.persist || !s.dirty.Load() {
+		return
+	}
+
+	err := update(s.cacheFilePath, func(list map[string]*Entry) {
+		for key := range s.deleted.ToSimple() {
+			delete(list, key)
+		}
+
+		for key, value := range s.updated.ToSimple() {
+			if co, ok := value.(*Entry); ok {
+				list[key] = co
+			}
+		}
+	})
+
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+// returns the value for the given key as long as
+// the duration is not expired
+func (s *Store) Get(key string) (string, bool) {
+	val, found := s.cache.Get(key)
+	if !found {
+		return "", false
+	}
+
+	if co, ok := val.(*Entry); ok {
+		return co.Value, true
+	}
+
+	return "", false
+}
+
+// sets the value for the given key with a duration
+func (s *Store) Set(key, value string, duration Duration) {
+	seconds := duration.Seconds()
+
+	if seconds == 0 {
+		return
+	}
+
+	co := &Entry{
+		Value:     value,
+		Timestamp: time.Now().Unix(),
+		TTL:       seconds,
+	}
+
+	s.cache.Set(key, co)
+	s.updated.Set(key, co)
+	s.deleted.Delete(key)
+
+	s.dirty.Store(true)
+}
+
+// delete the key from the cache
+func (s *Store) Delete(key string) {
+	s.cache.Delete(key)
+	s.updated.Delete(key)
+	s.deleted.Set(key, true)
+
+	s.dirty.Store(true)
+}
+
+// Compact removes all expired entries from the cache file.
+func Compact(cacheFilePath string) error {
+	return update(cacheFilePath, func(_ map[string]*Entry) {})
+}
+
+// Stats returns information about the cache file as it is on disk.
+func Stats(cacheFilePath string) (*Statistics, error) {
+	info, err := os.Stat(cacheFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := readEntries(cacheFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &Statistics{
+		Path:    cacheFilePath,
+		Size:    info.Size(),
+		Entries: len(list),
+	}
+
+	for _, co := range list {
+		if co.Expired() {
+			stats.Expired++
+		}
+	}
+
+	return stats, nil
+}
+
+// Files returns the paths of all cache files in the cache folder.
+func Files(cachePath string) ([]string, error) {
+	files, err := os.ReadDir(cachePath)
+	if err != nil {
+		return []string{}, err
+	}
+
+	var paths []string
+
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasPrefix(name, FileName) {
+			continue
+		}
+
+		if strings.HasSuffix(name, ".lock") || strings.HasSuffix(name, ".tmp") {
+			continue
+		}
+
+		paths = append(paths, filepath.Join(cachePath, name))
+	}
+
+	return paths, nil
+}
+
+// update applies the changes to the latest content of the cache file
+// and removes expired entries while holding an exclusive lock.
+func update(cacheFilePath string, apply func(list map[string]*Entry)) error {
+	unlock, err := lockFile(cacheFilePath + ".lock")
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	list, err := readEntries(cacheFilePath)
+	if err != nil {
+		list = make(map[string]*Entry)
+	}
+
+	apply(list)
+
+	for key, co := range list {
+		if co.Expired() {
+			delete(list, key)
+		}
+	}
+
+	dump, err := json.MarshalIndent(list, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first so readers never see a partial file
+	tmp, err := os.CreateTemp(filepath.Dir(cacheFilePath), filepath.Base(cacheFilePath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(dump); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), cacheFilePath)
+}
+
+func readEntries(cacheFilePath string) (map[string]*Entry, error) {
+	content, err := os.ReadFile(cacheFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var list map[string]*Entry
+	if err := json.Unmarshal(content, &list); err != nil {
+		return nil, err
+	}
+
+	if list == nil {
+		list = make(map[string]*Entry)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreMerge(t *testing.T) {
+	cacheFilePath := filepath.Join(t.TempDir(), FileName)
+
+	first := &Store{}
+	first.Init(cacheFilePath, true)
+
+	second := &Store{}
+	second.Init(cacheFilePath, true)
+
+	first.Set("a", "1", ONEDAY)
+	second.Set("b", "2", ONEDAY)
+
+	first.Close()
+	second.Close()
+
+	store := &Store{}
+	store.Init(cacheFilePath, true)
+
+	value, ok := store.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "1", value)
+
+	value, ok = store.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, "2", value)
+}
+
+func TestStoreDelete(t *testing.T) {
+	cacheFilePath := filepath.Join(t.TempDir(), FileName)
+
+	store := &Store{}
+	store.Init(cacheFilePath, true)
+	store.Set("a", "1", ONEDAY)
+	store.Set("b", "2", ONEDAY)
+	store.Close()
+
+	store = &Store{}
+	store.Init(cacheFilePath, true)
+	store.Delete("a")
+
+	other := &Store{}
+	other.Init(cacheFilePath, true)
+	other.Set("c", "3", ONEDAY)
+
+	store.Close()
+	other.Close()
+
+	store = &Store{}
+	store.Init(cacheFilePath, true)
+
+	_, ok := store.Get("a")
+	assert.False(t, ok)
+
+	_, ok = store.Get("b")
+	assert.True(t, ok)
+
+	_, ok = store.Get("c")
+	assert.True(t, ok)
+}
+
+func TestStoreNoPersist(t *testing.T) {
+	cacheFilePath := filepath.Join(t.TempDir(), FileName)
+
+	store := &Store{}
+	store.Init(cacheFilePath, false)
+	store.Set("a", "1", ONEDAY)
+	store.Close()
+
+	_, err := os.Stat(cacheFilePath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestCompactAndStats(t *testing.T) {
+	cacheFilePath := filepath.Join(t.TempDir(), FileName)
+
+	list := map[string]*Entry{
+		"valid":    {Value: "1", Timestamp: time.Now().Unix(), TTL: 60},
+		"infinite": {Value: "2", Timestamp: time.Now().Unix(), TTL: -1},
+		"expired":  {Value: "3", Timestamp: time.Now().Add(-time.Hour).Unix(), TTL: 60},
+	}
+
+	content, _ := json.Marshal(list)
+	assert.NoError(t, os.WriteFile(cacheFilePath, content, 0644))
+
+	stats, err := Stats(cacheFilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, stats.Entries)
+	assert.Equal(t, 1, stats.Expired)
+	assert.Equal(t, int64(len(content)), stats.Size)
+
+	assert.NoError(t, Compact(cacheFilePath))
+
+	stats, err = Stats(cacheFilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stats.Entries)
+	assert.Equal(t, 0, stats.Expired)
+
+	files, err := Files(filepath.Dir(cacheFilePath))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{cacheFilePath}, files)
+}
@@ -0,0 +1,22 @@
+package cache
+
+// Watcher is implemented by segments that cache values of their own,
+// so they can be invalidated when the watched files change as well
+type Watcher interface {
+	Watch(patterns []string)
+}
+
+func watchKey(key string) string {
+	return key + "_watch"
+}
+
+// SetFileState stores the state of the watched files next to the cache entry
+func SetFileState(cache Cache, key, state string, duration Duration) {
+	cache.Set(watchKey(key), state, duration)
+}
+
+// FileStateChanged checks if the state of the watched files differs from the one stored with the cache entry
+func FileStateChanged(cache Cache, key, state string) bool {
+	stored, OK := cache.Get(watchKey(key))
+	return !OK || stored != state
+}
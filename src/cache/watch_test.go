@@ -0,0 +1,20 @@
+package cache
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileStateChanged(t *testing.T) {
+	store := &Store{}
+	store.Init(filepath.Join(t.TempDir(), FileName), false)
+
+	assert.True(t, FileStateChanged(store, "key", ""), "no state stored")
+
+	SetFileState(store, "key", "state", ONEDAY)
+	assert.False(t, FileStateChanged(store, "key", "state"))
+	assert.True(t, FileStateChanged(store, "key", "other"))
+	assert.True(t, FileStateChanged(store, "other", "state"))
+}
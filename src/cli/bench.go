@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"runtime/pprof"
+	"runtime/trace"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/prompt"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	runs       int
+	cpuProfile string
+	traceFile  string
+)
+
+// benchCmd represents the bench command
+var benchCmd = createBenchCmd()
+
+func init() {
+	RootCmd.AddCommand(benchCmd)
+}
+
+func createBenchCmd() *cobra.Command {
+	benchCmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Benchmark the prompt",
+		Long: `Benchmark the prompt.
+
+Renders the primary prompt multiple times, without and with cache, and reports the min, median,
+p95 and max duration of every block and segment. It also reports the time spent rendering templates,
+resolving colors and running external commands. As segments execute in parallel, these can exceed the total.
+
+Example usage:
+
+> oh-my-posh bench --config ~/myconfig.omp.json --pwd ~/code/oh-my-posh --runs 50
+
+Use --cpuprofile and --trace to write a pprof CPU profile and an execution trace covering all runs.`,
+		Args: cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			if runs < 1 {
+				fmt.Println("runs must be at least 1")
+				os.Exit(2)
+			}
+
+			if len(cpuProfile) != 0 {
+				file, err := os.Create(cpuProfile)
+				if err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+
+				defer file.Close()
+
+				if err := pprof.StartCPUProfile(file); err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+
+				defer pprof.StopCPUProfile()
+			}
+
+			if len(traceFile) != 0 {
+				file, err := os.Create(traceFile)
+				if err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+
+				defer file.Close()
+
+				if err := trace.Start(file); err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+
+				defer trace.Stop()
+			}
+
+			for _, cached := range []bool{false, true} {
+				flags := &runtime.Flags{
+					Config:        configFlag,
+					PWD:           pwd,
+					Shell:         shellName,
+					TerminalWidth: terminalWidth,
+				}
+
+				benchmark, err := prompt.Bench(flags, runs, cached)
+				if err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+
+				fmt.Println(benchmark.String())
+			}
+		},
+	}
+
+	benchCmd.Flags().StringVar(&pwd, "pwd", "", "the directory to render the prompt in")
+	benchCmd.Flags().StringVar(&shellName, "shell", "", "the shell to render the prompt for")
+	benchCmd.Flags().IntVarP(&terminalWidth, "terminal-width", "w", 0, "width of the terminal")
+	benchCmd.Flags().IntVarP(&runs, "runs", "n", 10, "the number of times to render the prompt")
+	benchCmd.Flags().StringVar(&cpuProfile, "cpuprofile", "", "write a pprof CPU profile to this file")
+	benchCmd.Flags().StringVar(&traceFile, "trace", "", "write an execution trace to this file")
+
+	return benchCmd
+}
@@ -12,7 +12,7 @@ import (
 
 // getCmd represents the get command
 var getCache = &cobra.Command{
-	Use:   "cache [path|clear|edit]",
+	Use:   "cache [path|clear|edit|stats|compact]",
 	Short: "Interact with the oh-my-posh cache",
 	Long: `Interact with the oh-my-posh cache.
 
@@ -20,11 +20,15 @@ You can do the following:
 
 - path: list cache path
 - clear: remove all cache values
-- edit: edit cache values`,
+- edit: edit cache values
+- stats: show the size and number of (expired) entries per cache file
+- compact: remove expired entries from all cache files`,
 	ValidArgs: []string{
 		"path",
 		"clear",
 		"edit",
+		"stats",
+		"compact",
 	},
 	Args: NoArgsOrOneValidArg,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -49,6 +53,37 @@ You can do the following:
 		case "edit":
 			cacheFilePath := filepath.Join(cache.Path(), cache.FileName)
 			os.Exit(editFileWithEditor(cacheFilePath))
+		case "stats":
+			files, err := cache.Files(cache.Path())
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+
+			for _, file := range files {
+				stats, err := cache.Stats(file)
+				if err != nil {
+					fmt.Println(err)
+					continue
+				}
+
+				fmt.Printf("%s: %d bytes, %d entries, %d expired\n", stats.Path, stats.Size, stats.Entries, stats.Expired)
+			}
+		case "compact":
+			files, err := cache.Files(cache.Path())
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+
+			for _, file := range files {
+				if err := cache.Compact(file); err != nil {
+					fmt.Println(err)
+					continue
+				}
+
+				fmt.Println("compacted cache file:", file)
+			}
 		}
 	},
 }
@@ -13,7 +13,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var output string
+var (
+	output  string
+	flatten bool
+)
 
 // exportCmd represents the export command
 var exportCmd = &cobra.Command{
@@ -31,7 +34,11 @@ Exports the config file "~/myconfig.omp.json" in TOML format and prints the resu
 
 > oh-my-posh config export --output ~/new_config.omp.json
 
-Exports the current config to "~/new_config.omp.json" (in JSON format).`,
+Exports the current config to "~/new_config.omp.json" (in JSON format).
+
+> oh-my-posh config export --flatten --format json
+
+Exports the current config merged with all configs it extends and prints the result to stdout.`,
 	Args: cobra.NoArgs,
 	Run: func(_ *cobra.Command, _ []string) {
 		if len(output) == 0 && len(format) == 0 {
@@ -41,7 +48,13 @@ Exports the current config to "~/new_config.omp.json" (in JSON format).`,
 		}
 
 		configFile := config.Path(configFlag)
-		cfg := config.Load(configFile, shell.GENERIC, false)
+
+		var cfg *config.Config
+		if flatten {
+			cfg = config.Load(configFile, shell.GENERIC, false)
+		} else {
+			cfg = config.LoadFile(configFile, shell.GENERIC, false)
+		}
 
 		validateExportFormat := func() {
 			format = strings.ToLower(format)
@@ -95,5 +108,6 @@ func cleanOutputPath(output string) string {
 func init() {
 	exportCmd.Flags().StringVarP(&format, "format", "f", "json", "config format to migrate to")
 	exportCmd.Flags().StringVarP(&output, "output", "o", "", "config file to export to")
+	exportCmd.Flags().BoolVar(&flatten, "flatten", false, "merge the configs listed in extends into the exported config")
 	configCmd.AddCommand(exportCmd)
 }
@@ -2,7 +2,12 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
 	"github.com/jandedobbeleer/oh-my-posh/src/config"
 	"github.com/jandedobbeleer/oh-my-posh/src/image"
 	"github.com/jandedobbeleer/oh-my-posh/src/prompt"
@@ -18,8 +23,10 @@ var (
 	author string
 	// cursorPadding int
 	// rPromptOffset int
-	bgColor     string
-	outputImage string
+	bgColor      string
+	outputImage  string
+	imageFormat  string
+	imageCommand string
 )
 
 // imageCmd represents the image command
@@ -34,6 +41,8 @@ You can tweak the output by using additional flags:
 - cursor-padding: the padding of the prompt cursor
 - rprompt-offset: the offset of the right prompt
 - background-color: the background color of the image
+- format: png (default), svg or cast (asciinema v2), the extension of the output file is used when not set
+- command: the command typed in the cast
 
 Example usage:
 
@@ -47,9 +56,31 @@ Exports the config to an image file ~/mytheme.png.
 
 > oh-my-posh config export image --config ~/myconfig.omp.json --author "John Doe"
 
-Exports the config to an image file using customized output options.`,
+Exports the config to an image file using customized output options.
+
+> oh-my-posh config export image --config ~/myconfig.omp.json --format cast --command "npm test"
+
+Exports the config to an asciinema recording called myconfig.cast, typing "npm test" in the prompt.`,
 	Args: cobra.NoArgs,
-	Run: func(_ *cobra.Command, _ []string) {
+	Run: func(cmd *cobra.Command, _ []string) {
+		formats := []string{image.PNG, image.SVG, image.CAST}
+
+		if !slices.Contains(formats, imageFormat) {
+			fmt.Printf("unsupported format: %s\n", imageFormat)
+			os.Exit(2)
+		}
+
+		// the extension of the output file decides the format, unless set explicitly
+		extension := strings.ToLower(strings.TrimPrefix(filepath.Ext(outputImage), "."))
+		if slices.Contains(formats, extension) && extension != imageFormat {
+			if cmd.Flags().Changed("format") {
+				fmt.Printf("the output file %s doesn't match the %s format\n", outputImage, imageFormat)
+				os.Exit(2)
+			}
+
+			imageFormat = extension
+		}
+
 		configFile := config.Path(configFlag)
 		cfg := config.Load(configFile, shell.GENERIC, false)
 
@@ -72,6 +103,7 @@ Exports the config to an image file using customized output options.`,
 		// set sane defaults for things we don't print
 		cfg.ConsoleTitleTemplate = ""
 		cfg.PWD = ""
+		cfg.ColorProfile = color.TrueColor
 
 		terminal.Init(shell.GENERIC)
 		terminal.BackgroundColor = cfg.TerminalBackground.ResolveTemplate()
@@ -88,6 +120,12 @@ Exports the config to an image file using customized output options.`,
 			AnsiString: primaryPrompt,
 			Author:     author,
 			BgColor:    bgColor,
+			Format:     imageFormat,
+			Command:    imageCommand,
+		}
+
+		if imageFormat == image.CAST && cfg.TransientPrompt != nil {
+			imageCreator.Transient = eng.ExtraPrompt(prompt.Transient)
 		}
 
 		if outputImage != "" {
@@ -100,7 +138,7 @@ Exports the config to an image file using customized output options.`,
 			return
 		}
 
-		err = imageCreator.SavePNG()
+		err = imageCreator.Save()
 		if err != nil {
 			fmt.Print(err.Error())
 		}
@@ -110,6 +148,8 @@ Exports the config to an image file using customized output options.`,
 func init() {
 	imageCmd.Flags().StringVar(&author, "author", "", "config author")
 	imageCmd.Flags().StringVar(&bgColor, "background-color", "", "image background color")
-	imageCmd.Flags().StringVarP(&outputImage, "output", "o", "", "image file (.png) to export to")
+	imageCmd.Flags().StringVarP(&outputImage, "output", "o", "", "image file (.png, .svg or .cast) to export to")
+	imageCmd.Flags().StringVarP(&imageFormat, "format", "f", image.PNG, "image format (png, svg or cast), defaults to the extension of the output file")
+	imageCmd.Flags().StringVar(&imageCommand, "command", "git status", "command typed in the cast")
 	exportCmd.AddCommand(imageCmd)
 }
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	minimumContrast float64
+	lintBackground  string
+)
+
+// lintColorsCmd represents the lint-colors command
+var lintColorsCmd = &cobra.Command{
+	Use:   "lint-colors",
+	Short: "Check the contrast of your config's colors",
+	Long: `Check the contrast of your config's colors.
+
+Resolves every foreground and background pair, including palette references, template branches
+and cycle entries, and reports the ones with a WCAG contrast ratio below the minimum (4.5 by default).
+Transparent backgrounds are compared to --background, or terminal_background when not set.
+Exits with a non-zero exit code when the config contains unreadable colors.
+
+Example usage:
+
+> oh-my-posh config lint-colors --config ~/myconfig.omp.json --background "#ffffff"`,
+	Args: cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		configFile := config.Path(configFlag)
+		if len(configFile) == 0 {
+			// usage error
+			fmt.Println("no config file specified")
+			os.Exit(2)
+		}
+
+		problems := config.LintColors(configFile, minimumContrast, color.Ansi(lintBackground))
+		for _, problem := range problems {
+			fmt.Println(problem)
+		}
+
+		if len(problems) != 0 {
+			os.Exit(1)
+		}
+
+		fmt.Println("all colors are readable")
+	},
+}
+
+func init() {
+	lintColorsCmd.Flags().Float64Var(&minimumContrast, "minimum", config.MinimumContrast, "the minimum contrast ratio")
+	lintColorsCmd.Flags().StringVar(&lintBackground, "background", "", "the terminal background color to check transparent backgrounds against")
+	configCmd.AddCommand(lintColorsCmd)
+}
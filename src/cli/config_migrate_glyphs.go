@@ -36,7 +36,7 @@ A backup of the current config can be found at ~/myconfig.omp.json.bak.`,
 	Args: cobra.NoArgs,
 	Run: func(_ *cobra.Command, _ []string) {
 		configFile := config.Path(configFlag)
-		cfg := config.Load(configFile, shell.GENERIC, false)
+		cfg := config.LoadFile(configFile, shell.GENERIC, false)
 
 		flags := &runtime.Flags{
 			Config: configFile,
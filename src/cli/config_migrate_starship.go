@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/path"
+
+	"github.com/spf13/cobra"
+)
+
+// migrateStarshipCmd represents the migrate starship command
+var migrateStarshipCmd = &cobra.Command{
+	Use:   "starship",
+	Short: "Migrate a Starship config",
+	Long: `Migrate a Starship config.
+
+Translates the modules, format strings and styles of a starship.toml file into an Oh My Posh config.
+Everything that can't be translated is reported once the migration is done.
+
+When no config is specified, $STARSHIP_CONFIG or ~/.config/starship.toml is used.
+
+Example usage
+
+> oh-my-posh config migrate starship
+
+Migrates ~/.config/starship.toml and prints the result to stdout.
+
+> oh-my-posh config migrate starship --config ~/starship.toml --format toml
+
+Migrates the ~/starship.toml config file to TOML and prints the result to stdout.
+
+> oh-my-posh config migrate starship --output ~/starship.omp.json
+
+Migrates ~/.config/starship.toml and writes the result to ~/starship.omp.json.`,
+	Args: cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		configFile := starshipConfigPath()
+
+		data, err := os.ReadFile(configFile)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		cfg, warnings, err := config.MigrateStarship(data)
+		if err != nil {
+			fmt.Printf("unable to parse %s: %s\n", configFile, err)
+			os.Exit(1)
+		}
+
+		defer func() {
+			for _, warning := range warnings {
+				fmt.Fprintln(os.Stderr, "not migrated:", warning)
+			}
+		}()
+
+		if len(output) == 0 {
+			fmt.Print(cfg.Export(format))
+			return
+		}
+
+		cfg.Output = cleanOutputPath(output)
+		cfg.Write(format)
+	},
+}
+
+func starshipConfigPath() string {
+	if len(configFlag) != 0 {
+		return cleanOutputPath(configFlag)
+	}
+
+	if configFile := os.Getenv("STARSHIP_CONFIG"); len(configFile) != 0 {
+		return configFile
+	}
+
+	return filepath.Join(path.Home(), ".config", "starship.toml")
+}
+
+func init() {
+	migrateStarshipCmd.Flags().StringVarP(&format, "format", "f", "", "the config format to migrate to")
+	migrateStarshipCmd.Flags().StringVarP(&output, "output", "o", "", "config file to write the result to")
+	migrateCmd.AddCommand(migrateStarshipCmd)
+}
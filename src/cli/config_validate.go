@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+
+	"github.com/spf13/cobra"
+)
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate your config",
+	Long: `Validate your config.
+
+Reports syntax errors, unknown keys, segment types and properties, missing palette colors
+and invalid templates including their line and column. Exits with a non-zero exit code
+when the config contains problems.
+
+Example usage:
+
+> oh-my-posh config validate --config ~/myconfig.omp.json`,
+	Args: cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		configFile := config.Path(configFlag)
+		if len(configFile) == 0 {
+			// usage error
+			fmt.Println("no config file specified")
+			os.Exit(2)
+		}
+
+		problems := config.Validate(configFile)
+		for _, problem := range problems {
+			fmt.Println(problem)
+		}
+
+		if len(problems) != 0 {
+			os.Exit(1)
+		}
+
+		fmt.Println("config is valid")
+	},
+}
+
+func init() {
+	configCmd.AddCommand(validateCmd)
+}
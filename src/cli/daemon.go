@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/daemon"
+
+	"github.com/spf13/cobra"
+)
+
+var daemonPID int32
+
+// daemonCmd represents the daemon command
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run the background daemon for async segments",
+	Long: `Run the background daemon for async segments.
+
+The daemon keeps running for the current shell session and refreshes segments marked with "async": true
+in the background. The prompt renders their previous value immediately instead of waiting for them.
+
+Example usage:
+
+> oh-my-posh daemon --pid $$ &
+
+Starts the daemon in the background and stops it once the shell with the given process ID exits.`,
+	Args: cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		server := daemon.New(daemon.SocketPath())
+		server.PID = daemonPID
+
+		if err := server.Listen(); err != nil {
+			if errors.Is(err, daemon.ErrRunning) {
+				return
+			}
+
+			fmt.Println(err)
+			os.Exit(70)
+		}
+
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+		go func() {
+			<-signals
+			server.Stop()
+		}()
+
+		server.Serve()
+	},
+}
+
+func init() {
+	daemonCmd.Flags().Int32Var(&daemonPID, "pid", 0, "the shell process to follow, the daemon stops when it exits")
+	RootCmd.AddCommand(daemonCmd)
+}
@@ -17,6 +17,11 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	record string
+	redact []string
+)
+
 // debugCmd represents the prompt command
 var debugCmd = createDebugCmd()
 
@@ -41,15 +46,34 @@ func createDebugCmd() *cobra.Command {
 			cfg := config.Load(configFile, sh, false)
 
 			flags := &runtime.Flags{
-				Config: configFile,
-				Debug:  true,
-				PWD:    pwd,
-				Shell:  sh,
-				Plain:  plain,
+				Config:          configFile,
+				Debug:           true,
+				PWD:             pwd,
+				Shell:           sh,
+				Plain:           plain,
+				QueryBackground: cfg.QueryTerminalBackground,
 			}
 
-			env := &runtime.Terminal{}
-			env.Init(flags)
+			term := &runtime.Terminal{}
+			term.Init(flags)
+
+			var env runtime.Environment = term
+
+			if len(record) != 0 {
+				recorder, err := runtime.NewRecorder(term, redact)
+				if err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+
+				env = recorder
+
+				defer func() {
+					if err := recorder.Save(record); err != nil {
+						fmt.Println(err)
+					}
+				}()
+			}
 
 			template.Init(env, cfg.Var)
 
@@ -75,6 +99,8 @@ func createDebugCmd() *cobra.Command {
 
 	debugCmd.Flags().StringVar(&pwd, "pwd", "", "current working directory")
 	debugCmd.Flags().BoolVarP(&plain, "plain", "p", false, "plain text output (no ANSI)")
+	debugCmd.Flags().StringVar(&record, "record", "", "record the calls to the environment to a file for replay")
+	debugCmd.Flags().StringSliceVar(&redact, "redact", nil, "additional patterns for names of which the value is redacted from the recording")
 
 	// Deprecated flags, should be kept to avoid breaking CLI integration.
 	debugCmd.Flags().StringVar(&shellName, "shell", "", "the shell to print for")
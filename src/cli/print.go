@@ -2,6 +2,8 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"slices"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/prompt"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
@@ -26,8 +28,11 @@ var (
 	command      string
 	shellVersion string
 	plain        bool
+	printFormat  string
 	noStatus     bool
 	column       int
+	scenario     string
+	replay       string
 )
 
 // printCmd represents the prompt command
@@ -39,7 +44,7 @@ func init() {
 
 func createPrintCmd() *cobra.Command {
 	printCmd := &cobra.Command{
-		Use:   "print [debug|primary|secondary|transient|right|tooltip|valid|error]",
+		Use:   "print [debug|primary|secondary|transient|right|tooltip|valid|error|tmux-left|tmux-right|zellij-left|zellij-right]",
 		Short: "Print the prompt/context",
 		Long:  "Print one of the prompts based on the location/use-case.",
 		ValidArgs: []string{
@@ -51,6 +56,10 @@ func createPrintCmd() *cobra.Command {
 			prompt.TOOLTIP,
 			prompt.VALID,
 			prompt.ERROR,
+			prompt.TMUXLEFT,
+			prompt.TMUXRIGHT,
+			prompt.ZELLIJLEFT,
+			prompt.ZELLIJRIGHT,
 		},
 		Args: NoArgsOrOneValidArg,
 		Run: func(cmd *cobra.Command, args []string) {
@@ -59,6 +68,16 @@ func createPrintCmd() *cobra.Command {
 				return
 			}
 
+			if len(printFormat) != 0 && printFormat != "json" {
+				fmt.Printf("unsupported format: %s\n", printFormat)
+				os.Exit(2)
+			}
+
+			if printFormat == "json" && !slices.Contains([]string{prompt.PRIMARY, prompt.RIGHT, prompt.TOOLTIP}, args[0]) {
+				fmt.Printf("the json format is not supported for the %s prompt\n", args[0])
+				os.Exit(2)
+			}
+
 			flags := &runtime.Flags{
 				Config:        configFlag,
 				PWD:           pwd,
@@ -72,6 +91,7 @@ func createPrintCmd() *cobra.Command {
 				Shell:         shellName,
 				ShellVersion:  shellVersion,
 				Plain:         plain,
+				JSON:          printFormat == "json",
 				Type:          args[0],
 				Cleared:       cleared,
 				NoExitCode:    noStatus,
@@ -81,13 +101,51 @@ func createPrintCmd() *cobra.Command {
 				SaveCache:     saveCache,
 			}
 
-			eng := prompt.New(flags)
+			var eng *prompt.Engine
+
+			switch {
+			case len(scenario) != 0:
+				env, err := runtime.LoadScenario(scenario)
+				if err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+
+				env.Init(flags)
+				eng = prompt.NewWithEnvironment(flags, env)
+
+				defer printMissing("no answer in scenario:", env.Missing)
+			case len(replay) != 0:
+				env, err := runtime.LoadReplay(replay)
+				if err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+
+				env.Init(flags)
+				eng = prompt.NewWithEnvironment(flags, env)
+
+				defer printMissing("not recorded:", env.Missing)
+			default:
+				eng = prompt.New(flags)
+			}
 
 			defer func() {
 				template.SaveCache()
 				eng.Env.Close()
 			}()
 
+			if flags.JSON {
+				document, err := eng.JSON(args[0], command)
+				if err != nil {
+					fmt.Println(err)
+					return
+				}
+
+				fmt.Println(document)
+				return
+			}
+
 			switch args[0] {
 			case prompt.DEBUG:
 				fmt.Print(eng.ExtraPrompt(prompt.Debug))
@@ -105,6 +163,8 @@ func createPrintCmd() *cobra.Command {
 				fmt.Print(eng.ExtraPrompt(prompt.Valid))
 			case prompt.ERROR:
 				fmt.Print(eng.ExtraPrompt(prompt.Error))
+			case prompt.TMUXLEFT, prompt.TMUXRIGHT, prompt.ZELLIJLEFT, prompt.ZELLIJRIGHT:
+				fmt.Print(eng.StatusLine(args[0]))
 			default:
 				_ = cmd.Help()
 			}
@@ -123,14 +183,26 @@ func createPrintCmd() *cobra.Command {
 	printCmd.Flags().IntVarP(&terminalWidth, "terminal-width", "w", 0, "width of the terminal")
 	printCmd.Flags().StringVar(&command, "command", "", "tooltip command")
 	printCmd.Flags().BoolVarP(&plain, "plain", "p", false, "plain text output (no ANSI)")
+	printCmd.Flags().StringVar(&printFormat, "format", "", "output format (json)")
 	printCmd.Flags().BoolVar(&cleared, "cleared", false, "do we have a clear terminal or not")
 	printCmd.Flags().BoolVar(&eval, "eval", false, "output the prompt for eval")
 	printCmd.Flags().IntVar(&column, "column", 0, "the column position of the cursor")
 	printCmd.Flags().IntVar(&jobCount, "job-count", 0, "number of background jobs")
 	printCmd.Flags().BoolVar(&saveCache, "save-cache", false, "save updated cache to file")
+	printCmd.Flags().StringVar(&scenario, "scenario", "", "render using the canned environment of a scenario file")
+	printCmd.Flags().StringVar(&replay, "replay", "", "render using the environment recorded by debug --record")
+
+	printCmd.MarkFlagsMutuallyExclusive("scenario", "replay")
 
 	// Hide flags that are for internal use only.
 	_ = printCmd.Flags().MarkHidden("save-cache")
 
 	return printCmd
 }
+
+// printMissing lists the requests the canned environment had no answer for.
+func printMissing(prefix string, missing func() []string) {
+	for _, request := range missing() {
+		fmt.Fprintln(os.Stderr, prefix, request)
+	}
+}
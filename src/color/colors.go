@@ -13,8 +13,6 @@ import (
 	"github.com/jandedobbeleer/oh-my-posh/src/template"
 )
 
-var TrueColor = true
-
 // String is the interface that wraps ToColor method.
 //
 // ToColor gets the ANSI color code for a given color string.
@@ -189,12 +187,9 @@ func (d *Defaults) SetAccentColor(env runtime.Environment, defaultColor Ansi) {
 		return
 	}
 
-	foreground := color.RGB(rgb.R, rgb.G, rgb.B, false)
-	background := color.RGB(rgb.R, rgb.G, rgb.B, true)
-
 	d.accent = &Set{
-		Foreground: Ansi(foreground.String()),
-		Background: Ansi(background.String()),
+		Foreground: TerminalProfile.ansi(*rgb, false),
+		Background: TerminalProfile.ansi(*rgb, true),
 	}
 
 	env.Session().Set("accent_color", d.accent.String(), cache.INFINITE)
@@ -271,17 +266,11 @@ func (d *Defaults) ToAnsi(ansiColor Ansi, isBackground bool) Ansi {
 			return emptyColor
 		}
 
-		c256 := color.C256(uint8(val), isBackground)
-		return Ansi(c256.String())
+		return TerminalProfile.index(uint8(val), isBackground)
 	}
 
-	style := color.HEX(colorString, isBackground)
-	if !style.IsEmpty() {
-		if TrueColor {
-			return Ansi(style.String())
-		}
-
-		return Ansi(style.C256().String())
+	if rgb := color.HexToRgb(colorString); len(rgb) == 3 {
+		return TerminalProfile.ansi(RGB{uint8(rgb[0]), uint8(rgb[1]), uint8(rgb[2])}, isBackground)
 	}
 
 	if colorInt, err := strconv.ParseInt(colorString, 10, 8); err == nil {
@@ -348,6 +337,7 @@ type Cached struct {
 
 type cachedColorKey struct {
 	colorString  Ansi
+	profile      Profile
 	isBackground bool
 }
 
@@ -355,7 +345,7 @@ func (c *Cached) ToAnsi(colorString Ansi, isBackground bool) Ansi {
 	if c.colorCache == nil {
 		c.colorCache = make(map[cachedColorKey]Ansi)
 	}
-	key := cachedColorKey{colorString, isBackground}
+	key := cachedColorKey{colorString, TerminalProfile, isBackground}
 	if ansiColor, hit := c.colorCache[key]; hit {
 		return ansiColor
 	}
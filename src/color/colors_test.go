@@ -17,8 +17,8 @@ func TestGetAnsiFromColorString(t *testing.T) {
 		Case       string
 		Expected   Ansi
 		Color      Ansi
+		Profile    Profile
 		Background bool
-		Color256   bool
 	}{
 		{Case: "256 color", Expected: Ansi("38;5;99"), Color: "99", Background: false},
 		{Case: "256 color", Expected: Ansi("38;5;122"), Color: "122", Background: false},
@@ -30,11 +30,21 @@ func TestGetAnsiFromColorString(t *testing.T) {
 		{Case: "Base 8 background", Expected: Ansi("41"), Color: "red", Background: true},
 		{Case: "Base 16 foreground", Expected: Ansi("91"), Color: "lightRed", Background: false},
 		{Case: "Base 16 backround", Expected: Ansi("101"), Color: "lightRed", Background: true},
-		{Case: "Non true color TERM", Expected: Ansi("38;5;146"), Color: "#AABBCC", Color256: true},
+		{Case: "Non true color TERM", Expected: Ansi("38;5;146"), Color: "#AABBCC", Profile: Color256},
+		{Case: "256 color background", Expected: Ansi("48;5;196"), Color: "#FF0000", Profile: Color256, Background: true},
+		{Case: "Short hex", Expected: Ansi("38;2;255;255;255"), Color: "#FFF"},
+		{Case: "16 color hex", Expected: Ansi("94"), Color: "#6060F0", Profile: Color16},
+		{Case: "16 color hex background", Expected: Ansi("41"), Color: "#BB1010", Profile: Color16, Background: true},
+		{Case: "16 color, 256 color index", Expected: Ansi("37"), Color: "250", Profile: Color16},
+		{Case: "16 color, base index", Expected: Ansi("38;5;9"), Color: "9", Profile: Color16},
+		{Case: "16 color, color name", Expected: Ansi("91"), Color: "lightRed", Profile: Color16},
 	}
 	for _, tc := range cases {
 		ansiColors := &Defaults{}
-		TrueColor = !tc.Color256
+		TerminalProfile = TrueColor
+		if len(tc.Profile) != 0 {
+			TerminalProfile = tc.Profile
+		}
 		ansiColor := ansiColors.ToAnsi(tc.Color, tc.Background)
 		assert.Equal(t, tc.Expected, ansiColor, tc.Case)
 	}
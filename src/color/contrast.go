@@ -0,0 +1,98 @@
+package color
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/template"
+)
+
+var (
+	black = RGB{0, 0, 0}
+	white = RGB{255, 255, 255}
+)
+
+// Luminance is the relative luminance of the color as defined by WCAG 2.
+func (c RGB) Luminance() float64 {
+	channel := func(value uint8) float64 {
+		v := float64(value) / 255
+		if v <= 0.03928 {
+			return v / 12.92
+		}
+
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+
+	return 0.2126*channel(c.R) + 0.7152*channel(c.G) + 0.0722*channel(c.B)
+}
+
+// Contrast returns the WCAG 2 contrast ratio of both colors, ranging from 1 to 21.
+func Contrast(a, b RGB) float64 {
+	lighter, darker := a.Luminance(), b.Luminance()
+	if darker > lighter {
+		lighter, darker = darker, lighter
+	}
+
+	return (lighter + 0.05) / (darker + 0.05)
+}
+
+func (c RGB) String() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// ToRGB converts a hex color, a 256 color index or a color name to its RGB value.
+// Color names and the first 16 indexes use xterm's defaults, terminal themes can change those.
+func ToRGB(value Ansi) (RGB, error) {
+	colorString := value.String()
+
+	if strings.HasPrefix(colorString, "#") {
+		if rgb := color.HexToRgb(colorString); len(rgb) == 3 {
+			return RGB{uint8(rgb[0]), uint8(rgb[1]), uint8(rgb[2])}, nil
+		}
+
+		return RGB{}, fmt.Errorf("invalid hex color %s", value)
+	}
+
+	if index, err := strconv.ParseUint(colorString, 10, 8); err == nil {
+		return palette256[index], nil
+	}
+
+	codes, found := ansiColorCodes[value]
+	if !found || value == "default" {
+		return RGB{}, fmt.Errorf("color %s has no RGB value", value)
+	}
+
+	code, _ := strconv.Atoi(codes[foregroundIndex].String())
+	if code >= 90 {
+		return palette256[code-90+8], nil
+	}
+
+	return palette256[code-30], nil
+}
+
+// AutoForeground returns black or white, whichever is most readable on the background.
+// A transparent background uses the background color of the terminal.
+func AutoForeground(background, terminalBackground Ansi) Ansi {
+	if background.IsClear() {
+		background = terminalBackground
+	}
+
+	rgb, err := ToRGB(background)
+	if err != nil {
+		// without a known color we can only rely on the terminal
+		if template.TerminalBackgroundIsDark() {
+			return Ansi(white.String())
+		}
+
+		return Ansi(black.String())
+	}
+
+	if Contrast(black, rgb) >= Contrast(white, rgb) {
+		return Ansi(black.String())
+	}
+
+	return Ansi(white.String())
+}
@@ -0,0 +1,86 @@
+package color
+
+import (
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/mock"
+	"github.com/jandedobbeleer/oh-my-posh/src/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContrast(t *testing.T) {
+	cases := []struct {
+		Case       string
+		Foreground RGB
+		Background RGB
+		Expected   float64
+	}{
+		{Case: "black on white", Foreground: black, Background: white, Expected: 21},
+		{Case: "white on black", Foreground: white, Background: black, Expected: 21},
+		{Case: "same color", Foreground: RGB{128, 128, 128}, Background: RGB{128, 128, 128}, Expected: 1},
+		{Case: "gray on white", Foreground: RGB{118, 118, 118}, Background: white, Expected: 4.54},
+	}
+
+	for _, tc := range cases {
+		assert.InDelta(t, tc.Expected, Contrast(tc.Foreground, tc.Background), 0.01, tc.Case)
+	}
+}
+
+func TestToRGB(t *testing.T) {
+	cases := []struct {
+		Case        string
+		Color       Ansi
+		Expected    RGB
+		ShouldError bool
+	}{
+		{Case: "hex", Color: "#AABBCC", Expected: RGB{170, 187, 204}},
+		{Case: "short hex", Color: "#fff", Expected: white},
+		{Case: "invalid hex", Color: "#ggg", ShouldError: true},
+		{Case: "256 color", Color: "196", Expected: RGB{255, 0, 0}},
+		{Case: "grayscale", Color: "244", Expected: RGB{128, 128, 128}},
+		{Case: "color name", Color: "blue", Expected: RGB{0, 0, 238}},
+		{Case: "light color name", Color: "lightWhite", Expected: white},
+		{Case: "default", Color: "default", ShouldError: true},
+		{Case: "keyword", Color: "parentBackground", ShouldError: true},
+	}
+
+	for _, tc := range cases {
+		rgb, err := ToRGB(tc.Color)
+		if tc.ShouldError {
+			assert.Error(t, err, tc.Case)
+			continue
+		}
+
+		assert.NoError(t, err, tc.Case)
+		assert.Equal(t, tc.Expected, rgb, tc.Case)
+	}
+}
+
+func TestAutoForeground(t *testing.T) {
+	cases := []struct {
+		Case               string
+		Background         Ansi
+		TerminalBackground Ansi
+		Expected           Ansi
+	}{
+		{Case: "dark background", Background: "#1e1e2e", Expected: "#ffffff"},
+		{Case: "light background", Background: "#eff1f5", Expected: "#000000"},
+		{Case: "orange", Background: "#ff8800", Expected: "#000000"},
+		{Case: "blue", Background: "blue", Expected: "#ffffff"},
+		{Case: "transparent, light terminal", Background: Transparent, TerminalBackground: "#ffffff", Expected: "#000000"},
+		{Case: "unknown", Background: Transparent, Expected: "#ffffff"},
+	}
+
+	env := new(mock.Environment)
+	env.On("Shell").Return("pwsh")
+	env.On("TerminalBackground").Return("")
+
+	template.Cache = &cache.Template{}
+	template.Init(env, nil)
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.Expected, AutoForeground(tc.Background, tc.TerminalBackground), tc.Case)
+	}
+}
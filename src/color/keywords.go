@@ -13,6 +13,8 @@ const (
 	Background Ansi = "background"
 	// Foreground takes the current segment's foreground color
 	Foreground Ansi = "foreground"
+	// Auto picks black or white, whichever is most readable on the background
+	Auto Ansi = "auto"
 )
 
 func (color Ansi) isKeyword() bool {
@@ -0,0 +1,157 @@
+package color
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+)
+
+// Profile is the range of colors a terminal is able to display.
+type Profile string
+
+const (
+	TrueColor Profile = "truecolor"
+	Color256  Profile = "256color"
+	Color16   Profile = "16color"
+)
+
+// TerminalProfile is the profile hex colors are converted to.
+var TerminalProfile = TrueColor
+
+// xterm's default colors, the first 16 are usually altered by the terminal's theme
+var palette256 = func() (palette [256]RGB) {
+	base := []RGB{
+		{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0}, {0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+		{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0}, {92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+	}
+
+	copy(palette[:], base)
+
+	levels := []uint8{0, 95, 135, 175, 215, 255}
+
+	for i := 0; i < 216; i++ {
+		palette[16+i] = RGB{levels[i/36], levels[i/6%6], levels[i%6]}
+	}
+
+	for i := 0; i < 24; i++ {
+		gray := uint8(8 + i*10)
+		palette[232+i] = RGB{gray, gray, gray}
+	}
+
+	return
+}()
+
+// DetectProfile returns the profile of the current terminal, unless overridden by the configuration.
+func DetectProfile(env runtime.Environment, override Profile) Profile {
+	switch override {
+	case TrueColor, Color256, Color16:
+		return override
+	}
+
+	switch strings.ToLower(env.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return TrueColor
+	}
+
+	if env.Getenv("TERM_PROGRAM") == "Apple_Terminal" {
+		return Color256
+	}
+
+	term := env.Getenv("TERM")
+
+	switch {
+	case len(term) == 0, env.GOOS() == runtime.WINDOWS:
+		return TrueColor
+	case term == "linux":
+		return Color16
+	case strings.HasSuffix(term, "-direct"), strings.Contains(term, "truecolor"), strings.Contains(term, "24bit"):
+		return TrueColor
+	}
+
+	info, ok := loadTerminfo(env, term)
+	if !ok {
+		return TrueColor
+	}
+
+	switch {
+	case info.trueColor:
+		return TrueColor
+	case info.colors < 256:
+		return Color16
+	case strings.HasPrefix(term, "screen"), strings.HasPrefix(term, "tmux"):
+		// multiplexers only pass true color through when told to
+		return Color256
+	default:
+		// most terminal emulators advertising 256 colors handle true color as well
+		return TrueColor
+	}
+}
+
+// ansi converts the color to the closest one available in the profile
+func (p Profile) ansi(rgb RGB, isBackground bool) Ansi {
+	switch p {
+	case Color256:
+		return index256(nearest(rgb, palette256[16:])+16, isBackground)
+	case Color16:
+		return index16(nearest(rgb, palette256[:16]), isBackground)
+	default:
+		if isBackground {
+			return Ansi(fmt.Sprintf("48;2;%d;%d;%d", rgb.R, rgb.G, rgb.B))
+		}
+
+		return Ansi(fmt.Sprintf("38;2;%d;%d;%d", rgb.R, rgb.G, rgb.B))
+	}
+}
+
+// index converts a color from the 256 color palette to the closest one available in the profile
+func (p Profile) index(index uint8, isBackground bool) Ansi {
+	if p != Color16 || index < 16 {
+		return index256(int(index), isBackground)
+	}
+
+	return index16(nearest(palette256[index], palette256[:16]), isBackground)
+}
+
+func index256(index int, isBackground bool) Ansi {
+	if isBackground {
+		return Ansi(fmt.Sprintf("48;5;%d", index))
+	}
+
+	return Ansi(fmt.Sprintf("38;5;%d", index))
+}
+
+func index16(index int, isBackground bool) Ansi {
+	code := 30 + index
+	if index >= 8 {
+		code = 90 + index - 8
+	}
+
+	if isBackground {
+		code += 10
+	}
+
+	return Ansi(fmt.Sprintf("%d", code))
+}
+
+// nearest returns the index of the closest color in the palette,
+// using the "redmean" approximation of how we perceive the difference
+func nearest(rgb RGB, palette []RGB) int {
+	var index int
+	distance := -1
+
+	for i, candidate := range palette {
+		mean := (int(rgb.R) + int(candidate.R)) / 2
+		r := int(rgb.R) - int(candidate.R)
+		g := int(rgb.G) - int(candidate.G)
+		b := int(rgb.B) - int(candidate.B)
+
+		d := (512+mean)*r*r>>8 + 4*g*g + (767-mean)*b*b>>8
+		if distance == -1 || d < distance {
+			index = i
+			distance = d
+		}
+	}
+
+	return index
+}
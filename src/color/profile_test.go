@@ -0,0 +1,125 @@
+package color
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/mock"
+
+	"github.com/stretchr/testify/assert"
+	testify_ "github.com/stretchr/testify/mock"
+)
+
+// compileTerminfo creates a legacy terminfo entry with the number of colors and extended booleans
+func compileTerminfo(colors int16, extBools ...string) string {
+	var data []byte
+
+	put := func(values ...int16) {
+		for _, value := range values {
+			data = binary.LittleEndian.AppendUint16(data, uint16(value))
+		}
+	}
+
+	var table []byte
+	for _, name := range extBools {
+		table = append(table, name...)
+		table = append(table, 0)
+	}
+
+	put(terminfoMagic, 5, 0, terminfoColors+1, 0, 0)
+	data = append(data, "test\x00"...)
+	data = append(data, 0)
+
+	for i := 0; i < terminfoColors; i++ {
+		put(-1)
+	}
+
+	put(colors)
+
+	if len(extBools) == 0 {
+		return string(data)
+	}
+
+	put(int16(len(extBools)), 0, 0, int16(len(extBools)), int16(len(table)))
+
+	for range extBools {
+		data = append(data, 1)
+	}
+
+	if len(extBools)%2 != 0 {
+		data = append(data, 0)
+	}
+
+	var offset int16
+	for _, name := range extBools {
+		put(offset)
+		offset += int16(len(name) + 1)
+	}
+
+	data = append(data, table...)
+
+	return string(data)
+}
+
+func TestDetectProfile(t *testing.T) {
+	cases := []struct {
+		Case      string
+		Override  Profile
+		ColorTerm string
+		Program   string
+		Term      string
+		GOOS      string
+		Terminfo  string
+		Expected  Profile
+	}{
+		{Case: "Override", Override: Color16, ColorTerm: "truecolor", Expected: Color16},
+		{Case: "Invalid override", Override: "8color", ColorTerm: "truecolor", Expected: TrueColor},
+		{Case: "COLORTERM", ColorTerm: "24bit", Term: "linux", Expected: TrueColor},
+		{Case: "Apple Terminal", Program: "Apple_Terminal", Term: "xterm-256color", Expected: Color256},
+		{Case: "No TERM", Expected: TrueColor},
+		{Case: "Windows", Term: "xterm", GOOS: runtime.WINDOWS, Expected: TrueColor},
+		{Case: "Linux console", Term: "linux", Expected: Color16},
+		{Case: "Direct color TERM", Term: "xterm-direct", Expected: TrueColor},
+		{Case: "Unknown TERM", Term: "unknown", Expected: TrueColor},
+		{Case: "Serial console", Term: "vt100", Terminfo: compileTerminfo(-1), Expected: Color16},
+		{Case: "8 colors", Term: "xterm", Terminfo: compileTerminfo(8), Expected: Color16},
+		{Case: "256 colors", Term: "xterm-256color", Terminfo: compileTerminfo(256), Expected: TrueColor},
+		{Case: "tmux", Term: "tmux-256color", Terminfo: compileTerminfo(256), Expected: Color256},
+		{Case: "tmux with Tc", Term: "tmux-256color", Terminfo: compileTerminfo(256, "AX", "Tc", "XT"), Expected: TrueColor},
+		{Case: "screen with RGB", Term: "screen-256color", Terminfo: compileTerminfo(256, "RGB"), Expected: TrueColor},
+	}
+
+	for _, tc := range cases {
+		env := new(mock.Environment)
+		env.On("Getenv", "COLORTERM").Return(tc.ColorTerm)
+		env.On("Getenv", "TERM_PROGRAM").Return(tc.Program)
+		env.On("Getenv", "TERM").Return(tc.Term)
+		env.On("Getenv", testify_.Anything).Return("")
+		env.On("GOOS").Return(tc.GOOS)
+		env.On("Home").Return("/home/jan")
+
+		if len(tc.Term) != 0 {
+			env.On("FileContent", "/usr/share/terminfo/"+tc.Term[:1]+"/"+tc.Term).Return(tc.Terminfo)
+		}
+
+		env.On("FileContent", testify_.Anything).Return("")
+
+		assert.Equal(t, tc.Expected, DetectProfile(env, tc.Override), tc.Case)
+	}
+}
+
+func TestParseTerminfo(t *testing.T) {
+	_, ok := parseTerminfo([]byte("not a terminfo entry"))
+	assert.False(t, ok)
+
+	info, ok := parseTerminfo([]byte(compileTerminfo(88, "Tc")))
+	assert.True(t, ok)
+	assert.Equal(t, &terminfo{colors: 88, trueColor: true}, info)
+
+	// a negative names size must not panic
+	corrupt := []byte{0x1a, 0x01, 0x9c, 0xff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	info, ok = parseTerminfo(corrupt)
+	assert.False(t, ok)
+	assert.Nil(t, info)
+}
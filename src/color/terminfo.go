@@ -0,0 +1,177 @@
+package color
+
+import (
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+)
+
+const (
+	// magic numbers of the legacy and the extended number format of compiled terminfo entries
+	terminfoMagic   = 0o432
+	terminfoMagic32 = 0o1036
+
+	// position of the max_colors capability in the numbers section
+	terminfoColors = 13
+)
+
+type terminfo struct {
+	colors    int
+	trueColor bool
+}
+
+// loadTerminfo looks up the compiled terminfo entry of the terminal in the same locations as ncurses
+func loadTerminfo(env runtime.Environment, term string) (*terminfo, bool) {
+	if len(term) == 0 || strings.ContainsAny(term, `/\`) {
+		return nil, false
+	}
+
+	var directories []string
+
+	if dir := env.Getenv("TERMINFO"); len(dir) != 0 {
+		directories = append(directories, dir)
+	}
+
+	directories = append(directories, filepath.Join(env.Home(), ".terminfo"))
+
+	defaults := []string{"/etc/terminfo", "/lib/terminfo", "/usr/share/terminfo", "/usr/lib/terminfo"}
+
+	if dirs := env.Getenv("TERMINFO_DIRS"); len(dirs) != 0 {
+		for _, dir := range strings.Split(dirs, ":") {
+			if len(dir) == 0 {
+				directories = append(directories, defaults...)
+				continue
+			}
+
+			directories = append(directories, dir)
+		}
+	} else {
+		directories = append(directories, defaults...)
+	}
+
+	for _, dir := range directories {
+		// macOS uses the hexadecimal value of the first character as the directory name
+		for _, sub := range []string{term[:1], fmt.Sprintf("%x", term[0])} {
+			content := env.FileContent(filepath.Join(dir, sub, term))
+			if len(content) == 0 {
+				continue
+			}
+
+			if info, ok := parseTerminfo([]byte(content)); ok {
+				return info, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// parseTerminfo reads the number of colors and the true color extensions (Tc or RGB)
+// from a compiled terminfo entry, as described in term(5)
+func parseTerminfo(data []byte) (*terminfo, bool) {
+	if len(data) < 12 {
+		return nil, false
+	}
+
+	short := func(offset int) int {
+		if offset < 0 || offset+2 > len(data) {
+			return -1
+		}
+
+		return int(int16(binary.LittleEndian.Uint16(data[offset:])))
+	}
+
+	numberSize := 2
+
+	switch short(0) {
+	case terminfoMagic:
+	case terminfoMagic32:
+		numberSize = 4
+	default:
+		return nil, false
+	}
+
+	number := func(offset int) int {
+		if offset < 0 || offset+numberSize > len(data) {
+			return -1
+		}
+
+		if numberSize == 4 {
+			return int(int32(binary.LittleEndian.Uint32(data[offset:])))
+		}
+
+		return short(offset)
+	}
+
+	namesSize, boolCount, numberCount, stringCount, tableSize := short(2), short(4), short(6), short(8), short(10)
+	if namesSize < 0 || boolCount < 0 || numberCount < 0 || stringCount < 0 || tableSize < 0 {
+		return nil, false
+	}
+
+	offset := 12 + namesSize + boolCount
+	offset += offset % 2
+
+	info := &terminfo{colors: -1}
+
+	if numberCount > terminfoColors {
+		info.colors = number(offset + terminfoColors*numberSize)
+	}
+
+	// the extended capabilities follow the string table
+	offset += numberCount*numberSize + stringCount*2 + tableSize
+	offset += offset % 2
+
+	extBoolCount, extNumberCount, extStringCount := short(offset), short(offset+2), short(offset+4)
+	if extBoolCount < 0 || extNumberCount < 0 || extStringCount < 0 {
+		return info, true
+	}
+
+	extOffset := offset + 10
+	boolsOffset := extOffset
+
+	extOffset += extBoolCount
+	extOffset += extOffset % 2
+	numbersOffset := extOffset
+
+	extOffset += extNumberCount*numberSize + (extStringCount+extBoolCount+extNumberCount+extStringCount)*2
+	if extOffset < 0 || extOffset > len(data) {
+		return info, true
+	}
+
+	// the names of the extended capabilities are the last strings in the table,
+	// in the same order as the booleans, numbers and strings they belong to
+	table := strings.Split(strings.TrimSuffix(string(data[extOffset:]), "\x00"), "\x00")
+	nameCount := extBoolCount + extNumberCount + extStringCount
+
+	if len(table) < nameCount {
+		return info, true
+	}
+
+	names := table[len(table)-nameCount:]
+
+	for i, name := range names[:extBoolCount] {
+		if boolsOffset+i >= len(data) {
+			break
+		}
+
+		if (name == "Tc" || name == "RGB") && data[boolsOffset+i] == 1 {
+			info.trueColor = true
+		}
+	}
+
+	for i, name := range names[extBoolCount : extBoolCount+extNumberCount] {
+		if name == "RGB" && number(numbersOffset+i*numberSize) > 0 {
+			info.trueColor = true
+		}
+	}
+
+	// direct color terminals advertise the full range of colors
+	if info.colors >= 1<<24 {
+		info.trueColor = true
+	}
+
+	return info, true
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"encoding/json"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+)
+
+type asyncValue struct {
+	data    string
+	enabled bool
+}
+
+// SetAsyncValue sets the value the daemon computed in the background
+// for an async segment, which is restored instead of executing the segment.
+func (segment *Segment) SetAsyncValue(data string, enabled bool) {
+	segment.asyncValue = &asyncValue{
+		data:    data,
+		enabled: enabled,
+	}
+}
+
+// AsyncValue returns the state of an executed segment so it can be restored
+// in another process using SetAsyncValue.
+func (segment *Segment) AsyncValue() (string, bool) {
+	if !segment.Enabled {
+		return "", false
+	}
+
+	data, err := json.Marshal(segment.writer)
+	if err != nil {
+		log.Error(err)
+		return "", false
+	}
+
+	return string(data), true
+}
+
+func (segment *Segment) restoreAsync() bool {
+	if !segment.Async || segment.asyncValue == nil {
+		return false
+	}
+
+	log.Debug("restored segment from daemon: ", segment.Name())
+
+	if !segment.asyncValue.enabled {
+		segment.restored = true
+		return true
+	}
+
+	segment.restore(segment.asyncValue.data)
+
+	return true
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/maps"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments"
+	"github.com/jandedobbeleer/oh-my-posh/src/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestoreAsync(t *testing.T) {
+	cases := []struct {
+		Case            string
+		Data            string
+		Async           bool
+		HasValue        bool
+		Enabled         bool
+		Expected        bool
+		ExpectedEnabled bool
+	}{
+		{Case: "Not async", HasValue: true, Enabled: true},
+		{Case: "No value", Async: true},
+		{Case: "Disabled value", Async: true, HasValue: true, Expected: true},
+		{Case: "Enabled value", Async: true, HasValue: true, Enabled: true, Data: `{"Profile":"posh"}`, Expected: true, ExpectedEnabled: true},
+	}
+
+	for _, tc := range cases {
+		template.Cache = &cache.Template{
+			Segments: maps.NewConcurrent(),
+		}
+
+		segment := &Segment{
+			Type:   AWS,
+			Async:  tc.Async,
+			writer: &segments.Aws{},
+		}
+
+		if tc.HasValue {
+			segment.SetAsyncValue(tc.Data, tc.Enabled)
+		}
+
+		got := segment.restoreAsync()
+		assert.Equal(t, tc.Expected, got, tc.Case)
+		assert.Equal(t, tc.ExpectedEnabled, segment.Enabled, tc.Case)
+
+		if !tc.ExpectedEnabled {
+			continue
+		}
+
+		value, enabled := segment.AsyncValue()
+		assert.True(t, enabled, tc.Case)
+		assert.Contains(t, value, `"Profile":"posh"`, tc.Case)
+	}
+}
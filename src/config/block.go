@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 // BlockType type of block
 type BlockType string
 
@@ -14,6 +16,8 @@ const (
 	Prompt BlockType = "prompt"
 	// RPrompt is a right aligned prompt
 	RPrompt BlockType = "rprompt"
+	// Status is rendered in the status line of a terminal multiplexer
+	Status BlockType = "status"
 	// Left aligns left
 	Left BlockAlignment = "left"
 	// Right aligns right
@@ -22,6 +26,8 @@ const (
 	Break Overflow = "break"
 	// Hide hides the block
 	Hide Overflow = "hide"
+	// Fit drops or truncates the lowest priority segments until the blocks fit on the line
+	Fit Overflow = "fit"
 )
 
 // Block defines a part of the prompt with optional segments
@@ -33,6 +39,7 @@ type Block struct {
 	LeadingDiamond  string         `json:"leading_diamond,omitempty" toml:"leading_diamond,omitempty"`
 	TrailingDiamond string         `json:"trailing_diamond,omitempty" toml:"trailing_diamond,omitempty"`
 	Segments        []*Segment     `json:"segments,omitempty" toml:"segments,omitempty"`
+	Duration        time.Duration  `json:"-" toml:"-"`
 	MaxWidth        int            `json:"max_width,omitempty" toml:"max_width,omitempty"`
 	MinWidth        int            `json:"min_width,omitempty" toml:"min_width,omitempty"`
 	Newline         bool           `json:"newline,omitempty" toml:"newline,omitempty"`
@@ -18,6 +18,9 @@ const (
 	AUTOUPGRADE   = "upgrade"
 	UPGRADENOTICE = "notice"
 
+	darkPalette  = "dark"
+	lightPalette = "light"
+
 	Version = 3
 )
 
@@ -33,8 +36,11 @@ type Config struct {
 	ErrorLine               *Segment        `json:"error_line,omitempty" toml:"error_line,omitempty"`
 	TerminalBackground      color.Ansi      `json:"terminal_background,omitempty" toml:"terminal_background,omitempty"`
 	origin                  string
+	keys                    map[string]bool
+	extended                bool
 	PWD                     string                 `json:"pwd,omitempty" toml:"pwd,omitempty"`
 	AccentColor             color.Ansi             `json:"accent_color,omitempty" toml:"accent_color,omitempty"`
+	ColorProfile            color.Profile          `json:"color_profile,omitempty" toml:"color_profile,omitempty"`
 	Output                  string                 `json:"-" toml:"-"`
 	ConsoleTitleTemplate    string                 `json:"console_title_template,omitempty" toml:"console_title_template,omitempty"`
 	Format                  string                 `json:"-" toml:"-"`
@@ -43,12 +49,15 @@ type Config struct {
 	ITermFeatures           terminal.ITermFeatures `json:"iterm_features,omitempty" toml:"iterm_features,omitempty"`
 	Blocks                  []*Block               `json:"blocks,omitempty" toml:"blocks,omitempty"`
 	Tooltips                []*Segment             `json:"tooltips,omitempty" toml:"tooltips,omitempty"`
+	Extends                 []string               `json:"extends,omitempty" toml:"extends,omitempty"`
 	Version                 int                    `json:"version" toml:"version"`
+	SegmentTimeout          int                    `json:"segment_timeout,omitempty" toml:"segment_timeout,omitempty"`
 	AutoUpgrade             bool                   `json:"-" toml:"-"`
 	ShellIntegration        bool                   `json:"shell_integration,omitempty" toml:"shell_integration,omitempty"`
 	MigrateGlyphs           bool                   `json:"-" toml:"-"`
 	PatchPwshBleed          bool                   `json:"patch_pwsh_bleed,omitempty" toml:"patch_pwsh_bleed,omitempty"`
 	EnableCursorPositioning bool                   `json:"enable_cursor_positioning,omitempty" toml:"enable_cursor_positioning,omitempty"`
+	QueryTerminalBackground bool                   `json:"query_terminal_background,omitempty" toml:"query_terminal_background,omitempty"`
 	updated                 bool
 	FinalSpace              bool `json:"final_space,omitempty" toml:"final_space,omitempty"`
 	UpgradeNotice           bool `json:"-" toml:"-"`
@@ -56,6 +65,7 @@ type Config struct {
 
 func (cfg *Config) MakeColors(env runtime.Environment) color.String {
 	cacheDisabled := env.Getenv("OMP_CACHE_DISABLED") == "1"
+	color.TerminalProfile = color.DetectProfile(env, cfg.ColorProfile)
 	return color.MakeColors(cfg.getPalette(), !cacheDisabled, cfg.AccentColor, env)
 }
 
@@ -64,13 +74,23 @@ func (cfg *Config) getPalette() color.Palette {
 		return cfg.Palette
 	}
 
-	tmpl := &template.Text{
-		Template: cfg.Palettes.Template,
-	}
+	var key string
 
-	key, err := tmpl.Render()
-	if err != nil {
-		return cfg.Palette
+	switch {
+	case len(cfg.Palettes.Template) != 0:
+		tmpl := &template.Text{
+			Template: cfg.Palettes.Template,
+		}
+
+		var err error
+		if key, err = tmpl.Render(); err != nil {
+			return cfg.Palette
+		}
+	case cfg.Palettes.List[lightPalette] == nil, template.TerminalBackgroundIsDark():
+		// without a template, pick the variant matching the background of the terminal
+		key = darkPalette
+	default:
+		key = lightPalette
 	}
 
 	palette, ok := cfg.Palettes.List[key]
@@ -130,6 +150,8 @@ func (cfg *Config) Features(env runtime.Environment) shell.Features {
 		feats = append(feats, shell.PromptMark)
 	}
 
+	var async bool
+
 	for i, block := range cfg.Blocks {
 		if (i == 0 && block.Newline) && cfg.EnableCursorPositioning {
 			feats = append(feats, shell.CursorPositioning)
@@ -140,6 +162,8 @@ func (cfg *Config) Features(env runtime.Environment) shell.Features {
 		}
 
 		for _, segment := range block.Segments {
+			async = async || segment.Async
+
 			if segment.Type == AZ {
 				source := segment.Properties.GetString(segments.Source, segments.FirstMatch)
 				if source == segments.Pwsh || source == segments.FirstMatch {
@@ -156,5 +180,9 @@ func (cfg *Config) Features(env runtime.Environment) shell.Features {
 		}
 	}
 
+	if async {
+		feats = append(feats, shell.Daemon)
+	}
+
 	return feats
 }
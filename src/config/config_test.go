@@ -17,11 +17,17 @@ func TestGetPalette(t *testing.T) {
 		"blue": "#0000ff",
 	}
 
+	light := color.Palette{
+		"red":  "#aa0000",
+		"blue": "#0000aa",
+	}
+
 	cases := []struct {
-		Palettes        *color.Palettes
-		Palette         color.Palette
-		ExpectedPalette color.Palette
-		Case            string
+		Palettes           *color.Palettes
+		Palette            color.Palette
+		ExpectedPalette    color.Palette
+		Case               string
+		TerminalBackground string
 	}{
 		{
 			Case: "match",
@@ -87,11 +93,44 @@ func TestGetPalette(t *testing.T) {
 				"yellow": "#ffff00",
 			},
 		},
+		{
+			Case:               "no template, light background",
+			TerminalBackground: "#fafafa",
+			Palettes: &color.Palettes{
+				List: map[string]color.Palette{
+					"dark":  palette,
+					"light": light,
+				},
+			},
+			ExpectedPalette: light,
+		},
+		{
+			Case:               "no template, dark background",
+			TerminalBackground: "#1e1e2e",
+			Palettes: &color.Palettes{
+				List: map[string]color.Palette{
+					"dark":  palette,
+					"light": light,
+				},
+			},
+			ExpectedPalette: palette,
+		},
+		{
+			Case: "no template, unknown background",
+			Palettes: &color.Palettes{
+				List: map[string]color.Palette{
+					"dark":  palette,
+					"light": light,
+				},
+			},
+			ExpectedPalette: palette,
+		},
 	}
 
 	for _, tc := range cases {
 		env := &mock.Environment{}
 		env.On("Shell").Return("bash")
+		env.On("TerminalBackground").Return(tc.TerminalBackground)
 
 		template.Cache = &cache.Template{
 			Shell: "bash",
@@ -0,0 +1,322 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/path"
+)
+
+// extend returns the config merged on top of the configs it extends
+func (cfg *Config) extend() *Config {
+	defer log.Trace(time.Now(), cfg.origin)
+
+	return cfg.flatten(cfg.origin, map[string]bool{cfg.origin: true})
+}
+
+// flatten merges the configs listed in Extends in order, followed by the config itself.
+// Visited holds the configs currently being resolved to break circular references.
+func (cfg *Config) flatten(name string, visited map[string]bool) *Config {
+	cfg.setOrigin(name)
+
+	var result *Config
+
+	for _, entry := range cfg.Extends {
+		configPath, err := cfg.resolveExtends(entry)
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+
+		if visited[configPath] {
+			log.Error(fmt.Errorf("circular extends in %s: %s", cfg.origin, entry))
+			continue
+		}
+
+		base, err := readConfig(configPath, true)
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+
+		if base.Version < Version {
+			base.Migrate()
+		}
+
+		// show the URL instead of the downloaded file
+		baseName := configPath
+		if strings.HasPrefix(entry, "https://") {
+			baseName = entry
+		}
+
+		visited[configPath] = true
+		base = base.flatten(baseName, visited)
+		delete(visited, configPath)
+
+		if result == nil {
+			result = base
+			continue
+		}
+
+		result.merge(base)
+	}
+
+	if result == nil {
+		return cfg
+	}
+
+	result.merge(cfg)
+	result.origin = cfg.origin
+	result.Extends = nil
+
+	return result
+}
+
+func (cfg *Config) resolveExtends(entry string) (string, error) {
+	if strings.HasPrefix(entry, "https://") {
+		return Download(cache.Path(), entry)
+	}
+
+	entry = path.ReplaceTildePrefixWithHomeDir(entry)
+	if filepath.IsAbs(entry) {
+		return filepath.Clean(entry), nil
+	}
+
+	// relative paths start from the folder of the config that extends them
+	return filepath.Join(filepath.Dir(cfg.origin), entry), nil
+}
+
+func (cfg *Config) setOrigin(name string) {
+	set := func(segment *Segment) {
+		if len(segment.origins) == 0 {
+			segment.origins = []string{name}
+		}
+	}
+
+	for _, block := range cfg.Blocks {
+		for _, segment := range block.Segments {
+			set(segment)
+		}
+	}
+
+	for _, tooltip := range cfg.Tooltips {
+		set(tooltip)
+	}
+}
+
+// merge applies the settings of override on top of the config. Maps are merged key by key,
+// segments with an alias that already exists are merged into that segment, other segments are added.
+func (cfg *Config) merge(override *Config) {
+	palette := mergeMap(cfg.Palette, override.Palette)
+	variables := mergeMap(cfg.Var, override.Var)
+	palettes := mergePalettes(cfg.Palettes, override.Palettes)
+	blocks := mergeBlocks(cfg.Blocks, override.Blocks)
+	tooltips := mergeSegments(cfg.Tooltips, override.Tooltips)
+
+	setFields(cfg, override, override.keys)
+
+	cfg.keys = mergeMap(cfg.keys, override.keys)
+	cfg.Palette = palette
+	cfg.Var = variables
+	cfg.Palettes = palettes
+	cfg.Blocks = blocks
+	cfg.Tooltips = tooltips
+}
+
+func (segment *Segment) merge(override *Segment) {
+	props := mergeMap(segment.Properties, override.Properties)
+
+	setFields(segment, override, override.keys)
+
+	segment.keys = mergeMap(segment.keys, override.keys)
+	segment.Properties = props
+	segment.origins = append(segment.origins, override.origins...)
+}
+
+// Origins returns the config files the segment is defined in when the config extends other configs
+func (segment *Segment) Origins() []string {
+	return segment.origins
+}
+
+func mergeBlocks(base, override []*Block) []*Block {
+	result := slices.Clone(base)
+
+	findSegment := func(alias string) *Segment {
+		for _, block := range result {
+			if segment := findAlias(block.Segments, alias); segment != nil {
+				return segment
+			}
+		}
+
+		return nil
+	}
+
+	for _, block := range override {
+		var added []*Segment
+
+		for _, segment := range block.Segments {
+			if existing := findSegment(segment.Alias); existing != nil {
+				existing.merge(segment)
+				continue
+			}
+
+			added = append(added, segment)
+		}
+
+		// the block only contained changes to existing segments
+		if len(added) == 0 && len(block.Segments) != 0 {
+			continue
+		}
+
+		block.Segments = added
+		result = append(result, block)
+	}
+
+	return result
+}
+
+func mergeSegments(base, override []*Segment) []*Segment {
+	result := slices.Clone(base)
+
+	for _, segment := range override {
+		if existing := findAlias(result, segment.Alias); existing != nil {
+			existing.merge(segment)
+			continue
+		}
+
+		result = append(result, segment)
+	}
+
+	return result
+}
+
+func findAlias(segments []*Segment, alias string) *Segment {
+	if len(alias) == 0 {
+		return nil
+	}
+
+	for _, segment := range segments {
+		if segment.Alias == alias {
+			return segment
+		}
+	}
+
+	return nil
+}
+
+func mergePalettes(base, override *color.Palettes) *color.Palettes {
+	if base == nil {
+		return override
+	}
+
+	if override == nil {
+		return base
+	}
+
+	result := &color.Palettes{
+		Template: base.Template,
+		List:     make(map[string]color.Palette),
+	}
+
+	if len(override.Template) != 0 {
+		result.Template = override.Template
+	}
+
+	for name, palette := range base.List {
+		result.List[name] = palette
+	}
+
+	for name, palette := range override.List {
+		result.List[name] = mergeMap(result.List[name], palette)
+	}
+
+	return result
+}
+
+func mergeMap[M ~map[K]V, K comparable, V any](base, override M) M {
+	if base == nil {
+		return override
+	}
+
+	if override == nil {
+		return base
+	}
+
+	result := make(M, len(base)+len(override))
+
+	for key, value := range base {
+		result[key] = value
+	}
+
+	for key, value := range override {
+		result[key] = value
+	}
+
+	return result
+}
+
+// setFields copies all exported fields that are set in override,
+// either to a non-zero value or explicitly through one of the keys
+func setFields[T any](dst, override *T, keys map[string]bool) {
+	destination := reflect.ValueOf(dst).Elem()
+	source := reflect.ValueOf(override).Elem()
+
+	for i := 0; i < destination.NumField(); i++ {
+		field := destination.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if source.Field(i).IsZero() && !keys[key] {
+			continue
+		}
+
+		destination.Field(i).Set(source.Field(i))
+	}
+}
+
+// setKeys stores the keys of the raw config on the config and its segments
+func (cfg *Config) setKeys(raw map[string]any) {
+	cfg.keys = keySet(raw)
+
+	setSegmentKeys := func(segments []*Segment, value any) {
+		items, _ := value.([]any)
+		for i, item := range items {
+			if i >= len(segments) || segments[i] == nil {
+				break
+			}
+
+			fields, _ := item.(map[string]any)
+			segments[i].keys = keySet(fields)
+		}
+	}
+
+	blocks, _ := raw["blocks"].([]any)
+	for i, block := range blocks {
+		if i >= len(cfg.Blocks) || cfg.Blocks[i] == nil {
+			break
+		}
+
+		fields, _ := block.(map[string]any)
+		setSegmentKeys(cfg.Blocks[i].Segments, fields["segments"])
+	}
+
+	setSegmentKeys(cfg.Tooltips, raw["tooltips"])
+}
+
+func keySet(fields map[string]any) map[string]bool {
+	keys := make(map[string]bool, len(fields))
+	for key := range fields {
+		keys[key] = true
+	}
+
+	return keys
+}
@@ -0,0 +1,220 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/properties"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtends(t *testing.T) {
+	folder := t.TempDir()
+
+	writeConfig := func(name, content string) string {
+		configFile := filepath.Join(folder, name)
+		err := os.MkdirAll(filepath.Dir(configFile), 0755)
+		assert.NoError(t, err)
+		err = os.WriteFile(configFile, []byte(content), 0644)
+		assert.NoError(t, err)
+		return configFile
+	}
+
+	base := writeConfig("team/base.omp.json", `{
+  "version": 3,
+  "extends": ["../mine.omp.toml"],
+  "final_space": true,
+  "palette": { "fg": "#ffffff", "bg": "#000000" },
+  "var": { "team": "platform" },
+  "blocks": [
+    {
+      "type": "prompt",
+      "alignment": "left",
+      "segments": [
+        { "type": "path", "alias": "Path", "foreground": "p:fg", "properties": { "style": "folder", "folder_icon": "F" } },
+        { "type": "text", "template": "{{ .Var.team }}" }
+      ]
+    }
+  ],
+  "tooltips": [
+    { "type": "git", "alias": "Git", "tips": ["git"] }
+  ]
+}`)
+
+	configFile := writeConfig("mine.omp.toml", `version = 3
+extends = ["team/base.omp.json", "missing.omp.json"]
+
+[palette]
+  fg = "#ff0000"
+
+[var]
+  me = "jan"
+
+[[blocks]]
+  type = "prompt"
+
+  [[blocks.segments]]
+    alias = "Path"
+
+    [blocks.segments.properties]
+      style = "full"
+
+[[blocks]]
+  type = "rprompt"
+
+  [[blocks.segments]]
+    type = "text"
+    template = "{{ .Var.me }}"
+
+[[tooltips]]
+  alias = "Git"
+  template = "{{ .HEAD }}"
+`)
+
+	cfg := Load(configFile, "bash", true)
+
+	assert.Empty(t, cfg.Extends)
+	assert.True(t, cfg.FinalSpace)
+	assert.Equal(t, TOML, cfg.Format)
+	assert.Equal(t, color.Palette{"fg": "#ff0000", "bg": "#000000"}, cfg.Palette)
+	assert.Equal(t, map[string]any{"team": "platform", "me": "jan"}, cfg.Var)
+
+	assert.Len(t, cfg.Blocks, 2)
+	assert.Equal(t, Left, cfg.Blocks[0].Alignment)
+	assert.Len(t, cfg.Blocks[0].Segments, 2)
+
+	path := cfg.Blocks[0].Segments[0]
+	assert.Equal(t, PATH, path.Type)
+	assert.Equal(t, color.Ansi("p:fg"), path.Foreground)
+	assert.Equal(t, properties.Map{"style": "full", "folder_icon": "F"}, path.Properties)
+	assert.Equal(t, []string{base, configFile}, path.Origins())
+	assert.Equal(t, []string{base}, cfg.Blocks[0].Segments[1].Origins())
+
+	assert.Equal(t, RPrompt, cfg.Blocks[1].Type)
+	assert.Equal(t, []string{configFile}, cfg.Blocks[1].Segments[0].Origins())
+
+	assert.Len(t, cfg.Tooltips, 1)
+	assert.Equal(t, GIT, cfg.Tooltips[0].Type)
+	assert.Equal(t, "{{ .HEAD }}", cfg.Tooltips[0].Template)
+	assert.Equal(t, []string{"git"}, cfg.Tooltips[0].Tips)
+
+	file := LoadFile(configFile, "bash", true)
+	assert.Equal(t, []string{"team/base.omp.json", "missing.omp.json"}, file.Extends)
+	assert.Len(t, file.Blocks, 2)
+	assert.Nil(t, file.Blocks[0].Segments[0].Origins())
+}
+
+func TestExtendsZeroValues(t *testing.T) {
+	folder := t.TempDir()
+
+	base := filepath.Join(folder, "base.omp.json")
+	err := os.WriteFile(base, []byte(`{
+  "version": 3,
+  "final_space": true,
+  "console_title_template": "{{ .Folder }}",
+  "blocks": [
+    {
+      "type": "prompt",
+      "alignment": "left",
+      "segments": [
+        { "type": "text", "alias": "Text", "interactive": true, "template": "base" }
+      ]
+    }
+  ]
+}`), 0644)
+	assert.NoError(t, err)
+
+	configFile := filepath.Join(folder, "mine.omp.yaml")
+	err = os.WriteFile(configFile, []byte(`version: 3
+extends:
+  - base.omp.json
+final_space: false
+console_title_template: ""
+blocks:
+  - type: prompt
+    segments:
+      - alias: Text
+        interactive: false
+`), 0644)
+	assert.NoError(t, err)
+
+	cfg := Load(configFile, "bash", true)
+
+	assert.False(t, cfg.FinalSpace)
+	assert.Empty(t, cfg.ConsoleTitleTemplate)
+	assert.Len(t, cfg.Blocks, 1)
+
+	segment := cfg.Blocks[0].Segments[0]
+	assert.False(t, segment.Interactive)
+	assert.Equal(t, "base", segment.Template)
+}
+
+func TestReadConfigKeys(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Content  string
+		Extended bool
+		Expected bool
+	}{
+		{Case: "Plain config", Content: `{ "final_space": false }`},
+		{Case: "Extends", Content: `{ "extends": ["base.omp.json"], "final_space": false }`, Expected: true},
+		{Case: "Extended", Content: `{ "final_space": false }`, Extended: true, Expected: true},
+	}
+
+	for _, tc := range cases {
+		configFile := filepath.Join(t.TempDir(), "mine.omp.json")
+		err := os.WriteFile(configFile, []byte(tc.Content), 0644)
+		assert.NoError(t, err)
+
+		cfg, err := readConfig(configFile, tc.Extended)
+		assert.NoError(t, err, tc.Case)
+		assert.Equal(t, tc.Expected, cfg.keys["final_space"], tc.Case)
+	}
+}
+
+func TestMergePalettes(t *testing.T) {
+	cases := []struct {
+		Base     *color.Palettes
+		Override *color.Palettes
+		Expected *color.Palettes
+		Case     string
+	}{
+		{Case: "No palettes"},
+		{
+			Case:     "Base only",
+			Base:     &color.Palettes{Template: "{{ .Shell }}"},
+			Expected: &color.Palettes{Template: "{{ .Shell }}"},
+		},
+		{
+			Case: "Merge",
+			Base: &color.Palettes{
+				Template: "{{ .Shell }}",
+				List: map[string]color.Palette{
+					"bash": {"red": "#ff0000", "blue": "#0000ff"},
+					"zsh":  {"red": "#ff0001"},
+				},
+			},
+			Override: &color.Palettes{
+				List: map[string]color.Palette{
+					"bash": {"red": "#ff0002"},
+					"fish": {"red": "#ff0003"},
+				},
+			},
+			Expected: &color.Palettes{
+				Template: "{{ .Shell }}",
+				List: map[string]color.Palette{
+					"bash": {"red": "#ff0002", "blue": "#0000ff"},
+					"zsh":  {"red": "#ff0001"},
+					"fish": {"red": "#ff0003"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.Expected, mergePalettes(tc.Base, tc.Override), tc.Case)
+	}
+}
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/template"
+)
+
+// MinimumContrast is the WCAG AA contrast ratio for normal text.
+const MinimumContrast = 4.5
+
+// colorCandidate is a color a segment can use, together with the location it's defined at
+type colorCandidate struct {
+	value color.Ansi
+	path  string
+}
+
+type colorLinter struct {
+	*validator
+	background color.Ansi
+	minimum    float64
+}
+
+// LintColors reports every foreground and background pair in the config with a WCAG contrast ratio
+// below the minimum. Colors from all palettes, template branches and cycle entries are checked.
+// Transparent backgrounds are compared to the background, or terminal_background when empty.
+func LintColors(configFile string, minimum float64, background color.Ansi) []*Problem {
+	v, ok := newValidator(configFile)
+	if !ok {
+		return v.problems
+	}
+
+	l := &colorLinter{
+		validator:  v,
+		minimum:    minimum,
+		background: background,
+	}
+
+	if len(l.background) == 0 {
+		l.background = v.cfg.TerminalBackground
+	}
+
+	for i, block := range v.cfg.Blocks {
+		for j, segment := range block.Segments {
+			l.segment(fmt.Sprintf("blocks[%d].segments[%d]", i, j), segment)
+		}
+	}
+
+	for i, tooltip := range v.cfg.Tooltips {
+		l.segment(fmt.Sprintf("tooltips[%d]", i), tooltip)
+	}
+
+	l.segment("debug_prompt", v.cfg.DebugPrompt)
+	l.segment("valid_line", v.cfg.ValidLine)
+	l.segment("error_line", v.cfg.ErrorLine)
+	l.segment("secondary_prompt", v.cfg.SecondaryPrompt)
+	l.segment("transient_prompt", v.cfg.TransientPrompt)
+
+	for i, colors := range v.cfg.Cycle {
+		path := fmt.Sprintf("cycle[%d]", i)
+
+		l.pairs(
+			[]*colorCandidate{{value: colors.Foreground, path: path + ".foreground"}},
+			[]*colorCandidate{{value: colors.Background, path: path + ".background"}},
+		)
+	}
+
+	return v.sorted()
+}
+
+func (l *colorLinter) segment(path string, segment *Segment) {
+	if segment == nil {
+		return
+	}
+
+	l.pairs(
+		colorCandidates(path+".foreground", segment.Foreground, path+".foreground_templates", segment.ForegroundTemplates),
+		colorCandidates(path+".background", segment.Background, path+".background_templates", segment.BackgroundTemplates),
+	)
+}
+
+// candidates returns the color itself and every color the templates can resolve to
+func colorCandidates(path string, value color.Ansi, templatesPath string, templates template.List) []*colorCandidate {
+	candidates := []*colorCandidate{{value: value, path: path}}
+
+	for i, text := range templates {
+		tmpl := &template.Text{
+			Template: text,
+		}
+
+		// invalid templates are reported by validate
+		literals, err := tmpl.Literals()
+		if err != nil {
+			continue
+		}
+
+		for _, literal := range literals {
+			candidates = append(candidates, &colorCandidate{
+				value: color.Ansi(literal),
+				path:  fmt.Sprintf("%s[%d]", templatesPath, i),
+			})
+		}
+	}
+
+	return candidates
+}
+
+func (l *colorLinter) pairs(foregrounds, backgrounds []*colorCandidate) {
+	names, palettes := l.palettes()
+
+	isReference := func(value color.Ansi) bool {
+		return strings.HasPrefix(value.String(), "p:")
+	}
+
+	for i, name := range names {
+		palette := palettes[name]
+
+		for _, foreground := range foregrounds {
+			fg, ok := resolveRGB(foreground.value, palette)
+			if !ok {
+				continue
+			}
+
+			for _, background := range backgrounds {
+				value := background.value
+				if value.IsClear() {
+					value = l.background
+				}
+
+				// colors without a palette reference only need to be checked once
+				usesPalette := isReference(foreground.value) || isReference(value)
+				if !usesPalette && i != 0 {
+					continue
+				}
+
+				bg, ok := resolveRGB(value, palette)
+				if !ok {
+					continue
+				}
+
+				ratio := color.Contrast(fg, bg)
+				if ratio >= l.minimum {
+					continue
+				}
+
+				message := fmt.Sprintf("foreground %s on background %s has a contrast ratio of %.2f, below %s",
+					describe(foreground.value, fg), describe(value, bg), ratio, strconv.FormatFloat(l.minimum, 'f', -1, 64))
+
+				if usesPalette && len(name) != 0 {
+					message += fmt.Sprintf(" in palette %q", name)
+				}
+
+				l.add(foreground.path, "%s", message)
+			}
+		}
+	}
+}
+
+// resolve returns the RGB value of colors with a fixed value. Keywords, color names and the first 16 colors
+// are skipped as they depend on the terminal, auto is always readable.
+func resolveRGB(value color.Ansi, palette color.Palette) (color.RGB, bool) {
+	resolved, err := palette.ResolveColor(value)
+	if err != nil || resolved.IsClear() || color.IsAnsiColorName(resolved) {
+		return color.RGB{}, false
+	}
+
+	if index, err := strconv.Atoi(resolved.String()); err == nil && index < 16 {
+		return color.RGB{}, false
+	}
+
+	rgb, err := color.ToRGB(resolved)
+	if err != nil {
+		return color.RGB{}, false
+	}
+
+	return rgb, true
+}
+
+func describe(value color.Ansi, rgb color.RGB) string {
+	if strings.EqualFold(value.String(), rgb.String()) {
+		return rgb.String()
+	}
+
+	return fmt.Sprintf("%s (%s)", value, rgb)
+}
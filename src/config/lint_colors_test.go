@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintColors(t *testing.T) {
+	cases := []struct {
+		Case       string
+		Config     string
+		Background color.Ansi
+		Expected   []string
+	}{
+		{
+			Case: "Readable",
+			Config: `{
+  "version": 3,
+  "blocks": [
+    {
+      "type": "prompt",
+      "segments": [
+        { "type": "path", "foreground": "#ffffff", "background": "#000000" },
+        { "type": "text", "foreground": "auto", "background": "#777777" },
+        { "type": "text", "foreground": "red", "background": "lightRed" },
+        { "type": "text", "foreground": "#ffffff", "background": "parentBackground" }
+      ]
+    }
+  ]
+}`,
+		},
+		{
+			Case: "Colors and templates",
+			Config: `{
+  "version": 3,
+  "blocks": [
+    {
+      "type": "prompt",
+      "segments": [
+        { "type": "path", "foreground": "#777777", "background": "#ffffff" },
+        {
+          "type": "git",
+          "foreground": "#000000",
+          "background": "#ffffff",
+          "foreground_templates": ["{{ if .Working.Changed }}#fefefe{{ else }}#010101{{ end }}"]
+        },
+        { "type": "text", "foreground": "229" }
+      ]
+    }
+  ]
+}`,
+			Background: "#ffffff",
+			Expected: []string{
+				`7:27: foreground #777777 on background #ffffff has a contrast ratio of 4.48, below 4.5`,
+				`12:36: foreground #fefefe on background #ffffff has a contrast ratio of 1.01, below 4.5`,
+				`14:27: foreground 229 (#ffffaf) on background #ffffff has a contrast ratio of 1.04, below 4.5`,
+			},
+		},
+		{
+			Case: "Palettes and cycle",
+			Config: `{
+  "version": 3,
+  "terminal_background": "#000000",
+  "palette": { "text": "#eeeeee" },
+  "palettes": {
+    "list": {
+      "dark": { "background": "#111111" },
+      "light": { "background": "#fafafa" }
+    }
+  },
+  "cycle": [
+    { "foreground": "#202020", "background": "#000000" }
+  ],
+  "blocks": [
+    {
+      "type": "prompt",
+      "segments": [
+        { "type": "path", "foreground": "p:text", "background": "p:background" },
+        { "type": "text", "foreground": "#101010" }
+      ]
+    }
+  ]
+}`,
+			Expected: []string{
+				`12:7: foreground #202020 on background #000000 has a contrast ratio of 1.29, below 4.5`,
+				`18:27: foreground p:text (#eeeeee) on background p:background (#fafafa) has a contrast ratio of 1.11, below 4.5 in palette "light"`,
+				`19:27: foreground #101010 on background #000000 has a contrast ratio of 1.10, below 4.5`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		configFile := filepath.Join(t.TempDir(), "colors.omp.json")
+		err := os.WriteFile(configFile, []byte(tc.Config), 0644)
+		assert.NoError(t, err, tc.Case)
+
+		var got []string
+		for _, problem := range LintColors(configFile, MinimumContrast, tc.Background) {
+			got = append(got, problem.String()[len(configFile)+1:])
+		}
+
+		assert.Equal(t, tc.Expected, got, tc.Case)
+	}
+}
@@ -24,6 +24,16 @@ import (
 
 // LoadConfig returns the default configuration including possible user overrides
 func Load(configFile, sh string, migrate bool) *Config {
+	return load(configFile, sh, migrate, true)
+}
+
+// LoadFile returns the configuration of the given file without merging the configs it extends,
+// use this when the configuration is written back to disk.
+func LoadFile(configFile, sh string, migrate bool) *Config {
+	return load(configFile, sh, migrate, false)
+}
+
+func load(configFile, sh string, migrate, extend bool) *Config {
 	defer log.Trace(time.Now())
 
 	cfg := loadConfig(configFile)
@@ -33,6 +43,10 @@ func Load(configFile, sh string, migrate bool) *Config {
 		cfg.BackupAndMigrate()
 	}
 
+	if extend && len(cfg.Extends) != 0 {
+		cfg = cfg.extend()
+	}
+
 	if cfg.Upgrade == nil {
 		cfg.Upgrade = &upgrade.Config{
 			Source:        upgrade.CDN,
@@ -124,39 +138,75 @@ func loadConfig(configFile string) *Config {
 		return Default(false)
 	}
 
+	cfg, err := readConfig(configFile, false)
+	if err != nil {
+		log.Error(err)
+		return Default(true)
+	}
+
+	return cfg
+}
+
+// readConfig parses the config file, extended is set for the configs listed in Extends
+func readConfig(configFile string, extended bool) (*Config, error) {
 	var cfg Config
 	cfg.origin = configFile
+	cfg.extended = extended
 	cfg.Format = strings.TrimPrefix(filepath.Ext(configFile), ".")
 
 	data, err := stdOS.ReadFile(configFile)
 	if err != nil {
-		log.Error(err)
-		return Default(true)
+		return nil, err
 	}
 
+	if cfg.Format == "jsonc" || cfg.Format == "json" {
+		str := jsonutil.StripComments(string(data))
+		data = []byte(str)
+	}
+
+	if err = cfg.decode(data); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// decode parses the config based on the file extension set in Format,
+// JSON data is expected to be stripped of comments
+func (cfg *Config) decode(data []byte) error {
+	var unmarshal func([]byte, any) error
+
 	switch cfg.Format {
 	case "yml", "yaml":
 		cfg.Format = YAML
-		err = yaml.Unmarshal(data, &cfg)
+		unmarshal = yaml.Unmarshal
 	case "jsonc", "json":
 		cfg.Format = JSON
-
-		str := jsonutil.StripComments(string(data))
-		data = []byte(str)
-
-		decoder := json.NewDecoder(bytes.NewReader(data))
-		err = decoder.Decode(&cfg)
+		unmarshal = func(data []byte, v any) error {
+			decoder := json.NewDecoder(bytes.NewReader(data))
+			return decoder.Decode(v)
+		}
 	case "toml", "tml":
 		cfg.Format = TOML
-		err = toml.Unmarshal(data, &cfg)
+		unmarshal = toml.Unmarshal
 	default:
-		err = fmt.Errorf("unsupported config file format: %s", cfg.Format)
+		return fmt.Errorf("unsupported config file format: %s", cfg.Format)
 	}
 
-	if err != nil {
-		log.Error(err)
-		return Default(true)
+	if err := unmarshal(data, cfg); err != nil {
+		return err
+	}
+
+	// keep the keys that are present to know which zero values override an extended config,
+	// this is only needed when configs are merged so we avoid parsing the data twice otherwise
+	if len(cfg.Extends) == 0 && !cfg.extended {
+		return nil
+	}
+
+	var raw map[string]any
+	if err := unmarshal(data, &raw); err == nil {
+		cfg.setKeys(raw)
 	}
 
-	return &cfg
+	return nil
 }
@@ -0,0 +1,1478 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/properties"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments"
+
+	toml "github.com/pelletier/go-toml/v2"
+)
+
+// MigrateStarship translates a starship.toml configuration into a Config.
+// Everything that can't be translated is returned as a list of warnings.
+func MigrateStarship(data []byte) (*Config, []string, error) {
+	var settings map[string]any
+	if err := toml.Unmarshal(data, &settings); err != nil {
+		return nil, nil, err
+	}
+
+	s := &starship{
+		settings: settings,
+	}
+
+	return s.config(), s.warnings, nil
+}
+
+type starship struct {
+	settings map[string]any
+	palette  map[string]any
+	warnings []string
+}
+
+func (s *starship) warn(format string, args ...any) {
+	warning := fmt.Sprintf(format, args...)
+	if slices.Contains(s.warnings, warning) {
+		return
+	}
+
+	s.warnings = append(s.warnings, warning)
+}
+
+func (s *starship) config() *Config {
+	cfg := &Config{
+		Version: Version,
+		Format:  JSON,
+	}
+
+	s.setPalette(cfg)
+
+	// the top level keys which aren't module configurations
+	known := []string{"$schema", "format", "right_format", "add_newline", "palette", "palettes", "continuation_prompt"}
+
+	for _, key := range sortedKeys(s.settings) {
+		if _, isModule := s.settings[key].(map[string]any); isModule || slices.Contains(known, key) {
+			continue
+		}
+
+		s.warn("%s is not supported", key)
+	}
+
+	format := parseStarshipFormat(settingString(s.settings, "format", "$all"))
+	rightFormat := parseStarshipFormat(settingString(s.settings, "right_format", ""))
+
+	// $all only adds the modules which aren't placed elsewhere
+	placed := starshipVariables(format)
+	placed = append(placed, starshipVariables(rightFormat)...)
+
+	cfg.Blocks = s.blocks(format, placed)
+
+	if addNewline, ok := s.settings["add_newline"].(bool); (!ok || addNewline) && len(cfg.Blocks) != 0 {
+		cfg.Blocks[0].Newline = true
+	}
+
+	if len(rightFormat) != 0 {
+		rprompt := &Block{
+			Type: RPrompt,
+		}
+
+		for _, block := range s.blocks(rightFormat, placed) {
+			rprompt.Segments = append(rprompt.Segments, block.Segments...)
+		}
+
+		cfg.Blocks = append(cfg.Blocks, rprompt)
+	}
+
+	if continuation, ok := s.settings["continuation_prompt"].(string); ok {
+		renderer := &starshipRenderer{starship: s, name: "continuation_prompt"}
+		template, _, _ := renderer.render(parseStarshipFormat(continuation))
+		cfg.SecondaryPrompt = &Segment{
+			Template: template,
+		}
+	}
+
+	return cfg
+}
+
+// setPalette uses the palette selected in the configuration
+func (s *starship) setPalette(cfg *Config) {
+	name, ok := s.settings["palette"].(string)
+	if !ok {
+		return
+	}
+
+	palettes, _ := s.settings["palettes"].(map[string]any)
+	palette, ok := palettes[name].(map[string]any)
+	if !ok {
+		s.warn("palette %s does not exist", name)
+		return
+	}
+
+	s.palette = palette
+	cfg.Palette = make(color.Palette)
+
+	for key, value := range palette {
+		colorValue, _ := value.(string)
+		cfg.Palette[color.Ansi(key)] = s.color(colorValue)
+	}
+}
+
+// blocks splits the segments into blocks on $line_break and $fill
+func (s *starship) blocks(format []*starshipNode, placed []string) []*Block {
+	block := &Block{
+		Type:      Prompt,
+		Alignment: Left,
+	}
+
+	blocks := []*Block{block}
+
+	renderer := &starshipRenderer{starship: s, name: "format"}
+
+	var text strings.Builder
+
+	flush := func() {
+		if text.Len() == 0 {
+			return
+		}
+
+		block.Segments = append(block.Segments, &Segment{
+			Type:     TEXT,
+			Style:    Plain,
+			Template: text.String(),
+		})
+
+		text.Reset()
+	}
+
+	var add func(name string)
+	add = func(name string) {
+		switch name {
+		case "all":
+			for _, module := range starshipDefaultOrder {
+				if slices.Contains(placed, module) {
+					continue
+				}
+
+				add(module)
+			}
+		case "custom":
+			custom, _ := s.settings["custom"].(map[string]any)
+
+			for _, key := range sortedKeys(custom) {
+				if slices.Contains(placed, "custom."+key) {
+					continue
+				}
+
+				add("custom." + key)
+			}
+		case "line_break":
+			flush()
+
+			block = &Block{
+				Type:      Prompt,
+				Alignment: Left,
+				Newline:   true,
+			}
+
+			blocks = append(blocks, block)
+		case "fill":
+			flush()
+
+			block = &Block{
+				Type:      Prompt,
+				Alignment: Right,
+				Filler:    s.filler(),
+			}
+
+			blocks = append(blocks, block)
+		default:
+			if segment := s.segment(name); segment != nil {
+				flush()
+				block.Segments = append(block.Segments, segment)
+			}
+		}
+	}
+
+	var walk func(nodes []*starshipNode)
+	walk = func(nodes []*starshipNode) {
+		for _, node := range nodes {
+			switch node.kind {
+			case starshipVariableNode:
+				add(node.variable)
+			case starshipConditionalNode:
+				// modules are only shown when they are enabled
+				walk(node.children)
+			default:
+				template, _, _ := renderer.render([]*starshipNode{node})
+				text.WriteString(template)
+			}
+		}
+	}
+
+	walk(format)
+	flush()
+
+	return slices.DeleteFunc(blocks, func(block *Block) bool {
+		return len(block.Segments) == 0
+	})
+}
+
+func (s *starship) filler() string {
+	settings, _ := s.settings["fill"].(map[string]any)
+
+	renderer := &starshipRenderer{starship: s, name: "fill"}
+	symbol := &starshipNode{kind: starshipTextNode, text: settingString(settings, "symbol", ".")}
+	group := &starshipNode{kind: starshipGroupNode, children: []*starshipNode{symbol}, style: settingString(settings, "style", "bold black")}
+
+	filler, _, _ := renderer.render([]*starshipNode{group})
+	return filler
+}
+
+func (s *starship) segment(name string) *Segment {
+	moduleName, key, _ := strings.Cut(name, ".")
+
+	module, ok := starshipModules[moduleName]
+	if !ok {
+		s.warn("module %s is not supported", name)
+		return nil
+	}
+
+	settings, _ := s.settings[moduleName].(map[string]any)
+	if len(key) != 0 {
+		settings, _ = settings[key].(map[string]any)
+	}
+
+	if settings == nil {
+		settings = make(map[string]any)
+	}
+
+	if disabled, ok := settings["disabled"].(bool); ok && disabled || !ok && module.disabled {
+		return nil
+	}
+
+	m := &starshipRenderer{
+		starship: s,
+		module:   module,
+		settings: settings,
+		name:     name,
+		key:      key,
+	}
+
+	for _, option := range sortedKeys(settings) {
+		if _, known := module.options[option]; known || option == "disabled" {
+			continue
+		}
+
+		s.warn("%s.%s is not supported", name, option)
+	}
+
+	if module.enabled != nil && !module.enabled(m) {
+		return nil
+	}
+
+	style := m.style("$style")
+
+	segment := &Segment{
+		Type:       module.segment,
+		Style:      Plain,
+		Foreground: style.foreground,
+		Background: style.background,
+	}
+
+	m.segment = segment
+
+	m.variables = map[string]*starshipVariable{
+		"symbol": {template: m.format(m.string("symbol"), nil)},
+	}
+
+	if module.variables != nil {
+		for variable, value := range module.variables(m) {
+			m.variables[variable] = value
+		}
+	}
+
+	_, m.userFormat = settings["format"]
+
+	segment.Template, _, _ = m.render(parseStarshipFormat(m.string("format")))
+
+	if module.when != nil {
+		if when := module.when(m); len(when) != 0 {
+			segment.Template = fmt.Sprintf("{{ if %s }}%s{{ end }}", when, segment.Template)
+		}
+	}
+
+	if module.properties != nil {
+		props := properties.Map{}
+		module.properties(m, props)
+
+		if len(props) != 0 {
+			segment.Properties = props
+		}
+	}
+
+	return segment
+}
+
+var (
+	starshipColors = map[string]color.Ansi{
+		"black":          "black",
+		"red":            "red",
+		"green":          "green",
+		"yellow":         "yellow",
+		"blue":           "blue",
+		"purple":         "magenta",
+		"cyan":           "cyan",
+		"white":          "white",
+		"bright-black":   "darkGray",
+		"bright-red":     "lightRed",
+		"bright-green":   "lightGreen",
+		"bright-yellow":  "lightYellow",
+		"bright-blue":    "lightBlue",
+		"bright-purple":  "lightMagenta",
+		"bright-cyan":    "lightCyan",
+		"bright-white":   "lightWhite",
+		"prev_fg":        color.ParentForeground,
+		"prev_bg":        color.ParentBackground,
+		"bright-magenta": "lightMagenta",
+		"magenta":        "magenta",
+	}
+
+	starshipModifiers = map[string]string{
+		"bold":          "b",
+		"italic":        "i",
+		"underline":     "u",
+		"dimmed":        "d",
+		"inverted":      "r",
+		"blink":         "f",
+		"strikethrough": "s",
+	}
+)
+
+func (s *starship) color(value string) color.Ansi {
+	if len(value) == 0 || strings.EqualFold(value, "none") {
+		return ""
+	}
+
+	if strings.HasPrefix(value, "#") {
+		return color.Ansi(value)
+	}
+
+	if _, err := strconv.ParseUint(value, 10, 8); err == nil {
+		return color.Ansi(value)
+	}
+
+	if _, ok := s.palette[value]; ok {
+		return color.Ansi("p:" + value)
+	}
+
+	if ansi, ok := starshipColors[strings.ToLower(value)]; ok {
+		return ansi
+	}
+
+	s.warn("color %s is not supported", value)
+	return ""
+}
+
+type starshipStyle struct {
+	foreground color.Ansi
+	background color.Ansi
+	modifiers  []string
+}
+
+func (s *starship) parseStyle(style string) *starshipStyle {
+	result := &starshipStyle{}
+
+	for _, field := range strings.Fields(style) {
+		lower := strings.ToLower(field)
+
+		if modifier, ok := starshipModifiers[lower]; ok {
+			result.modifiers = append(result.modifiers, modifier)
+			continue
+		}
+
+		switch {
+		case lower == "none":
+			result.foreground = ""
+			result.background = ""
+			result.modifiers = nil
+		case lower == "hidden":
+			s.warn("style %s is not supported", field)
+		case strings.HasPrefix(lower, "fg:"):
+			result.foreground = s.color(field[3:])
+		case strings.HasPrefix(lower, "bg:"):
+			result.background = s.color(field[3:])
+		default:
+			result.foreground = s.color(field)
+		}
+	}
+
+	return result
+}
+
+func sortedKeys(settings map[string]any) []string {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func settingString(settings map[string]any, key, defaultValue string) string {
+	value, ok := settings[key].(string)
+	if !ok {
+		return defaultValue
+	}
+
+	return value
+}
+
+type starshipNodeKind int
+
+const (
+	starshipTextNode starshipNodeKind = iota
+	starshipVariableNode
+	starshipGroupNode
+	starshipConditionalNode
+)
+
+// starshipNode is a parsed element of a starship format string
+type starshipNode struct {
+	text     string
+	variable string
+	style    string
+	children []*starshipNode
+	kind     starshipNodeKind
+}
+
+type starshipParser struct {
+	input    []rune
+	position int
+}
+
+func parseStarshipFormat(format string) []*starshipNode {
+	parser := &starshipParser{input: []rune(format)}
+	return parser.parse(0)
+}
+
+func (p *starshipParser) next() (rune, bool) {
+	if p.position >= len(p.input) {
+		return 0, false
+	}
+
+	char := p.input[p.position]
+	p.position++
+
+	return char, true
+}
+
+func (p *starshipParser) peek(char rune) bool {
+	return p.position < len(p.input) && p.input[p.position] == char
+}
+
+func (p *starshipParser) parse(until rune) []*starshipNode {
+	var nodes []*starshipNode
+	var text strings.Builder
+
+	flush := func() {
+		if text.Len() == 0 {
+			return
+		}
+
+		nodes = append(nodes, &starshipNode{kind: starshipTextNode, text: text.String()})
+		text.Reset()
+	}
+
+	for {
+		char, ok := p.next()
+		if !ok || (until != 0 && char == until) {
+			flush()
+			return nodes
+		}
+
+		switch char {
+		case '\\':
+			if escaped, ok := p.next(); ok {
+				text.WriteRune(escaped)
+			}
+		case '$':
+			name := p.variable()
+			if len(name) == 0 {
+				text.WriteRune(char)
+				continue
+			}
+
+			flush()
+			nodes = append(nodes, &starshipNode{kind: starshipVariableNode, variable: name})
+		case '[':
+			flush()
+
+			node := &starshipNode{kind: starshipGroupNode, children: p.parse(']')}
+			if p.peek('(') {
+				p.position++
+				node.style = p.until(')')
+			}
+
+			nodes = append(nodes, node)
+		case '(':
+			flush()
+			nodes = append(nodes, &starshipNode{kind: starshipConditionalNode, children: p.parse(')')})
+		default:
+			text.WriteRune(char)
+		}
+	}
+}
+
+func (p *starshipParser) variable() string {
+	if p.peek('{') {
+		p.position++
+		return p.until('}')
+	}
+
+	start := p.position
+
+	for p.position < len(p.input) {
+		char := p.input[p.position]
+		if char != '_' && (char < 'a' || char > 'z') && (char < 'A' || char > 'Z') && (char < '0' || char > '9') {
+			break
+		}
+
+		p.position++
+	}
+
+	return string(p.input[start:p.position])
+}
+
+func (p *starshipParser) until(end rune) string {
+	var value strings.Builder
+
+	for {
+		char, ok := p.next()
+		if !ok || char == end {
+			return value.String()
+		}
+
+		value.WriteRune(char)
+	}
+}
+
+// starshipVariables lists all variables used in the format
+func starshipVariables(nodes []*starshipNode) []string {
+	var variables []string
+
+	for _, node := range nodes {
+		if node.kind == starshipVariableNode {
+			variables = append(variables, node.variable)
+		}
+
+		variables = append(variables, starshipVariables(node.children)...)
+	}
+
+	return variables
+}
+
+// starshipVariable is the template equivalent of a starship variable,
+// the condition is empty when the variable is constant
+type starshipVariable struct {
+	template  string
+	condition string
+}
+
+// starshipRenderer translates the format strings of a module into a template
+type starshipRenderer struct {
+	*starship
+	module     *starshipModule
+	segment    *Segment
+	settings   map[string]any
+	variables  map[string]*starshipVariable
+	name       string
+	key        string
+	userFormat bool
+	grouped    bool
+}
+
+// option returns the value set by the user or the module's default value
+func (m *starshipRenderer) option(key string) any {
+	if value, ok := m.settings[key]; ok {
+		return value
+	}
+
+	if m.module == nil {
+		return nil
+	}
+
+	return m.module.options[key]
+}
+
+func (m *starshipRenderer) string(key string) string {
+	value, _ := m.option(key).(string)
+	return value
+}
+
+func (m *starshipRenderer) bool(key string) bool {
+	value, _ := m.option(key).(bool)
+	return value
+}
+
+func (m *starshipRenderer) int(key string) (int, bool) {
+	switch value := m.option(key).(type) {
+	case int64:
+		return int(value), true
+	case int:
+		return value, true
+	default:
+		return 0, false
+	}
+}
+
+func (m *starshipRenderer) isSet(key string) bool {
+	_, ok := m.settings[key]
+	return ok
+}
+
+// style parses the style after replacing references to options, like $style
+func (m *starshipRenderer) style(style string) *starshipStyle {
+	fields := strings.Fields(style)
+
+	for i, field := range fields {
+		option, ok := strings.CutPrefix(field, "$")
+		if !ok {
+			continue
+		}
+
+		if option == "style" && m.module != nil && len(m.module.styleOption) != 0 {
+			option = m.module.styleOption
+		}
+
+		fields[i] = m.string(option)
+	}
+
+	return m.parseStyle(strings.Join(fields, " "))
+}
+
+// format renders a format string found in an option, using the additional variables
+func (m *starshipRenderer) format(format string, variables map[string]*starshipVariable) string {
+	renderer := *m
+	renderer.variables = make(map[string]*starshipVariable)
+	renderer.userFormat = false
+	renderer.grouped = true
+
+	for key, value := range m.variables {
+		renderer.variables[key] = value
+	}
+
+	for key, value := range variables {
+		renderer.variables[key] = value
+	}
+
+	template, _, _ := renderer.render(parseStarshipFormat(format))
+	return template
+}
+
+// render returns the template, the conditions of the variables used
+// and whether any non constant variable is used
+func (m *starshipRenderer) render(nodes []*starshipNode) (string, []string, bool) {
+	var template strings.Builder
+	var conditions []string
+	var hasVariables bool
+
+	for _, node := range nodes {
+		switch node.kind {
+		case starshipTextNode:
+			// text in a group uses the group's style
+			if m.grouped {
+				template.WriteString(node.text)
+				continue
+			}
+
+			template.WriteString(m.wrap(node.text, &starshipStyle{}))
+		case starshipVariableNode:
+			variable, ok := m.variables[node.variable]
+			if !ok {
+				hasVariables = true
+
+				if m.userFormat {
+					m.warn("%s: variable $%s is not supported", m.name, node.variable)
+				}
+
+				continue
+			}
+
+			template.WriteString(variable.template)
+
+			if len(variable.condition) != 0 {
+				hasVariables = true
+				conditions = append(conditions, variable.condition)
+			}
+		case starshipGroupNode:
+			group := *m
+			group.grouped = true
+
+			content, childConditions, childVariables := group.render(node.children)
+			template.WriteString(m.wrap(content, m.style(node.style)))
+			conditions = append(conditions, childConditions...)
+			hasVariables = hasVariables || childVariables
+		case starshipConditionalNode:
+			content, childConditions, childVariables := m.render(node.children)
+
+			// nothing to show when none of the variables is available
+			if childVariables && len(childConditions) == 0 {
+				continue
+			}
+
+			conditions = append(conditions, childConditions...)
+			hasVariables = hasVariables || childVariables
+
+			switch len(childConditions) {
+			case 0:
+				template.WriteString(content)
+			case 1:
+				template.WriteString(fmt.Sprintf("{{ if %s }}%s{{ end }}", childConditions[0], content))
+			default:
+				template.WriteString(fmt.Sprintf("{{ if or (%s) }}%s{{ end }}", strings.Join(childConditions, ") ("), content))
+			}
+		}
+	}
+
+	return template.String(), conditions, hasVariables
+}
+
+// wrap adds the color and style overrides compared to the segment's colors
+func (m *starshipRenderer) wrap(text string, style *starshipStyle) string {
+	if len(text) == 0 {
+		return text
+	}
+
+	var foreground, background color.Ansi
+	if m.segment != nil {
+		foreground = m.segment.Foreground
+		background = m.segment.Background
+	}
+
+	// the foreground color doesn't matter for whitespace
+	if len(strings.TrimSpace(text)) == 0 && len(style.modifiers) == 0 && len(style.background) == 0 && len(background) == 0 {
+		return text
+	}
+
+	textForeground := style.foreground
+	if len(textForeground) == 0 && len(foreground) != 0 {
+		textForeground = "default"
+	}
+
+	textBackground := style.background
+	if len(textBackground) == 0 && len(background) != 0 {
+		textBackground = color.Transparent
+	}
+
+	for i := len(style.modifiers) - 1; i >= 0; i-- {
+		text = fmt.Sprintf("<%s>%s</%s>", style.modifiers[i], text, style.modifiers[i])
+	}
+
+	if textForeground == foreground && textBackground == background {
+		return text
+	}
+
+	if len(textBackground) == 0 {
+		return fmt.Sprintf("<%s>%s</>", textForeground, text)
+	}
+
+	return fmt.Sprintf("<%s,%s>%s</>", textForeground, textBackground, text)
+}
+
+// starshipModule describes how to translate a starship module into a segment
+type starshipModule struct {
+	// options holds the supported options and their default values
+	options map[string]any
+	// variables translates the module's variables into template expressions
+	variables func(m *starshipRenderer) map[string]*starshipVariable
+	// properties sets the segment's properties based on the options
+	properties func(m *starshipRenderer, props properties.Map)
+	// when returns the condition to show the segment
+	when func(m *starshipRenderer) string
+	// enabled returns false when the module can't be shown with its options
+	enabled func(m *starshipRenderer) bool
+	// styleOption is the option $style refers to, defaults to style
+	styleOption string
+	segment     SegmentType
+	disabled    bool
+}
+
+// starshipDefaultOrder is the order of the modules in $all
+var starshipDefaultOrder = []string{
+	"username",
+	"hostname",
+	"kubernetes",
+	"directory",
+	"git_branch",
+	"git_status",
+	"docker_context",
+	"golang",
+	"java",
+	"lua",
+	"nodejs",
+	"php",
+	"python",
+	"ruby",
+	"rust",
+	"terraform",
+	"aws",
+	"gcloud",
+	"azure",
+	"env_var",
+	"custom",
+	"cmd_duration",
+	"line_break",
+	"battery",
+	"time",
+	"status",
+	"os",
+	"shell",
+	"character",
+}
+
+var starshipModules = map[string]*starshipModule{
+	"username": {
+		segment: SESSION,
+		options: map[string]any{
+			"format":      "[$user]($style) in ",
+			"style_user":  "yellow bold",
+			"style_root":  "red bold",
+			"show_always": false,
+		},
+		styleOption: "style_user",
+		variables: func(m *starshipRenderer) map[string]*starshipVariable {
+			if m.isSet("style_root") {
+				m.warn("username.style_root is not supported")
+			}
+
+			return map[string]*starshipVariable{
+				"user": {template: "{{ .UserName }}", condition: ".UserName"},
+			}
+		},
+		when: func(m *starshipRenderer) string {
+			if m.bool("show_always") {
+				return ""
+			}
+
+			return "or .Root .SSHSession"
+		},
+	},
+	"hostname": {
+		segment: SESSION,
+		options: map[string]any{
+			"format":     "[$ssh_symbol$hostname]($style) in ",
+			"style":      "bold dimmed green",
+			"ssh_only":   true,
+			"ssh_symbol": "🌐 ",
+		},
+		variables: func(m *starshipRenderer) map[string]*starshipVariable {
+			return map[string]*starshipVariable{
+				"hostname":   {template: "{{ .HostName }}", condition: ".HostName"},
+				"ssh_symbol": {template: fmt.Sprintf("{{ if .SSHSession }}%s{{ end }}", m.format(m.string("ssh_symbol"), nil))},
+			}
+		},
+		when: func(m *starshipRenderer) string {
+			if m.bool("ssh_only") {
+				return ".SSHSession"
+			}
+
+			return ""
+		},
+	},
+	"directory": {
+		segment: PATH,
+		options: map[string]any{
+			"format":            "[$path]($style)[$read_only]($read_only_style) ",
+			"style":             "bold cyan",
+			"read_only":         " \U000F033E",
+			"read_only_style":   "red",
+			"home_symbol":       "~",
+			"truncation_length": int64(3),
+			"truncation_symbol": "",
+		},
+		variables: func(m *starshipRenderer) map[string]*starshipVariable {
+			return map[string]*starshipVariable{
+				"path":      {template: "{{ .Path }}", condition: ".Path"},
+				"read_only": {template: fmt.Sprintf("{{ if not .Writable }}%s{{ end }}", m.format(m.string("read_only"), nil)), condition: "not .Writable"},
+			}
+		},
+		properties: func(m *starshipRenderer, props properties.Map) {
+			props[segments.HomeIcon] = m.string("home_symbol")
+
+			length, _ := m.int("truncation_length")
+			if length <= 0 {
+				props[properties.Style] = segments.Full
+				return
+			}
+
+			props[properties.Style] = segments.AgnosterShort
+			props[segments.MaxDepth] = length
+			props[segments.HideRootLocation] = true
+
+			if m.isSet("truncation_symbol") {
+				props[segments.FolderIcon] = m.string("truncation_symbol")
+			}
+		},
+	},
+	"git_branch": {
+		segment: GIT,
+		options: map[string]any{
+			"format":            "on [$symbol$branch(:$remote_branch)]($style) ",
+			"symbol":            "\uE0A0 ",
+			"style":             "bold purple",
+			"truncation_length": nil,
+			"truncation_symbol": "…",
+		},
+		variables: func(_ *starshipRenderer) map[string]*starshipVariable {
+			return map[string]*starshipVariable{
+				"branch": {template: "{{ .HEAD }}", condition: ".HEAD"},
+			}
+		},
+		properties: func(m *starshipRenderer, props properties.Map) {
+			// the symbol is part of the template
+			props[segments.BranchIcon] = ""
+
+			if length, ok := m.int("truncation_length"); ok {
+				props[segments.BranchMaxLength] = length
+				props[segments.TruncateSymbol] = m.string("truncation_symbol")
+			}
+		},
+	},
+	"git_status": {
+		segment: GIT,
+		options: map[string]any{
+			"format":      `([\[$all_status$ahead_behind\]]($style) )`,
+			"style":       "bold red",
+			"conflicted":  "=",
+			"ahead":       "⇡",
+			"behind":      "⇣",
+			"diverged":    "⇕",
+			"up_to_date":  "",
+			"untracked":   "?",
+			"stashed":     `\$`,
+			"modified":    "!",
+			"staged":      "+",
+			"renamed":     "»",
+			"deleted":     "✘",
+			"typechanged": "",
+		},
+		variables: gitStatusVariables,
+		properties: func(_ *starshipRenderer, props properties.Map) {
+			props[segments.FetchStatus] = true
+			props[segments.BranchIcon] = ""
+		},
+	},
+	"nodejs": starshipLanguage(NODE, "\uE718 ", "bold green"),
+	"golang": starshipLanguage(GOLANG, "🐹 ", "bold cyan"),
+	"rust":   starshipLanguage(RUST, "🦀 ", "bold red"),
+	"java":   starshipLanguage(JAVA, "☕ ", "red dimmed"),
+	"ruby":   starshipLanguage(RUBY, "💎 ", "bold red"),
+	"php":    starshipLanguage(PHP, "🐘 ", "147 bold"),
+	"lua":    starshipLanguage(LUA, "🌙 ", "bold blue"),
+	"python": starshipPython(),
+	"kubernetes": {
+		segment:  KUBECTL,
+		disabled: true,
+		options: map[string]any{
+			"format": `[$symbol$context( \($namespace\))]($style) in `,
+			"symbol": "☸ ",
+			"style":  "cyan bold",
+		},
+		variables: func(_ *starshipRenderer) map[string]*starshipVariable {
+			return map[string]*starshipVariable{
+				"context":   {template: "{{ .Context }}", condition: ".Context"},
+				"namespace": {template: "{{ .Namespace }}", condition: ".Namespace"},
+				"cluster":   {template: "{{ .Cluster }}", condition: ".Cluster"},
+				"user":      {template: "{{ .User }}", condition: ".User"},
+			}
+		},
+	},
+	"aws": {
+		segment: AWS,
+		options: map[string]any{
+			"format": `on [$symbol($profile )(\($region\) )(\[$duration\] )]($style)`,
+			"symbol": "☁️  ",
+			"style":  "bold yellow",
+		},
+		variables: func(_ *starshipRenderer) map[string]*starshipVariable {
+			return map[string]*starshipVariable{
+				"profile": {template: "{{ .Profile }}", condition: ".Profile"},
+				"region":  {template: "{{ .Region }}", condition: ".Region"},
+			}
+		},
+	},
+	"gcloud": {
+		segment: GCP,
+		options: map[string]any{
+			"format": `on [$symbol$account(@$domain)(\($region\))]($style) `,
+			"symbol": "☁️  ",
+			"style":  "bold blue",
+		},
+		variables: func(_ *starshipRenderer) map[string]*starshipVariable {
+			return map[string]*starshipVariable{
+				"account": {template: "{{ .Account }}", condition: ".Account"},
+				"project": {template: "{{ .Project }}", condition: ".Project"},
+				"region":  {template: "{{ .Region }}", condition: ".Region"},
+			}
+		},
+	},
+	"azure": {
+		segment:  AZ,
+		disabled: true,
+		options: map[string]any{
+			"format": "on [$symbol($subscription)]($style) ",
+			"symbol": "\U000F0805 ",
+			"style":  "blue bold",
+		},
+		variables: func(_ *starshipRenderer) map[string]*starshipVariable {
+			return map[string]*starshipVariable{
+				"subscription": {template: "{{ .Name }}", condition: ".Name"},
+				"username":     {template: "{{ .User.Name }}", condition: ".User.Name"},
+			}
+		},
+	},
+	"docker_context": {
+		segment: DOCKER,
+		options: map[string]any{
+			"format": "via [$symbol$context]($style) ",
+			"symbol": "🐳 ",
+			"style":  "blue bold",
+		},
+		variables: func(_ *starshipRenderer) map[string]*starshipVariable {
+			return map[string]*starshipVariable{
+				"context": {template: "{{ .Context }}", condition: ".Context"},
+			}
+		},
+	},
+	"terraform": {
+		segment: TERRAFORM,
+		options: map[string]any{
+			"format": "via [$symbol$workspace]($style) ",
+			"symbol": "💠 ",
+			"style":  "bold 105",
+		},
+		variables: func(_ *starshipRenderer) map[string]*starshipVariable {
+			return map[string]*starshipVariable{
+				"workspace": {template: "{{ .WorkspaceName }}", condition: ".WorkspaceName"},
+				"version":   {template: "{{ .Version }}", condition: ".Version"},
+			}
+		},
+	},
+	"cmd_duration": {
+		segment: EXECUTIONTIME,
+		options: map[string]any{
+			"format":   "took [$duration]($style) ",
+			"style":    "bold yellow",
+			"min_time": int64(2000),
+		},
+		variables: func(_ *starshipRenderer) map[string]*starshipVariable {
+			return map[string]*starshipVariable{
+				"duration": {template: "{{ .FormattedMs }}", condition: ".FormattedMs"},
+			}
+		},
+		properties: func(m *starshipRenderer, props properties.Map) {
+			minTime, _ := m.int("min_time")
+			props[segments.ThresholdProperty] = minTime
+			props[properties.Style] = string(segments.Austin)
+		},
+	},
+	"character": {
+		segment: TEXT,
+		options: map[string]any{
+			"format":         "$symbol ",
+			"success_symbol": "[❯](bold green)",
+			"error_symbol":   "[❯](bold red)",
+			"vimcmd_symbol":  "[❮](bold green)",
+		},
+		variables: func(m *starshipRenderer) map[string]*starshipVariable {
+			if m.isSet("vimcmd_symbol") {
+				m.warn("character.vimcmd_symbol is not supported")
+			}
+
+			symbol := fmt.Sprintf("{{ if gt .Code 0 }}%s{{ else }}%s{{ end }}",
+				m.format(m.string("error_symbol"), nil),
+				m.format(m.string("success_symbol"), nil))
+
+			return map[string]*starshipVariable{
+				"symbol": {template: symbol},
+			}
+		},
+	},
+	"time": {
+		segment:  TIME,
+		disabled: true,
+		options: map[string]any{
+			"format":      "at [$time]($style) ",
+			"style":       "bold yellow",
+			"time_format": "%T",
+			"use_12hr":    false,
+		},
+		variables: func(_ *starshipRenderer) map[string]*starshipVariable {
+			return map[string]*starshipVariable{
+				"time": {template: "{{ .CurrentDate | date .Format }}"},
+			}
+		},
+		properties: func(m *starshipRenderer, props properties.Map) {
+			format := m.string("time_format")
+			if !m.isSet("time_format") && m.bool("use_12hr") {
+				format = "%r"
+			}
+
+			props[segments.TimeFormat] = strftimeToLayout(m.starship, format)
+		},
+	},
+	"status": {
+		segment:  STATUS,
+		disabled: true,
+		options: map[string]any{
+			"format":         "[$symbol$status]($style) ",
+			"symbol":         "❌",
+			"success_symbol": "",
+			"style":          "bold red",
+		},
+		variables: func(m *starshipRenderer) map[string]*starshipVariable {
+			symbol := fmt.Sprintf("{{ if .Error }}%s{{ else }}%s{{ end }}",
+				m.format(m.string("symbol"), nil),
+				m.format(m.string("success_symbol"), nil))
+
+			return map[string]*starshipVariable{
+				"symbol":         {template: symbol},
+				"status":         {template: "{{ .Code }}", condition: ".Code"},
+				"common_meaning": {template: "{{ .Meaning }}", condition: ".Meaning"},
+			}
+		},
+	},
+	"battery": {
+		segment: BATTERY,
+		options: map[string]any{
+			"format":             "[$symbol$percentage]($style) ",
+			"full_symbol":        "\U000F0079 ",
+			"charging_symbol":    "\U000F0084 ",
+			"discharging_symbol": "\U000F0083 ",
+			"unknown_symbol":     "\U000F007D ",
+			"empty_symbol":       "\U000F008E ",
+			"style":              "bold red",
+			"display":            nil,
+		},
+		when: func(m *starshipRenderer) string {
+			// starship only shows the battery below the highest display threshold
+			threshold := int64(10)
+
+			if display, ok := m.option("display").([]any); ok {
+				threshold = 0
+
+				for _, item := range display {
+					setting, _ := item.(map[string]any)
+					if value, ok := setting["threshold"].(int64); ok && value > threshold {
+						threshold = value
+					}
+				}
+			}
+
+			return fmt.Sprintf("le .Percentage %d", threshold)
+		},
+		variables: func(_ *starshipRenderer) map[string]*starshipVariable {
+			return map[string]*starshipVariable{
+				"symbol":     {template: "{{ .Icon }}"},
+				"percentage": {template: "{{ .Percentage }}%", condition: "gt .Percentage 0"},
+			}
+		},
+		properties: func(m *starshipRenderer, props properties.Map) {
+			props[segments.ChargedIcon] = m.string("full_symbol")
+			props[segments.ChargingIcon] = m.string("charging_symbol")
+			props[segments.DischargingIcon] = m.string("discharging_symbol")
+		},
+	},
+	"shell": {
+		segment:  SHELL,
+		disabled: true,
+		options: map[string]any{
+			"format":               "[$indicator]($style) ",
+			"style":                "white bold",
+			"bash_indicator":       "bsh",
+			"fish_indicator":       "fsh",
+			"zsh_indicator":        "zsh",
+			"powershell_indicator": "psh",
+			"pwsh_indicator":       "psh",
+			"ion_indicator":        "ion",
+			"elvish_indicator":     "esh",
+			"tcsh_indicator":       "tsh",
+			"xonsh_indicator":      "xsh",
+			"cmd_indicator":        "cmd",
+			"nu_indicator":         "nu",
+		},
+		variables: func(_ *starshipRenderer) map[string]*starshipVariable {
+			return map[string]*starshipVariable{
+				"indicator": {template: "{{ .Name }}", condition: ".Name"},
+			}
+		},
+		properties: func(m *starshipRenderer, props properties.Map) {
+			names := make(map[string]any)
+
+			for option := range m.module.options {
+				shell, ok := strings.CutSuffix(option, "_indicator")
+				if !ok {
+					continue
+				}
+
+				if shell == "xonsh" || shell == "tcsh" || shell == "ion" {
+					continue
+				}
+
+				names[shell] = m.string(option)
+			}
+
+			props[segments.MappedShellNames] = names
+		},
+	},
+	"os": {
+		segment:  OS,
+		disabled: true,
+		options: map[string]any{
+			"format":  "[$symbol]($style)",
+			"style":   "bold white",
+			"symbols": nil,
+		},
+		variables: func(_ *starshipRenderer) map[string]*starshipVariable {
+			return map[string]*starshipVariable{
+				"symbol": {template: "{{ .Icon }}"},
+				"name":   {template: "{{ .OS }}", condition: ".OS"},
+			}
+		},
+		properties: func(m *starshipRenderer, props properties.Map) {
+			symbols, _ := m.option("symbols").(map[string]any)
+
+			for name, symbol := range symbols {
+				props[properties.Property(strings.ToLower(name))] = symbol
+			}
+		},
+	},
+	"env_var": {
+		segment: TEXT,
+		options: map[string]any{
+			"format":   "with [$env_value]($style) ",
+			"style":    "black bold dimmed",
+			"variable": "",
+			"default":  "",
+		},
+		enabled: func(m *starshipRenderer) bool {
+			return len(m.string("variable")) != 0 || len(m.key) != 0
+		},
+		variables: func(m *starshipRenderer) map[string]*starshipVariable {
+			name := m.string("variable")
+			if len(name) == 0 {
+				name = m.key
+			}
+
+			value := fmt.Sprintf("{{ .Env.%s }}", name)
+			if fallback := m.string("default"); len(fallback) != 0 {
+				value = fmt.Sprintf("{{ if .Env.%s }}{{ .Env.%s }}{{ else }}%s{{ end }}", name, name, fallback)
+			}
+
+			return map[string]*starshipVariable{
+				"env_value": {template: value, condition: ".Env." + name},
+			}
+		},
+	},
+	"custom": {
+		segment: CMD,
+		options: map[string]any{
+			"format":  "[$symbol($output )]($style)",
+			"style":   "bold green",
+			"command": "",
+			"shell":   nil,
+			"when":    false,
+		},
+		enabled: func(m *starshipRenderer) bool {
+			return len(m.string("command")) != 0
+		},
+		variables: func(m *starshipRenderer) map[string]*starshipVariable {
+			if m.isSet("when") {
+				m.warn("%s.when is not supported", m.name)
+			}
+
+			return map[string]*starshipVariable{
+				"output": {template: "{{ .Output }}", condition: ".Output"},
+			}
+		},
+		properties: func(m *starshipRenderer, props properties.Map) {
+			props[segments.Command] = m.string("command")
+
+			switch shell := m.option("shell").(type) {
+			case string:
+				props[segments.ExecutableShell] = shell
+			case []any:
+				if len(shell) != 0 {
+					props[segments.ExecutableShell] = shell[0]
+				}
+			}
+		},
+	},
+}
+
+func starshipLanguage(segment SegmentType, symbol, style string) *starshipModule {
+	return &starshipModule{
+		segment: segment,
+		options: map[string]any{
+			"format":         "via [$symbol($version )]($style)",
+			"version_format": "v${raw}",
+			"symbol":         symbol,
+			"style":          style,
+		},
+		variables: languageVariables,
+	}
+}
+
+func starshipPython() *starshipModule {
+	python := starshipLanguage(PYTHON, "🐍 ", "yellow bold")
+	python.options["format"] = `via [${symbol}${pyenv_prefix}(${version} )(\($virtualenv\) )]($style)`
+	python.variables = func(m *starshipRenderer) map[string]*starshipVariable {
+		variables := languageVariables(m)
+		variables["virtualenv"] = &starshipVariable{template: "{{ .Venv }}", condition: ".Venv"}
+		return variables
+	}
+
+	return python
+}
+
+func languageVariables(m *starshipRenderer) map[string]*starshipVariable {
+	version := m.format(m.string("version_format"), map[string]*starshipVariable{
+		"raw":   {template: "{{ .Full }}"},
+		"major": {template: "{{ .Major }}"},
+		"minor": {template: "{{ .Minor }}"},
+		"patch": {template: "{{ .Patch }}"},
+	})
+
+	return map[string]*starshipVariable{
+		"version": {template: version, condition: ".Full"},
+	}
+}
+
+func gitStatusVariables(m *starshipRenderer) map[string]*starshipVariable {
+	count := func(option, count string) *starshipVariable {
+		condition := fmt.Sprintf("gt %s 0", count)
+		symbol := m.format(m.string(option), map[string]*starshipVariable{
+			"count": {template: fmt.Sprintf("{{ %s }}", count)},
+		})
+
+		return &starshipVariable{
+			template:  fmt.Sprintf("{{ if %s }}%s{{ end }}", condition, symbol),
+			condition: condition,
+		}
+	}
+
+	variables := map[string]*starshipVariable{
+		"conflicted": count("conflicted", ".Working.Unmerged"),
+		"stashed":    count("stashed", ".StashCount"),
+		"deleted":    count("deleted", "(add .Working.Deleted .Staging.Deleted)"),
+		"modified":   count("modified", ".Working.Modified"),
+		"staged":     count("staged", "(add .Staging.Added .Staging.Modified .Staging.Deleted)"),
+		"untracked":  count("untracked", ".Working.Untracked"),
+	}
+
+	if m.isSet("renamed") {
+		m.warn("git_status.renamed is not supported, renames are shown as modified")
+	}
+
+	if m.isSet("typechanged") {
+		m.warn("git_status.typechanged is not supported")
+	}
+
+	counts := map[string]*starshipVariable{
+		"ahead_count":  {template: "{{ .Ahead }}"},
+		"behind_count": {template: "{{ .Behind }}"},
+		"count":        {template: "{{ .Ahead }}"},
+	}
+
+	ahead := m.format(m.string("ahead"), counts)
+	diverged := m.format(m.string("diverged"), counts)
+
+	counts["count"] = &starshipVariable{template: "{{ .Behind }}"}
+	behind := m.format(m.string("behind"), counts)
+
+	aheadBehind := fmt.Sprintf("{{ if and (gt .Ahead 0) (gt .Behind 0) }}%s{{ else if gt .Ahead 0 }}%s{{ else if gt .Behind 0 }}%s", diverged, ahead, behind)
+
+	if upToDate := m.format(m.string("up_to_date"), nil); len(upToDate) != 0 {
+		aheadBehind += fmt.Sprintf("{{ else }}%s", upToDate)
+	}
+
+	aheadBehind += "{{ end }}"
+
+	variables["ahead_behind"] = &starshipVariable{
+		template:  aheadBehind,
+		condition: "or (gt .Ahead 0) (gt .Behind 0)",
+	}
+
+	// all_status is a shorthand for the individual statuses
+	renderer := *m
+	renderer.variables = variables
+	renderer.userFormat = false
+
+	allStatus, conditions, _ := renderer.render(parseStarshipFormat("$conflicted$stashed$deleted$renamed$modified$typechanged$staged$untracked"))
+
+	variables["all_status"] = &starshipVariable{
+		template:  allStatus,
+		condition: fmt.Sprintf("or (%s)", strings.Join(conditions, ") (")),
+	}
+
+	return variables
+}
+
+// strftimeToLayout converts a strftime format into a Go time layout
+func strftimeToLayout(s *starship, format string) string {
+	directives := map[rune]string{
+		'a': "Mon",
+		'A': "Monday",
+		'b': "Jan",
+		'B': "January",
+		'd': "02",
+		'e': "_2",
+		'H': "15",
+		'I': "03",
+		'j': "002",
+		'm': "01",
+		'M': "04",
+		'p': "PM",
+		'S': "05",
+		'y': "06",
+		'Y': "2006",
+		'z': "-0700",
+		'Z': "MST",
+		'T': "15:04:05",
+		'R': "15:04",
+		'r': "03:04:05 PM",
+		'D': "01/02/06",
+		'F': "2006-01-02",
+		'%': "%",
+	}
+
+	var layout strings.Builder
+
+	runes := []rune(format)
+
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' || i == len(runes)-1 {
+			layout.WriteRune(runes[i])
+			continue
+		}
+
+		i++
+
+		directive, ok := directives[runes[i]]
+		if !ok {
+			s.warn("time format %%%c is not supported", runes[i])
+			continue
+		}
+
+		layout.WriteString(directive)
+	}
+
+	return layout.String()
+}
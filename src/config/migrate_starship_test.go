@@ -0,0 +1,208 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/properties"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateStarshipSegment(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Config   string
+		Expected *Segment
+		Warnings []string
+	}{
+		{
+			Case:   "Directory",
+			Config: "format = '$directory'\n[directory]\nformat = '[$path]($style) '\nstyle = 'bold cyan'\ntruncation_length = 0",
+			Expected: &Segment{
+				Type:       PATH,
+				Style:      Plain,
+				Foreground: "cyan",
+				Template:   "<b>{{ .Path }}</b> ",
+				Properties: properties.Map{
+					segments.HomeIcon: "~",
+					properties.Style:  segments.Full,
+				},
+			},
+		},
+		{
+			Case:   "Colors and background",
+			Config: "format = '$nodejs'\n[nodejs]\nformat = '[ $symbol($version) ]($style)[>](fg:#abcdef bg:prev_bg)'\nstyle = 'bg:bright-green fg:208'\nsymbol = 'N '",
+			Expected: &Segment{
+				Type:       NODE,
+				Style:      Plain,
+				Foreground: "208",
+				Background: "lightGreen",
+				Template:   " N {{ if .Full }}v{{ .Full }}{{ end }} <#abcdef,parentBackground>></>",
+			},
+		},
+		{
+			Case:   "Unstyled text",
+			Config: "format = '$aws'\n[aws]\nformat = 'on [$profile]($style) '\nstyle = 'bg:blue'",
+			Expected: &Segment{
+				Type:       AWS,
+				Style:      Plain,
+				Background: "blue",
+				Template:   "<,transparent>on </>{{ .Profile }}<,transparent> </>",
+			},
+		},
+		{
+			Case:   "Unsupported variables and options",
+			Config: "format = '$git_branch'\n[git_branch]\nformat = '[$branch( $remote_name)]($style)'\nstyle = 'red'\nonly_attached = true",
+			Expected: &Segment{
+				Type:       GIT,
+				Style:      Plain,
+				Foreground: "red",
+				Template:   "{{ .HEAD }}",
+				Properties: properties.Map{
+					segments.BranchIcon: "",
+				},
+			},
+			Warnings: []string{"git_branch.only_attached is not supported", "git_branch: variable $remote_name is not supported"},
+		},
+		{
+			Case:   "Character",
+			Config: "format = '$character'\n[character]\nsuccess_symbol = '[>](green)'\nerror_symbol = '[x](bold red)'",
+			Expected: &Segment{
+				Type:     TEXT,
+				Style:    Plain,
+				Template: "{{ if gt .Code 0 }}<red><b>x</b></>{{ else }}<green>></>{{ end }} ",
+			},
+		},
+		{
+			Case:   "Git status",
+			Config: "format = '$git_status'\n[git_status]\nformat = '([$modified$ahead_behind]($style))'\nstyle = 'yellow'\nmodified = '!${count}'\nahead = '⇡${count}'",
+			Expected: &Segment{
+				Type:       GIT,
+				Style:      Plain,
+				Foreground: "yellow",
+				Template:   "{{ if or (gt .Working.Modified 0) (or (gt .Ahead 0) (gt .Behind 0)) }}{{ if gt .Working.Modified 0 }}!{{ .Working.Modified }}{{ end }}{{ if and (gt .Ahead 0) (gt .Behind 0) }}⇕{{ else if gt .Ahead 0 }}⇡{{ .Ahead }}{{ else if gt .Behind 0 }}⇣{{ end }}{{ end }}", //nolint:lll
+				Properties: properties.Map{
+					segments.FetchStatus: true,
+					segments.BranchIcon:  "",
+				},
+			},
+		},
+		{
+			Case:   "Palette",
+			Config: "format = '$time'\npalette = 'mine'\n[palettes.mine]\naccent = '#ff0000'\n[time]\ndisabled = false\nformat = '[$time]($style)'\nstyle = 'accent'\ntime_format = '%H:%M %Q'", //nolint:lll
+			Expected: &Segment{
+				Type:       TIME,
+				Style:      Plain,
+				Foreground: "p:accent",
+				Template:   "{{ .CurrentDate | date .Format }}",
+				Properties: properties.Map{
+					segments.TimeFormat: "15:04 ",
+				},
+			},
+			Warnings: []string{"time format %Q is not supported"},
+		},
+		{
+			Case:   "Disabled by default",
+			Config: "format = '$kubernetes'",
+		},
+		{
+			Case:     "Unsupported module",
+			Config:   "format = '$jobs'",
+			Warnings: []string{"module jobs is not supported"},
+		},
+	}
+
+	for _, tc := range cases {
+		cfg, warnings, err := MigrateStarship([]byte(tc.Config))
+		require.NoError(t, err, tc.Case)
+
+		assert.Equal(t, tc.Warnings, warnings, tc.Case)
+
+		if tc.Expected == nil {
+			assert.Empty(t, cfg.Blocks, tc.Case)
+			continue
+		}
+
+		require.Len(t, cfg.Blocks, 1, tc.Case)
+		require.Len(t, cfg.Blocks[0].Segments, 1, tc.Case)
+		assert.Equal(t, tc.Expected, cfg.Blocks[0].Segments[0], tc.Case)
+	}
+}
+
+func TestMigrateStarshipBlocks(t *testing.T) {
+	config := `
+add_newline = false
+format = "[>](red)$directory$fill$cmd_duration$line_break$character"
+right_format = "$time"
+continuation_prompt = "[∙](bright-black) "
+
+[fill]
+symbol = "-"
+style = "blue"
+
+[time]
+disabled = false
+`
+
+	cfg, warnings, err := MigrateStarship([]byte(config))
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	require.Len(t, cfg.Blocks, 4)
+
+	left := cfg.Blocks[0]
+	assert.Equal(t, Left, left.Alignment)
+	assert.False(t, left.Newline)
+	require.Len(t, left.Segments, 2)
+	assert.Equal(t, TEXT, left.Segments[0].Type)
+	assert.Equal(t, "<red>></>", left.Segments[0].Template)
+	assert.Equal(t, PATH, left.Segments[1].Type)
+
+	right := cfg.Blocks[1]
+	assert.Equal(t, Right, right.Alignment)
+	assert.Equal(t, "<blue>-</>", right.Filler)
+	assert.Equal(t, EXECUTIONTIME, right.Segments[0].Type)
+
+	assert.True(t, cfg.Blocks[2].Newline)
+	assert.Equal(t, TEXT, cfg.Blocks[2].Segments[0].Type)
+
+	assert.Equal(t, RPrompt, cfg.Blocks[3].Type)
+	assert.Equal(t, TIME, cfg.Blocks[3].Segments[0].Type)
+
+	assert.Equal(t, "<darkGray>∙</> ", cfg.SecondaryPrompt.Template)
+}
+
+func TestMigrateStarshipAll(t *testing.T) {
+	cfg, warnings, err := MigrateStarship([]byte("format = '$all$directory'\nunknown = 1"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"unknown is not supported"}, warnings)
+
+	var types []SegmentType
+	for _, block := range cfg.Blocks {
+		for _, segment := range block.Segments {
+			types = append(types, segment.Type)
+		}
+	}
+
+	// directory is placed explicitly
+	assert.Equal(t, PATH, types[len(types)-1])
+	assert.Equal(t, 1, countSegmentType(types, PATH))
+	assert.True(t, cfg.Blocks[0].Newline, "add_newline defaults to true")
+
+	_, _, err = MigrateStarship([]byte("format = "))
+	assert.Error(t, err)
+}
+
+func countSegmentType(types []SegmentType, segmentType SegmentType) int {
+	var count int
+
+	for _, item := range types {
+		if item == segmentType {
+			count++
+		}
+	}
+
+	return count
+}
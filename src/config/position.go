@@ -0,0 +1,269 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+type position struct {
+	line   int
+	column int
+}
+
+// positions maps the path of every key and list item in a config file to its location.
+// Paths use the keys as they appear in the file, for example blocks[0].segments[1].type.
+type positions map[string]*position
+
+func newPositions(format string, data []byte) positions {
+	p := make(positions)
+
+	switch format {
+	case JSON:
+		p.json(data)
+	case YAML:
+		p.yaml(data)
+	case TOML:
+		p.toml(data)
+	}
+
+	return p
+}
+
+func (p positions) get(path string) *position {
+	if pos, ok := p[path]; ok {
+		return pos
+	}
+
+	// fall back to the closest parent that has a location
+	for len(path) != 0 {
+		index := strings.LastIndexAny(path, ".[")
+		if index == -1 {
+			break
+		}
+
+		path = path[:index]
+		if pos, ok := p[path]; ok {
+			return pos
+		}
+	}
+
+	return &position{}
+}
+
+func childPath(path, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func indexPath(path string, index int) string {
+	return fmt.Sprintf("%s[%d]", path, index)
+}
+
+func (p positions) json(data []byte) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	offset := func() *position {
+		index := int(decoder.InputOffset())
+
+		// skip separators until the start of the next token
+		for index < len(data) && strings.ContainsRune(" \t\r\n,:", rune(data[index])) {
+			index++
+		}
+
+		return offsetPosition(data, index)
+	}
+
+	var walk func(path string) error
+	walk = func(path string) error {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		switch token {
+		case json.Delim('{'):
+			for decoder.More() {
+				pos := offset()
+
+				token, err = decoder.Token()
+				if err != nil {
+					return err
+				}
+
+				key, _ := token.(string)
+				p[childPath(path, key)] = pos
+
+				if err = walk(childPath(path, key)); err != nil {
+					return err
+				}
+			}
+
+			_, err = decoder.Token()
+			return err
+		case json.Delim('['):
+			for index := 0; decoder.More(); index++ {
+				p[indexPath(path, index)] = offset()
+
+				if err = walk(indexPath(path, index)); err != nil {
+					return err
+				}
+			}
+
+			_, err = decoder.Token()
+			return err
+		}
+
+		return nil
+	}
+
+	_ = walk("")
+}
+
+func offsetPosition(data []byte, offset int) *position {
+	if offset > len(data) {
+		offset = len(data)
+	}
+
+	lead := data[:offset]
+
+	return &position{
+		line:   bytes.Count(lead, []byte{'\n'}) + 1,
+		column: len(lead) - bytes.LastIndexByte(lead, '\n'),
+	}
+}
+
+func (p positions) yaml(data []byte) {
+	file, err := parser.ParseBytes(data, 0)
+	if err != nil || len(file.Docs) == 0 {
+		return
+	}
+
+	tokenPosition := func(node ast.Node) *position {
+		token := node.GetToken()
+		if token == nil || token.Position == nil {
+			return &position{}
+		}
+
+		return &position{
+			line:   token.Position.Line,
+			column: token.Position.Column,
+		}
+	}
+
+	var walk func(path string, node ast.Node)
+	walk = func(path string, node ast.Node) {
+		switch node := node.(type) {
+		case *ast.DocumentNode:
+			walk(path, node.Body)
+		case *ast.AnchorNode:
+			walk(path, node.Value)
+		case *ast.TagNode:
+			walk(path, node.Value)
+		case *ast.MappingNode:
+			for _, value := range node.Values {
+				walk(path, value)
+			}
+		case *ast.MappingValueNode:
+			key := childPath(path, node.Key.GetToken().Value)
+			p[key] = tokenPosition(node.Key)
+			walk(key, node.Value)
+		case *ast.SequenceNode:
+			for index, value := range node.Values {
+				p[indexPath(path, index)] = tokenPosition(value)
+				walk(indexPath(path, index), value)
+			}
+		}
+	}
+
+	walk("", file.Docs[0])
+}
+
+func (p positions) toml(data []byte) {
+	var tomlParser unstable.Parser
+	tomlParser.Reset(data)
+
+	keyPosition := func(node *unstable.Node) *position {
+		shape := tomlParser.Shape(node.Raw)
+		return &position{
+			line:   shape.Start.Line,
+			column: shape.Start.Column,
+		}
+	}
+
+	// the number of elements in every array of tables
+	arrays := make(map[string]int)
+
+	resolve := func(table string, keys unstable.Iterator) (string, *unstable.Node) {
+		path := table
+		var last *unstable.Node
+
+		for keys.Next() {
+			last = keys.Node()
+			path = childPath(path, string(last.Data))
+
+			if count, ok := arrays[path]; ok && !keys.IsLast() {
+				path = indexPath(path, count-1)
+			}
+		}
+
+		return path, last
+	}
+
+	var value func(path string, node *unstable.Node)
+	value = func(path string, node *unstable.Node) {
+		switch node.Kind { //nolint:exhaustive
+		case unstable.InlineTable:
+			children := node.Children()
+			for children.Next() {
+				keyValue := children.Node()
+				key, last := resolve(path, keyValue.Key())
+				p[key] = keyPosition(last)
+				value(key, keyValue.Value())
+			}
+		case unstable.Array:
+			children := node.Children()
+			for index := 0; children.Next(); index++ {
+				child := children.Node()
+				p[indexPath(path, index)] = p[path]
+
+				if child.Kind != unstable.InlineTable && child.Kind != unstable.Array {
+					p[indexPath(path, index)] = keyPosition(child)
+				}
+
+				value(indexPath(path, index), child)
+			}
+		}
+	}
+
+	var table string
+
+	for tomlParser.NextExpression() {
+		expression := tomlParser.Expression()
+
+		switch expression.Kind { //nolint:exhaustive
+		case unstable.Table:
+			path, last := resolve("", expression.Key())
+			table = path
+			p[path] = keyPosition(last)
+		case unstable.ArrayTable:
+			path, last := resolve("", expression.Key())
+			table = indexPath(path, arrays[path])
+			arrays[path]++
+			p[path] = keyPosition(last)
+			p[table] = keyPosition(last)
+		case unstable.KeyValue:
+			path, last := resolve(table, expression.Key())
+			p[path] = keyPosition(last)
+			value(path, expression.Value())
+		}
+	}
+}
@@ -46,9 +46,15 @@ type Segment struct {
 	Alias                  string         `json:"alias,omitempty" toml:"alias,omitempty"`
 	styleCache             SegmentStyle
 	name                   string
+	timeoutText            string
+	fileState              string
+	asyncValue             *asyncValue
+	origins                []string
+	keys                   map[string]bool
 	LeadingDiamond         string         `json:"leading_diamond,omitempty" toml:"leading_diamond,omitempty"`
 	TrailingDiamond        string         `json:"trailing_diamond,omitempty" toml:"trailing_diamond,omitempty"`
 	Template               string         `json:"template,omitempty" toml:"template,omitempty"`
+	TimeoutTemplate        string         `json:"timeout_template,omitempty" toml:"timeout_template,omitempty"`
 	Foreground             color.Ansi     `json:"foreground,omitempty" toml:"foreground,omitempty"`
 	TemplatesLogic         template.Logic `json:"templates_logic,omitempty" toml:"templates_logic,omitempty"`
 	PowerlineSymbol        string         `json:"powerline_symbol,omitempty" toml:"powerline_symbol,omitempty"`
@@ -68,10 +74,14 @@ type Segment struct {
 	MaxWidth               int            `json:"max_width,omitempty" toml:"max_width,omitempty"`
 	MinWidth               int            `json:"min_width,omitempty" toml:"min_width,omitempty"`
 	Duration               time.Duration  `json:"-" toml:"-"`
+	Timeout                int            `json:"timeout,omitempty" toml:"timeout,omitempty"`
+	Priority               int            `json:"priority,omitempty" toml:"priority,omitempty"`
 	Interactive            bool           `json:"interactive,omitempty" toml:"interactive,omitempty"`
 	Enabled                bool           `json:"-" toml:"-"`
 	Newline                bool           `json:"newline,omitempty" toml:"newline,omitempty"`
 	InvertPowerline        bool           `json:"invert_powerline,omitempty" toml:"invert_powerline,omitempty"`
+	TimedOut               bool           `json:"-" toml:"-"`
+	Async                  bool           `json:"async,omitempty" toml:"async,omitempty"`
 	restored               bool           `json:"-" toml:"-"`
 }
 
@@ -90,9 +100,9 @@ func (segment *Segment) Name() string {
 }
 
 func (segment *Segment) Execute(env runtime.Environment) {
-	// segment timings for debug purposes
+	// segment timings for debug purposes, the json output and benchmarks
 	var start time.Time
-	if env.Flags().Debug {
+	if env.Flags().Debug || env.Flags().JSON || env.Flags().Bench {
 		start = time.Now()
 		segment.NameLength = len(segment.Name())
 		defer func() {
@@ -117,13 +127,17 @@ func (segment *Segment) Execute(env runtime.Environment) {
 		return
 	}
 
+	if segment.restoreAsync() {
+		return
+	}
+
 	if shouldHideForWidth(segment.env, segment.MinWidth, segment.MaxWidth) {
 		return
 	}
 
 	if segment.writer.Enabled() {
 		segment.Enabled = true
-		template.Cache.AddSegmentData(segment.Name(), segment.writer)
+		template.Cache.AddSegmentData(segment.Name(), segment.templateData())
 	}
 }
 
@@ -144,7 +158,16 @@ func (segment *Segment) Render() {
 	segment.setCache()
 
 	// We do this to make `.Text` available for a cross-segment reference in an extra prompt.
-	template.Cache.AddSegmentData(segment.Name(), segment.writer)
+	template.Cache.AddSegmentData(segment.Name(), segment.templateData())
+}
+
+// templateData returns the data other templates can access using .Segments
+func (segment *Segment) templateData() any {
+	if dynamic, ok := segment.writer.(template.Dynamic); ok {
+		return dynamic.TemplateData()
+	}
+
+	return segment.writer
 }
 
 func (segment *Segment) Text() string {
@@ -223,6 +246,11 @@ func (segment *Segment) restoreCache() bool {
 		return false
 	}
 
+	// keep the state of the watched files to store it with the new value
+	if len(segment.Cache.Watch) != 0 {
+		segment.fileState = runtime.FileState(segment.env, segment.Cache.Watch)
+	}
+
 	cacheKey := segment.cacheKey()
 	data, OK := segment.env.Session().Get(cacheKey)
 	if !OK {
@@ -230,19 +258,28 @@ func (segment *Segment) restoreCache() bool {
 		return false
 	}
 
+	if len(segment.Cache.Watch) != 0 && cache.FileStateChanged(segment.env.Session(), cacheKey, segment.fileState) {
+		log.Debugf("watched files changed for segment: %s, key: %s", segment.Name(), cacheKey)
+		return false
+	}
+
+	segment.restore(data)
+
+	log.Debug("restored segment from cache: ", segment.Name())
+
+	return true
+}
+
+func (segment *Segment) restore(data string) {
 	err := json.Unmarshal([]byte(data), &segment.writer)
 	if err != nil {
 		log.Error(err)
 	}
 
 	segment.Enabled = true
-	template.Cache.AddSegmentData(segment.Name(), segment.writer)
-
-	log.Debug("restored segment from cache: ", segment.Name())
+	template.Cache.AddSegmentData(segment.Name(), segment.templateData())
 
 	segment.restored = true
-
-	return true
 }
 
 func (segment *Segment) setCache() {
@@ -256,7 +293,12 @@ func (segment *Segment) setCache() {
 		return
 	}
 
-	segment.env.Session().Set(segment.cacheKey(), string(data), segment.Cache.Duration)
+	cacheKey := segment.cacheKey()
+	segment.env.Session().Set(cacheKey, string(data), segment.Cache.Duration)
+
+	if len(segment.Cache.Watch) != 0 {
+		cache.SetFileState(segment.env.Session(), cacheKey, segment.fileState, segment.Cache.Duration)
+	}
 }
 
 func (segment *Segment) cacheKey() string {
@@ -285,6 +327,10 @@ func (segment *Segment) folderKey() string {
 }
 
 func (segment *Segment) string() string {
+	if segment.TimedOut {
+		return segment.timeoutString()
+	}
+
 	result := segment.Templates.Resolve(segment.writer, "", segment.TemplatesLogic)
 	if len(result) != 0 {
 		return result
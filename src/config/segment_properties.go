@@ -0,0 +1,785 @@
+// Code generated by segment_properties_gen.go; DO NOT EDIT.
+
+package config
+
+import "github.com/jandedobbeleer/oh-my-posh/src/properties"
+
+// genericProperties can be set on any segment.
+var genericProperties = []properties.Property{
+	"access_token",
+	"always_enabled",
+	"cache_duration",
+	"display_default",
+	"display_error",
+	"fetch_version",
+	"files",
+	"http_timeout",
+	"refresh_token",
+	"style",
+	"version_url_template",
+}
+
+// segmentProperties contains the properties every segment supports on top of the generic ones.
+// Segments resolving property names at runtime are omitted.
+var segmentProperties = map[SegmentType][]properties.Property{
+	ANGULAR: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	ARGOCD: {},
+	AURELIA: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	AWS: {},
+	AZ: {
+		"source",
+		"type",
+	},
+	AZD: {
+		"folders",
+	},
+	AZFUNC: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	BATTERY: {
+		"charged_icon",
+		"charging_icon",
+		"discharging_icon",
+		"icon",
+		"not_charging_icon",
+	},
+	BAZEL: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"icon",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	BREWFATHER: {
+		"api_key",
+		"archived_status_icon",
+		"batch_id",
+		"brewing_status_icon",
+		"completed_status_icon",
+		"conditioning_status_icon",
+		"day_icon",
+		"doubledown_icon",
+		"doubleup_icon",
+		"fermenting_status_icon",
+		"flat_icon",
+		"fortyfivedown_icon",
+		"fortyfiveup_icon",
+		"planning_status_icon",
+		"singledown_icon",
+		"singleup_icon",
+		"url",
+		"user_id",
+	},
+	BUF: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	BUN: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	CARBONINTENSITY: {},
+	CDS: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	CF: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	CFTARGET: {
+		"display_mode",
+		"url",
+	},
+	CMAKE: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	CMD: {
+		"command",
+		"interpret",
+		"script",
+		"shell",
+	},
+	CONNECTION: {
+		"type",
+	},
+	CRYSTAL: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	DART: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	DENO: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	DOCKER: {},
+	DOTNET: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	ELIXIR: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	EXECUTIONTIME: {
+		"threshold",
+	},
+	EXIT: {
+		"status_separator",
+		"status_template",
+	},
+	FIREBASE: {},
+	FLUTTER: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	FORTRAN: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	FOSSIL: {
+		"branch_max_length",
+		"full_branch_path",
+		"mapped_branches",
+		"native_fallback",
+		"status_formats",
+		"truncate_symbol",
+	},
+	GCP: {},
+	GIT: {
+		"azure_devops_icon",
+		"bitbucket_icon",
+		"branch_ahead_icon",
+		"branch_behind_icon",
+		"branch_gone_icon",
+		"branch_icon",
+		"branch_identical_icon",
+		"branch_max_length",
+		"cherry_pick_icon",
+		"codeberg_icon",
+		"codecommit_icon",
+		"commit_icon",
+		"disable_with_jj",
+		"fetch_bare_info",
+		"fetch_stash_count",
+		"fetch_status",
+		"fetch_upstream_icon",
+		"fetch_user",
+		"fetch_worktree_count",
+		"full_branch_path",
+		"git_icon",
+		"github_icon",
+		"gitlab_icon",
+		"icon",
+		"ignore_status",
+		"ignore_submodules",
+		"mapped_branches",
+		"merge_icon",
+		"native",
+		"native_fallback",
+		"no_commits_icon",
+		"rebase_icon",
+		"revert_icon",
+		"source",
+		"status_formats",
+		"tag_icon",
+		"truncate_symbol",
+		"untracked_modes",
+		"upstream_icons",
+	},
+	GITVERSION: {},
+	GOLANG: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"parse_mod_file",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	HASKELL: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"stack_ghc_mode",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	HELM: {
+		"display_mode",
+	},
+	HTTP: {
+		"body",
+		"fields",
+		"headers",
+		"method",
+		"url",
+	},
+	IPIFY: {
+		"url",
+	},
+	JAVA: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	JJ: {
+		"branch_max_length",
+		"fetch_status",
+		"full_branch_path",
+		"mapped_branches",
+		"native_fallback",
+		"status_formats",
+		"truncate_symbol",
+	},
+	JULIA: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	KOTLIN: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	KUBECTL: {
+		"context_aliases",
+		"parse_kubeconfig",
+	},
+	LASTFM: {
+		"api_key",
+		"icon",
+		"playing_icon",
+		"stopped_icon",
+		"username",
+	},
+	LUA: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"preferred_executable",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	MERCURIAL: {
+		"branch_max_length",
+		"fetch_status",
+		"full_branch_path",
+		"mapped_branches",
+		"native_fallback",
+		"status_formats",
+		"truncate_symbol",
+	},
+	MOJO: {
+		"display_mode",
+		"extensions",
+		"fetch_virtual_env",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	MVN: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	NBA: {
+		"days_offset",
+		"finished_template",
+		"in_progress_template",
+		"scheduled_template",
+		"season",
+		"team",
+	},
+	NBGV: {},
+	NIGHTSCOUT: {
+		"doubledown_icon",
+		"doubleup_icon",
+		"flat_icon",
+		"fortyfivedown_icon",
+		"fortyfiveup_icon",
+		"headers",
+		"singledown_icon",
+		"singleup_icon",
+		"url",
+	},
+	NIXSHELL: {
+		"type",
+	},
+	NODE: {
+		"display_mode",
+		"extensions",
+		"fetch_package_manager",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"npm_icon",
+		"pnpm_icon",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+		"yarn_icon",
+	},
+	NPM: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	NX: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	OCAML: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	OWM: {
+		"apiKey",
+		"api_key",
+		"location",
+		"units",
+		"url",
+	},
+	PATH: {
+		"cycle",
+		"cycle_folder_separator",
+		"display_cygpath",
+		"edge_format",
+		"folder_format",
+		"folder_icon",
+		"folder_separator_icon",
+		"folder_separator_template",
+		"gitdir_format",
+		"hide_root_location",
+		"home_icon",
+		"left_format",
+		"location",
+		"mapped_locations",
+		"mapped_locations_enabled",
+		"max_depth",
+		"max_width",
+		"mixed_threshold",
+		"right_format",
+		"windows_registry_icon",
+	},
+	PERL: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	PHP: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	PLASTIC: {
+		"branch_icon",
+		"branch_max_length",
+		"commit_icon",
+		"fetch_status",
+		"full_branch_path",
+		"mapped_branches",
+		"native_fallback",
+		"status_formats",
+		"tag_icon",
+		"truncate_symbol",
+	},
+	PNPM: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	PROJECT: {
+		"type",
+	},
+	PULUMI: {
+		"fetch_about",
+		"fetch_stack",
+	},
+	PYTHON: {
+		"default_venv_names",
+		"display_mode",
+		"extensions",
+		"fetch_virtual_env",
+		"folder_name_fallback",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"use_python_version_file",
+		"version_strategy",
+	},
+	QUASAR: {
+		"display_mode",
+		"extensions",
+		"fetch_dependencies",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	R: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	REACT: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	ROOT: {},
+	RUBY: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	RUST: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	SAPLING: {
+		"branch_max_length",
+		"fetch_status",
+		"full_branch_path",
+		"mapped_branches",
+		"native_fallback",
+		"status_formats",
+		"truncate_symbol",
+	},
+	SESSION: {},
+	SHELL: {
+		"mapped_shell_names",
+	},
+	SITECORE: {},
+	SPOTIFY: {
+		"icon",
+		"paused_icon",
+		"playing_icon",
+		"stopped_icon",
+	},
+	STATUS: {
+		"status_separator",
+		"status_template",
+	},
+	STRAVA: {
+		"icon",
+		"ride_icon",
+		"run_icon",
+		"skiing_icon",
+		"type",
+		"unknown_activity_icon",
+		"url",
+		"workout_icon",
+	},
+	SVELTE: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	SVN: {
+		"branch_max_length",
+		"fetch_status",
+		"full_branch_path",
+		"mapped_branches",
+		"native_fallback",
+		"status_formats",
+		"truncate_symbol",
+	},
+	SWIFT: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	SYSTEMINFO: {
+		"precision",
+	},
+	TALOSCTL: {},
+	TAURI: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	TERRAFORM: {},
+	TEXT:      {},
+	TIME: {
+		"time_format",
+	},
+	UI5TOOLING: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	UMBRACO: {},
+	UNITY:   {},
+	UPGRADE: {
+		"source",
+	},
+	VALA: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	WAKATIME: {
+		"url",
+	},
+	WINREG: {
+		"fallback",
+		"path",
+	},
+	WITHINGS: {},
+	XMAKE: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	YARN: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+	YTM: {
+		"api_url",
+		"icon",
+		"paused_icon",
+		"playing_icon",
+		"stopped_icon",
+	},
+	ZIG: {
+		"display_mode",
+		"extensions",
+		"folders",
+		"home_enabled",
+		"missing_command_text",
+		"tool_manager_version",
+		"url",
+		"version_strategy",
+	},
+}
@@ -0,0 +1,417 @@
+//go:build ignore
+
+// This program generates segment_properties.go, the list of properties every segment
+// supports. It walks the methods of each segment writer, including the ones of embedded
+// types and the package level functions they call, and collects all references to
+// properties.Property constants next to the ones declared in the same files.
+//
+// Run it using go generate from the config folder.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	segmentsDir   = "../segments"
+	propertiesDir = "../properties"
+	segmentTypes  = "segment_types.go"
+	output        = "segment_properties.go"
+)
+
+type function struct {
+	properties map[string]bool
+	calls      map[string]bool
+	dynamic    bool
+}
+
+type generator struct {
+	declared   map[string][]string
+	files      map[string][]string
+	constants  map[string]string
+	generic    map[string]string
+	functions  map[string]*function
+	methods    map[string][]string
+	embeds     map[string][]string
+	properties map[string]bool
+	visited    map[string]bool
+	dynamic    bool
+}
+
+func main() {
+	g := &generator{
+		declared:  make(map[string][]string),
+		files:     make(map[string][]string),
+		constants: make(map[string]string),
+		generic:   make(map[string]string),
+		functions: make(map[string]*function),
+		methods:   make(map[string][]string),
+		embeds:    make(map[string][]string),
+	}
+
+	fset := token.NewFileSet()
+
+	for _, file := range parseDir(fset, propertiesDir) {
+		g.collectConstants(fset, file, "Property", g.generic)
+	}
+
+	files := parseDir(fset, segmentsDir)
+
+	for _, file := range files {
+		g.collectConstants(fset, file, "properties.Property", g.constants)
+		g.collectTypes(file)
+	}
+
+	for _, file := range files {
+		g.collectFunctions(fset, file)
+	}
+
+	writers := segmentWriters(fset)
+
+	var buf bytes.Buffer
+
+	buf.WriteString("// Code generated by segment_properties_gen.go; DO NOT EDIT.\n\n")
+	buf.WriteString("package config\n\n")
+	buf.WriteString("import \"github.com/jandedobbeleer/oh-my-posh/src/properties\"\n\n")
+	buf.WriteString("// genericProperties can be set on any segment.\n")
+	buf.WriteString("var genericProperties = []properties.Property{\n")
+	writeValues(&buf, g.generic)
+	buf.WriteString("}\n\n")
+	buf.WriteString("// segmentProperties contains the properties every segment supports on top of the generic ones.\n")
+	buf.WriteString("// Segments resolving property names at runtime are omitted.\n")
+	buf.WriteString("var segmentProperties = map[SegmentType][]properties.Property{\n")
+
+	keys := make([]string, 0, len(writers))
+	for key := range writers {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		values, dynamic := g.resolve(writers[key])
+		if dynamic {
+			continue
+		}
+
+		fmt.Fprintf(&buf, "%s: {\n", key)
+		writeValues(&buf, values)
+		buf.WriteString("},\n")
+	}
+
+	buf.WriteString("}\n")
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		fail(err)
+	}
+
+	if err := os.WriteFile(output, source, 0644); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+
+func parseDir(fset *token.FileSet, dir string) []*ast.File {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		fail(err)
+	}
+
+	var files []*ast.File
+
+	for _, match := range matches {
+		if strings.HasSuffix(match, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, match, nil, 0)
+		if err != nil {
+			fail(err)
+		}
+
+		files = append(files, file)
+	}
+
+	return files
+}
+
+func writeValues(buf *bytes.Buffer, values map[string]string) {
+	list := make([]string, 0, len(values))
+	for _, value := range values {
+		list = append(list, value)
+	}
+
+	slices.Sort(list)
+	list = slices.Compact(list)
+
+	for _, value := range list {
+		fmt.Fprintf(buf, "%q,\n", value)
+	}
+}
+
+func (g *generator) collectConstants(fset *token.FileSet, file *ast.File, typeName string, constants map[string]string) {
+	fileName := fset.File(file.Pos()).Name()
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			value, ok := spec.(*ast.ValueSpec)
+			if !ok || value.Type == nil || exprString(value.Type) != typeName {
+				continue
+			}
+
+			for i, name := range value.Names {
+				if i >= len(value.Values) {
+					continue
+				}
+
+				lit, ok := value.Values[i].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+
+				text, err := strconv.Unquote(lit.Value)
+				if err != nil {
+					fail(err)
+				}
+
+				constants[name.Name] = text
+				g.declared[fileName] = append(g.declared[fileName], text)
+			}
+		}
+	}
+}
+
+func (g *generator) collectTypes(file *ast.File) {
+	ast.Inspect(file, func(node ast.Node) bool {
+		spec, ok := node.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+
+		str, ok := spec.Type.(*ast.StructType)
+		if !ok {
+			return false
+		}
+
+		for _, field := range str.Fields.List {
+			if len(field.Names) != 0 {
+				continue
+			}
+
+			name := strings.TrimPrefix(exprString(field.Type), "*")
+			g.embeds[spec.Name.Name] = append(g.embeds[spec.Name.Name], name)
+		}
+
+		return false
+	})
+}
+
+func (g *generator) collectFunctions(fset *token.FileSet, file *ast.File) {
+	fileName := fset.File(file.Pos()).Name()
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+
+		key := fn.Name.Name
+		if fn.Recv != nil && len(fn.Recv.List) == 1 {
+			receiver := strings.TrimPrefix(exprString(fn.Recv.List[0].Type), "*")
+			g.methods[receiver] = append(g.methods[receiver], key)
+			g.files[receiver] = append(g.files[receiver], fileName)
+			key = receiver + "." + key
+		}
+
+		f := &function{
+			properties: make(map[string]bool),
+			calls:      make(map[string]bool),
+		}
+
+		ast.Inspect(fn.Body, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.CallExpr:
+				switch fun := node.Fun.(type) {
+				case *ast.Ident:
+					f.calls[fun.Name] = true
+				case *ast.SelectorExpr:
+					switch exprString(fun) {
+					case "properties.Property":
+						if _, ok := node.Args[0].(*ast.BasicLit); !ok {
+							f.dynamic = true
+						}
+					case "properties.OneOf":
+						// alternative property names can be passed as literals
+						for _, arg := range node.Args[2:] {
+							if lit, ok := arg.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+								text, _ := strconv.Unquote(lit.Value)
+								f.properties[text] = true
+							}
+						}
+					}
+				}
+			case *ast.SelectorExpr:
+				pkg, ok := node.X.(*ast.Ident)
+				if !ok || pkg.Name != "properties" {
+					return true
+				}
+
+				if value, ok := g.generic[node.Sel.Name]; ok {
+					f.properties[value] = true
+				}
+
+				// reading the wrapped map gives access to every property
+				if node.Sel.Name == "Wrapper" {
+					f.dynamic = true
+				}
+
+				return false
+			case *ast.Ident:
+				if value, ok := g.constants[node.Name]; ok {
+					f.properties[value] = true
+				}
+			}
+
+			return true
+		})
+
+		g.functions[key] = f
+	}
+}
+
+// resolve returns the properties read by the given type and whether it resolves property names at runtime
+func (g *generator) resolve(typeName string) (map[string]string, bool) {
+	g.properties = make(map[string]bool)
+	g.visited = make(map[string]bool)
+	g.dynamic = false
+
+	g.visitType(typeName)
+
+	generic := make(map[string]bool, len(g.generic))
+	for _, value := range g.generic {
+		generic[value] = true
+	}
+
+	values := make(map[string]string, len(g.properties))
+	for value := range g.properties {
+		if generic[value] {
+			continue
+		}
+
+		values[value] = value
+	}
+
+	return values, g.dynamic
+}
+
+func (g *generator) visitType(typeName string) {
+	if g.visited["type:"+typeName] {
+		return
+	}
+
+	g.visited["type:"+typeName] = true
+
+	for _, method := range g.methods[typeName] {
+		g.visitFunction(typeName + "." + method)
+	}
+
+	for _, file := range g.files[typeName] {
+		for _, value := range g.declared[file] {
+			g.properties[value] = true
+		}
+	}
+
+	for _, embed := range g.embeds[typeName] {
+		g.visitType(embed)
+	}
+}
+
+func (g *generator) visitFunction(key string) {
+	f, ok := g.functions[key]
+	if !ok || g.visited[key] {
+		return
+	}
+
+	g.visited[key] = true
+
+	if f.dynamic {
+		g.dynamic = true
+	}
+
+	for value := range f.properties {
+		g.properties[value] = true
+	}
+
+	for call := range f.calls {
+		g.visitFunction(call)
+	}
+}
+
+// segmentWriters maps the segment type constants to the writer types in the Segments map
+func segmentWriters(fset *token.FileSet) map[string]string {
+	file, err := parser.ParseFile(fset, segmentTypes, nil, 0)
+	if err != nil {
+		fail(err)
+	}
+
+	writers := make(map[string]string)
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		kv, ok := node.(*ast.KeyValueExpr)
+		if !ok {
+			return true
+		}
+
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		ast.Inspect(kv.Value, func(node ast.Node) bool {
+			sel, ok := node.(*ast.SelectorExpr)
+			if ok && exprString(sel.X) == "segments" {
+				writers[key.Name] = sel.Sel.Name
+				return false
+			}
+
+			return true
+		})
+
+		return false
+	})
+
+	return writers
+}
+
+func exprString(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name
+	case *ast.StarExpr:
+		return "*" + exprString(expr.X)
+	case *ast.SelectorExpr:
+		return exprString(expr.X) + "." + expr.Sel.Name
+	default:
+		return ""
+	}
+}
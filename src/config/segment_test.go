@@ -2,12 +2,16 @@ package config
 
 import (
 	"encoding/json"
+	"path/filepath"
 	"testing"
 
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
 	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/maps"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime/mock"
 	"github.com/jandedobbeleer/oh-my-posh/src/segments"
+	"github.com/jandedobbeleer/oh-my-posh/src/template"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -203,3 +207,52 @@ func TestEvaluateNeeds(t *testing.T) {
 		assert.Equal(t, tc.Needs, tc.Segment.Needs, tc.Case)
 	}
 }
+
+func TestRestoreCacheWatch(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Watch    []string
+		Modify   bool
+		Expected bool
+	}{
+		{Case: "No watch", Modify: true, Expected: true},
+		{Case: "Unchanged files", Watch: []string{"package.json", ".nvmrc"}, Expected: true},
+		{Case: "Changed files", Watch: []string{"package.json", ".nvmrc"}, Modify: true},
+	}
+
+	for _, tc := range cases {
+		folder := "/home/jan/code"
+		nvmrc := filepath.Join(folder, ".nvmrc")
+		modified := []*runtime.FileStat{{Path: nvmrc, Size: 2}}
+		if tc.Modify {
+			modified = []*runtime.FileStat{{Path: nvmrc, Size: 4}}
+		}
+
+		session := &cache.Store{}
+		session.Init(filepath.Join(t.TempDir(), cache.FileName), false)
+
+		template.Cache = &cache.Template{
+			Segments: maps.NewConcurrent(),
+		}
+
+		env := new(mock.Environment)
+		env.On("Pwd").Return(folder)
+		env.On("Home").Return("/home/jan")
+		env.On("Session").Return(session)
+		env.On("StatFiles", filepath.Join(folder, "package.json")).Return([]*runtime.FileStat{})
+		env.On("StatFiles", nvmrc).Return([]*runtime.FileStat{{Path: nvmrc, Size: 2}}).Once()
+		env.On("StatFiles", nvmrc).Return(modified)
+
+		segment := &Segment{
+			Type:  TEXT,
+			Cache: &cache.Config{Duration: cache.ONEDAY, Watch: tc.Watch},
+		}
+
+		_ = segment.MapSegmentWithWriter(env)
+		assert.False(t, segment.restoreCache(), tc.Case)
+
+		segment.setCache()
+
+		assert.Equal(t, tc.Expected, segment.restoreCache(), tc.Case)
+	}
+}
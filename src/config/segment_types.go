@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
 	"github.com/jandedobbeleer/oh-my-posh/src/properties"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
 	"github.com/jandedobbeleer/oh-my-posh/src/segments"
@@ -103,10 +104,14 @@ const (
 	HASKELL SegmentType = "haskell"
 	// HELM segment
 	HELM SegmentType = "helm"
+	// HTTP requests a JSON document from a URL
+	HTTP SegmentType = "http"
 	// IPIFY segment
 	IPIFY SegmentType = "ipify"
 	// JAVA writes the active java version
 	JAVA SegmentType = "java"
+	// JJ represents the jj segment
+	JJ SegmentType = "jj"
 	// JULIA writes which julia version is currently active
 	JULIA SegmentType = "julia"
 	// KOTLIN writes the active kotlin version
@@ -151,6 +156,8 @@ const (
 	PHP SegmentType = "php"
 	// PLASTIC represents the plastic scm status and information
 	PLASTIC SegmentType = "plastic"
+	// PLUGIN runs an executable that answers with JSON
+	PLUGIN SegmentType = "plugin"
 	// pnpm version
 	PNPM SegmentType = "pnpm"
 	// Project version
@@ -229,6 +236,8 @@ const (
 	ZIG SegmentType = "zig"
 )
 
+//go:generate go run segment_properties_gen.go
+
 // Segments contains all available prompt segment writers.
 // Consumers of the library can also add their own segment writer.
 var Segments = map[SegmentType]func() SegmentWriter{
@@ -269,8 +278,10 @@ var Segments = map[SegmentType]func() SegmentWriter{
 	GOLANG:          func() SegmentWriter { return &segments.Golang{} },
 	HASKELL:         func() SegmentWriter { return &segments.Haskell{} },
 	HELM:            func() SegmentWriter { return &segments.Helm{} },
+	HTTP:            func() SegmentWriter { return &segments.HTTP{} },
 	IPIFY:           func() SegmentWriter { return &segments.IPify{} },
 	JAVA:            func() SegmentWriter { return &segments.Java{} },
+	JJ:              func() SegmentWriter { return &segments.Jj{} },
 	JULIA:           func() SegmentWriter { return &segments.Julia{} },
 	KOTLIN:          func() SegmentWriter { return &segments.Kotlin{} },
 	KUBECTL:         func() SegmentWriter { return &segments.Kubectl{} },
@@ -293,6 +304,7 @@ var Segments = map[SegmentType]func() SegmentWriter{
 	PERL:            func() SegmentWriter { return &segments.Perl{} },
 	PHP:             func() SegmentWriter { return &segments.Php{} },
 	PLASTIC:         func() SegmentWriter { return &segments.Plastic{} },
+	PLUGIN:          func() SegmentWriter { return &segments.Plugin{} },
 	PNPM:            func() SegmentWriter { return &segments.Pnpm{} },
 	PROJECT:         func() SegmentWriter { return &segments.Project{} },
 	PULUMI:          func() SegmentWriter { return &segments.Pulumi{} },
@@ -353,5 +365,10 @@ func (segment *Segment) MapSegmentWithWriter(env runtime.Environment) error {
 	writer.Init(wrapper, env)
 	segment.writer = writer
 
+	// segments with a cache of their own invalidate it when the watched files change
+	if watcher, ok := writer.(cache.Watcher); ok && segment.Cache != nil {
+		watcher.Watch(segment.Cache.Watch)
+	}
+
 	return nil
 }
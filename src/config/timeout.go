@@ -0,0 +1,108 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/template"
+)
+
+// ExecutionTimeout returns how long we wait for the segment to execute, using the
+// global segment_timeout when the segment has none. Zero means we wait until it's done.
+func (segment *Segment) ExecutionTimeout(segmentTimeout int) time.Duration {
+	timeout := segment.Timeout
+	if timeout == 0 {
+		timeout = segmentTimeout
+	}
+
+	if timeout <= 0 {
+		return 0
+	}
+
+	return time.Duration(timeout) * time.Millisecond
+}
+
+// Placeholder returns a copy of the segment to render in case it does not execute in time.
+// It must be created before the segment starts executing as we can't touch it while it runs.
+func (segment *Segment) Placeholder() *Segment {
+	placeholder := *segment
+	placeholder.Needs = nil
+	return &placeholder
+}
+
+// SetTimedOut marks the placeholder as timed out and restores the last known
+// value of the segment, or the timeout template when there is none.
+func (segment *Segment) SetTimedOut(env runtime.Environment, timeout time.Duration) {
+	segment.TimedOut = true
+	segment.Duration = timeout
+	segment.NameLength = len(segment.Name())
+	// never overwrite the cached values with the placeholder's
+	segment.restored = true
+
+	log.Debugf("segment timed out: %s", segment.Name())
+
+	defer segment.evaluateNeeds()
+
+	if err := segment.MapSegmentWithWriter(env); err != nil {
+		return
+	}
+
+	env.Session().Set(segment.timedOutKey(), "true", cache.ONEDAY)
+
+	if text, OK := env.Session().Get(segment.timeoutCacheKey()); OK {
+		segment.timeoutText = text
+		segment.Enabled = true
+		return
+	}
+
+	segment.Enabled = len(segment.TimeoutTemplate) != 0
+}
+
+func (segment *Segment) timeoutString() string {
+	if len(segment.timeoutText) != 0 {
+		return segment.timeoutText
+	}
+
+	tmpl := &template.Text{
+		Template: segment.TimeoutTemplate,
+		Context:  segment.writer,
+	}
+
+	text, err := tmpl.Render()
+	if err != nil {
+		return err.Error()
+	}
+
+	return text
+}
+
+// SetTimeoutCache stores the text to render when the segment times out in this folder.
+// Segments using the global segment_timeout only do so once they timed out,
+// otherwise we would store the text of every segment for every folder.
+func (segment *Segment) SetTimeoutCache() {
+	if !segment.Enabled || segment.TimedOut {
+		return
+	}
+
+	session := segment.env.Session()
+
+	if segment.Timeout <= 0 {
+		if _, OK := session.Get(segment.timedOutKey()); !OK {
+			return
+		}
+	}
+
+	session.Set(segment.timeoutCacheKey(), segment.Text(), cache.ONEDAY)
+}
+
+func (segment *Segment) timedOutKey() string {
+	return fmt.Sprintf("segment_timed_out_%s", segment.Name())
+}
+
+func (segment *Segment) timeoutCacheKey() string {
+	return fmt.Sprintf("segment_timeout_cache_%s", strings.Join([]string{segment.Name(), segment.env.Pwd()}, "_"))
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	cache_ "github.com/jandedobbeleer/oh-my-posh/src/cache/mock"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/mock"
+	"github.com/jandedobbeleer/oh-my-posh/src/segments"
+
+	"github.com/stretchr/testify/assert"
+	testify_ "github.com/stretchr/testify/mock"
+)
+
+func TestExecutionTimeout(t *testing.T) {
+	cases := []struct {
+		Case           string
+		Timeout        int
+		SegmentTimeout int
+		Expected       time.Duration
+	}{
+		{Case: "No timeout"},
+		{Case: "Negative timeout", Timeout: -1},
+		{Case: "Timeout", Timeout: 150, Expected: 150 * time.Millisecond},
+		{Case: "Global timeout", SegmentTimeout: 200, Expected: 200 * time.Millisecond},
+		{Case: "Timeout over global timeout", Timeout: 150, SegmentTimeout: 200, Expected: 150 * time.Millisecond},
+		{Case: "Disabled global timeout", Timeout: -1, SegmentTimeout: 200},
+	}
+
+	for _, tc := range cases {
+		segment := &Segment{Timeout: tc.Timeout}
+		assert.Equal(t, tc.Expected, segment.ExecutionTimeout(tc.SegmentTimeout), tc.Case)
+		assert.Equal(t, tc.Timeout, segment.Timeout, tc.Case)
+	}
+}
+
+func TestSetTimedOut(t *testing.T) {
+	cases := []struct {
+		Case            string
+		CachedText      string
+		TimeoutTemplate string
+		Expected        string
+		HasCache        bool
+		ExpectedEnabled bool
+	}{
+		{Case: "No cache, no template"},
+		{Case: "Cached value", CachedText: "main", HasCache: true, ExpectedEnabled: true, Expected: "main"},
+		{Case: "Template", TimeoutTemplate: "...", ExpectedEnabled: true, Expected: "..."},
+		{Case: "Cached value over template", CachedText: "main", HasCache: true, TimeoutTemplate: "...", ExpectedEnabled: true, Expected: "main"},
+	}
+
+	for _, tc := range cases {
+		env := new(mock.Environment)
+		env.On("Pwd").Return(cwd)
+
+		session := &cache_.Cache{}
+		session.On("Get", "segment_timeout_cache_Git_"+cwd).Return(tc.CachedText, tc.HasCache)
+		session.On("Set", "segment_timed_out_Git", "true", cache.ONEDAY)
+		env.On("Session").Return(session)
+
+		segment := &Segment{
+			Type:            GIT,
+			TimeoutTemplate: tc.TimeoutTemplate,
+		}
+
+		placeholder := segment.Placeholder()
+		placeholder.SetTimedOut(env, 100*time.Millisecond)
+
+		assert.True(t, placeholder.TimedOut, tc.Case)
+		assert.False(t, segment.TimedOut, tc.Case)
+		assert.Equal(t, 100*time.Millisecond, placeholder.Duration, tc.Case)
+		assert.Equal(t, tc.ExpectedEnabled, placeholder.Enabled, tc.Case)
+		session.AssertCalled(t, "Set", "segment_timed_out_Git", "true", cache.ONEDAY)
+
+		if !tc.ExpectedEnabled {
+			continue
+		}
+
+		assert.Equal(t, tc.Expected, placeholder.string(), tc.Case)
+	}
+}
+
+func TestSetTimeoutCache(t *testing.T) {
+	cases := []struct {
+		Case          string
+		Timeout       int
+		TimedOut      bool
+		TimedOutEarly bool
+		Disabled      bool
+		Expected      bool
+	}{
+		{Case: "Global timeout"},
+		{Case: "Global timeout, timed out before", TimedOutEarly: true, Expected: true},
+		{Case: "Timeout", Timeout: 100, Expected: true},
+		{Case: "Timed out", Timeout: 100, TimedOut: true},
+		{Case: "Disabled", Timeout: 100, Disabled: true},
+	}
+
+	for _, tc := range cases {
+		env := new(mock.Environment)
+		env.On("Pwd").Return(cwd)
+
+		session := &cache_.Cache{}
+		session.On("Get", "segment_timed_out_Git").Return("true", tc.TimedOutEarly)
+		session.On("Set", testify_.Anything, testify_.Anything, testify_.Anything)
+		env.On("Session").Return(session)
+
+		segment := &Segment{
+			Type:     GIT,
+			Timeout:  tc.Timeout,
+			TimedOut: tc.TimedOut,
+			Enabled:  !tc.Disabled,
+			env:      env,
+			writer:   &segments.Text{},
+		}
+
+		segment.SetText("main")
+		segment.SetTimeoutCache()
+
+		if tc.Expected {
+			session.AssertCalled(t, "Set", "segment_timeout_cache_Git_"+cwd, "main", cache.ONEDAY)
+			continue
+		}
+
+		session.AssertNotCalled(t, "Set", testify_.Anything, testify_.Anything, testify_.Anything)
+	}
+}
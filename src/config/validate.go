@@ -0,0 +1,502 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/properties"
+	"github.com/jandedobbeleer/oh-my-posh/src/regex"
+	"github.com/jandedobbeleer/oh-my-posh/src/template"
+
+	json "github.com/goccy/go-json"
+	yaml "github.com/goccy/go-yaml"
+	toml "github.com/pelletier/go-toml/v2"
+)
+
+// Problem describes an issue found while validating a config file.
+type Problem struct {
+	File    string
+	Message string
+	Line    int
+	Column  int
+}
+
+func (p *Problem) String() string {
+	if p.Line == 0 {
+		return fmt.Sprintf("%s: %s", p.File, p.Message)
+	}
+
+	return fmt.Sprintf("%s:%d:%d: %s", p.File, p.Line, p.Column, p.Message)
+}
+
+type validator struct {
+	cfg       *Config
+	positions positions
+	file      string
+	problems  []*Problem
+}
+
+// Validate checks the config file for syntax errors, unknown keys, segment types and properties,
+// missing palette colors and invalid templates. It returns all problems ordered by their location.
+func Validate(configFile string) []*Problem {
+	v, ok := newValidator(configFile)
+	if !ok {
+		return v.problems
+	}
+
+	v.keys()
+	v.blocks()
+	v.colors()
+
+	v.template("console_title_template", v.cfg.ConsoleTitleTemplate)
+
+	if v.cfg.Palettes != nil {
+		v.template("palettes.template", v.cfg.Palettes.Template)
+	}
+
+	return v.sorted()
+}
+
+// newValidator decodes the config file, it returns false when that fails
+func newValidator(configFile string) (*validator, bool) {
+	v := &validator{
+		file:      configFile,
+		positions: make(positions),
+	}
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		v.add("", "%s", err.Error())
+		return v, false
+	}
+
+	v.cfg = &Config{
+		origin: configFile,
+		Format: strings.TrimPrefix(filepath.Ext(configFile), "."),
+	}
+
+	if v.cfg.Format == "jsonc" || v.cfg.Format == "json" {
+		data = stripJSONComments(data)
+	}
+
+	if err := v.cfg.decode(data); err != nil {
+		v.decodeError(data, err)
+		return v, false
+	}
+
+	v.positions = newPositions(v.cfg.Format, data)
+
+	return v, true
+}
+
+func (v *validator) sorted() []*Problem {
+	sort.SliceStable(v.problems, func(i, j int) bool {
+		if v.problems[i].Line != v.problems[j].Line {
+			return v.problems[i].Line < v.problems[j].Line
+		}
+
+		return v.problems[i].Column < v.problems[j].Column
+	})
+
+	return v.problems
+}
+
+func (v *validator) add(path, message string, args ...any) {
+	pos := v.positions.get(path)
+
+	v.problems = append(v.problems, &Problem{
+		File:    v.file,
+		Message: fmt.Sprintf(message, args...),
+		Line:    pos.line,
+		Column:  pos.column,
+	})
+}
+
+func (v *validator) decodeError(data []byte, err error) {
+	problem := &Problem{
+		File:    v.file,
+		Message: err.Error(),
+	}
+
+	v.problems = append(v.problems, problem)
+
+	setOffset := func(offset int64) {
+		pos := offsetPosition(data, int(offset))
+		problem.Line = pos.line
+		problem.Column = pos.column
+	}
+
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+	var decodeError *toml.DecodeError
+
+	switch {
+	case errors.As(err, &syntaxError):
+		setOffset(syntaxError.Offset)
+	case errors.As(err, &typeError):
+		setOffset(typeError.Offset)
+	case errors.As(err, &decodeError):
+		problem.Line, problem.Column = decodeError.Position()
+	case v.cfg.Format == YAML:
+		// YAML errors are formatted as [line:column] message
+		match := regex.FindNamedRegexMatch(`^\[(?P<line>\d+):(?P<column>\d+)\] (?P<message>.*)`, yaml.FormatError(err, false, false))
+		if len(match) == 0 {
+			return
+		}
+
+		problem.Line, _ = strconv.Atoi(match["line"])
+		problem.Column, _ = strconv.Atoi(match["column"])
+		problem.Message = match["message"]
+	}
+}
+
+// keys reports the keys that do not match any config setting
+func (v *validator) keys() {
+	paths := make([]string, 0, len(v.positions))
+	for path := range v.positions {
+		paths = append(paths, path)
+	}
+
+	// parents sort before their children
+	slices.Sort(paths)
+
+	var unknown []string
+
+	isChild := func(path string) bool {
+		for _, parent := range unknown {
+			if strings.HasPrefix(path, parent+".") || strings.HasPrefix(path, parent+"[") {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for _, path := range paths {
+		// editors use $schema to validate the config
+		if path == "$schema" {
+			continue
+		}
+
+		if isChild(path) || knownKey(reflect.TypeOf(Config{}), path) {
+			continue
+		}
+
+		unknown = append(unknown, path)
+
+		key := path
+		if index := strings.LastIndex(path, "."); index != -1 {
+			key = path[index+1:]
+		}
+
+		v.add(path, "unknown key %q", key)
+	}
+}
+
+// knownKey walks the config type following the given path, anything inside a map
+// is considered known as the keys are defined by the user
+func knownKey(t reflect.Type, path string) bool {
+	for len(path) != 0 {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		switch t.Kind() { //nolint:exhaustive
+		case reflect.Struct:
+			path = strings.TrimPrefix(path, ".")
+
+			key := path
+			if index := strings.IndexAny(path, ".["); index != -1 {
+				key = path[:index]
+			}
+
+			field, ok := fieldByTag(t, key)
+			if !ok {
+				return false
+			}
+
+			t = field.Type
+			path = path[len(key):]
+		case reflect.Slice, reflect.Array:
+			end := strings.Index(path, "]")
+			if !strings.HasPrefix(path, "[") || end == -1 {
+				return false
+			}
+
+			t = t.Elem()
+			path = path[end+1:]
+		default:
+			return true
+		}
+	}
+
+	return true
+}
+
+func fieldByTag(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || len(name) == 0 {
+			continue
+		}
+
+		if strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+func (v *validator) blocks() {
+	for i, block := range v.cfg.Blocks {
+		for j, segment := range block.Segments {
+			path := fmt.Sprintf("blocks[%d].segments[%d]", i, j)
+			v.segmentType(path, segment)
+			v.segment(path, segment)
+		}
+	}
+
+	for i, tooltip := range v.cfg.Tooltips {
+		path := fmt.Sprintf("tooltips[%d]", i)
+		v.segmentType(path, tooltip)
+		v.segment(path, tooltip)
+	}
+
+	v.segment("debug_prompt", v.cfg.DebugPrompt)
+	v.segment("valid_line", v.cfg.ValidLine)
+	v.segment("error_line", v.cfg.ErrorLine)
+	v.segment("secondary_prompt", v.cfg.SecondaryPrompt)
+	v.segment("transient_prompt", v.cfg.TransientPrompt)
+}
+
+func (v *validator) segmentType(path string, segment *Segment) {
+	if segment == nil {
+		return
+	}
+
+	if len(segment.Type) == 0 {
+		// segments in a config that extends others can change an existing segment using its alias
+		if len(v.cfg.Extends) == 0 || len(segment.Alias) == 0 {
+			v.add(path, "missing segment type")
+		}
+
+		return
+	}
+
+	if _, ok := Segments[segment.Type]; !ok {
+		v.add(path+".type", "unknown segment type %q", segment.Type)
+		return
+	}
+
+	supported, ok := segmentProperties[segment.Type]
+	if !ok {
+		return
+	}
+
+	keys := make([]string, 0, len(segment.Properties))
+	for key := range segment.Properties {
+		keys = append(keys, string(key))
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		property := properties.Property(key)
+		if slices.Contains(supported, property) || slices.Contains(genericProperties, property) {
+			continue
+		}
+
+		v.add(path+".properties."+key, "unknown property %q for segment type %q", key, segment.Type)
+	}
+}
+
+func (v *validator) segment(path string, segment *Segment) {
+	if segment == nil {
+		return
+	}
+
+	v.template(path+".template", segment.Template)
+	v.template(path+".timeout_template", segment.TimeoutTemplate)
+
+	for i, tmpl := range segment.Templates {
+		v.template(fmt.Sprintf("%s.templates[%d]", path, i), tmpl)
+	}
+
+	for i, tmpl := range segment.ForegroundTemplates {
+		v.template(fmt.Sprintf("%s.foreground_templates[%d]", path, i), tmpl)
+		v.paletteReferences(fmt.Sprintf("%s.foreground_templates[%d]", path, i), tmpl)
+	}
+
+	for i, tmpl := range segment.BackgroundTemplates {
+		v.template(fmt.Sprintf("%s.background_templates[%d]", path, i), tmpl)
+		v.paletteReferences(fmt.Sprintf("%s.background_templates[%d]", path, i), tmpl)
+	}
+
+	v.color(path+".foreground", segment.Foreground)
+	v.color(path+".background", segment.Background)
+}
+
+func (v *validator) template(path, text string) {
+	if len(text) == 0 {
+		return
+	}
+
+	tmpl := &template.Text{
+		Template: text,
+	}
+
+	err := tmpl.Parse()
+	if err == nil {
+		return
+	}
+
+	message := regex.ReplaceAllString(`^template: parse:\d+: `, err.Error(), "")
+	v.add(path, "invalid template: %s", message)
+}
+
+func (v *validator) colors() {
+	v.color("accent_color", v.cfg.AccentColor)
+	v.color("terminal_background", v.cfg.TerminalBackground)
+}
+
+// paletteReferences validates the palette colors a template can resolve to
+func (v *validator) paletteReferences(path, text string) {
+	for _, match := range regex.FindAllNamedRegexMatch(`(?P<color>p:[a-zA-Z0-9_\-]+)`, text) {
+		v.color(path, color.Ansi(match["color"]))
+	}
+}
+
+func (v *validator) color(path string, value color.Ansi) {
+	if !strings.HasPrefix(value.String(), "p:") {
+		return
+	}
+
+	names, palettes := v.palettes()
+
+	// the palette is selected at runtime, so every palette needs to contain the color
+	for _, name := range names {
+		v.resolveColor(path, value, palettes[name], name)
+	}
+}
+
+// palettes returns the names of all palettes that can be selected at runtime, merged with
+// the default palette. The default palette has no name and is only used without palettes.
+func (v *validator) palettes() ([]string, map[string]color.Palette) {
+	if v.cfg.Palettes == nil || len(v.cfg.Palettes.List) == 0 {
+		return []string{""}, map[string]color.Palette{"": v.cfg.Palette}
+	}
+
+	names := make([]string, 0, len(v.cfg.Palettes.List))
+	palettes := make(map[string]color.Palette, len(v.cfg.Palettes.List))
+
+	for name, list := range v.cfg.Palettes.List {
+		palette := make(color.Palette)
+
+		for key, value := range v.cfg.Palette {
+			palette[key] = value
+		}
+
+		for key, value := range list {
+			palette[key] = value
+		}
+
+		names = append(names, name)
+		palettes[name] = palette
+	}
+
+	slices.Sort(names)
+
+	return names, palettes
+}
+
+func (v *validator) resolveColor(path string, value color.Ansi, palette color.Palette, name string) {
+	_, err := palette.ResolveColor(value)
+	if err == nil {
+		return
+	}
+
+	var keyError *color.PaletteKeyError
+	if !errors.As(err, &keyError) {
+		v.add(path, "%s", err.Error())
+		return
+	}
+
+	if len(name) == 0 {
+		v.add(path, "unknown palette color %q", value)
+		return
+	}
+
+	v.add(path, "unknown palette color %q in palette %q", value, name)
+}
+
+// stripJSONComments removes comments from JSON while keeping the location of all other
+// characters intact, so reported lines and columns match the original file
+func stripJSONComments(data []byte) []byte {
+	result := slices.Clone(data)
+
+	var inString, escaped bool
+
+	for i := 0; i < len(result); i++ {
+		char := result[i]
+
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case char == '\\':
+				escaped = true
+			case char == '"':
+				inString = false
+			}
+
+			continue
+		}
+
+		if char == '"' {
+			inString = true
+			continue
+		}
+
+		if char != '/' || i+1 >= len(result) {
+			continue
+		}
+
+		switch result[i+1] {
+		case '/':
+			for ; i < len(result) && result[i] != '\n'; i++ {
+				result[i] = ' '
+			}
+		case '*':
+			end := i + 2
+			for end+1 < len(result) && (result[end] != '*' || result[end+1] != '/') {
+				end++
+			}
+
+			end = min(end+2, len(result))
+
+			for ; i < end; i++ {
+				if result[i] != '\n' {
+					result[i] = ' '
+				}
+			}
+
+			i--
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,175 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		Case     string
+		File     string
+		Config   string
+		Expected []string
+	}{
+		{
+			Case: "Valid JSON",
+			File: "valid.omp.json",
+			Config: `{
+  "$schema": "https://raw.githubusercontent.com/JanDeDobbeleer/oh-my-posh/main/themes/schema.json",
+  "version": 3,
+  "palette": { "blue": "#0000ff" },
+  "blocks": [
+    {
+      "type": "prompt",
+      "segments": [
+        { "type": "path", "foreground": "p:blue", "properties": { "style": "folder", "folder_icon": "F" } }
+      ]
+    }
+  ]
+}`,
+		},
+		{
+			Case: "JSON",
+			File: "invalid.omp.jsonc",
+			Config: `{
+  // the version
+  "version": 3, /* multi
+  line */
+  "blocks": [
+    {
+      "type": "prompt",
+      "segments": [
+        { "type": "gti" },
+        {
+          "type": "git",
+          "foreground": "p:red",
+          "properties": { "fetch_status": true, "fetch_statuss": true },
+          "template": "{{ .HEAD "
+        }
+      ],
+      "segmnts": []
+    }
+  ]
+}`,
+			Expected: []string{
+				`9:11: unknown segment type "gti"`,
+				`12:11: unknown palette color "p:red"`,
+				`13:49: unknown property "fetch_statuss" for segment type "git"`,
+				`14:11: invalid template: unclosed action`,
+				`17:7: unknown key "segmnts"`,
+			},
+		},
+		{
+			Case: "YAML",
+			File: "invalid.omp.yaml",
+			Config: `version: 3
+blocks:
+  - type: prompt
+    segments:
+      - type: git
+        background_templates:
+          - "{{ if .Working.Changed }}p:yellow{{ end }}"
+        properties:
+          fetch_statuss: true
+tooltip: []
+`,
+			Expected: []string{
+				`7:13: unknown palette color "p:yellow"`,
+				`9:11: unknown property "fetch_statuss" for segment type "git"`,
+				`10:1: unknown key "tooltip"`,
+			},
+		},
+		{
+			Case: "TOML",
+			File: "invalid.omp.toml",
+			Config: `version = 3
+
+[palettes]
+  template = "{{ .Shell }}"
+
+  [palettes.list.dark]
+    bg = "#000000"
+
+  [palettes.list.light]
+    fg = "#ffffff"
+
+[[blocks]]
+  type = "prompt"
+
+  [[blocks.segments]]
+    type = "path"
+
+  [[blocks.segments]]
+    type = "git"
+    foreground = "p:fg"
+
+    [blocks.segments.properties]
+      branch_icon = ""
+      fetch_statuss = true
+`,
+			Expected: []string{
+				`20:5: unknown palette color "p:fg" in palette "dark"`,
+				`24:7: unknown property "fetch_statuss" for segment type "git"`,
+			},
+		},
+		{
+			Case:     "JSON syntax error",
+			File:     "syntax.omp.json",
+			Config:   "{\n  \"version\": 3,\n  \"blocks\": [ }\n}",
+			Expected: []string{`3:15: invalid character '}' looking for beginning of value`},
+		},
+		{
+			Case:     "YAML type error",
+			File:     "syntax.omp.yaml",
+			Config:   "version: 3\nblocks:\n  - segments: x\n",
+			Expected: []string{`3:15: string was used where sequence is expected`},
+		},
+		{
+			Case:     "TOML syntax error",
+			File:     "syntax.omp.toml",
+			Config:   "version = 3\n[[blocks\n",
+			Expected: []string{`2:9: toml: expected character ]`},
+		},
+	}
+
+	for _, tc := range cases {
+		configFile := filepath.Join(t.TempDir(), tc.File)
+		err := os.WriteFile(configFile, []byte(tc.Config), 0644)
+		assert.NoError(t, err, tc.Case)
+
+		var got []string
+		for _, problem := range Validate(configFile) {
+			assert.Equal(t, configFile, problem.File, tc.Case)
+			got = append(got, problem.String()[len(configFile)+1:])
+		}
+
+		assert.Equal(t, tc.Expected, got, tc.Case)
+	}
+}
+
+func TestSegmentPropertiesGenerated(t *testing.T) {
+	for segmentType := range Segments {
+		// the os segment reads a property for every platform and distro
+		if segmentType == OS {
+			continue
+		}
+
+		// the plugin segment sends all of its properties to the executable
+		if segmentType == PLUGIN {
+			continue
+		}
+
+		_, ok := segmentProperties[segmentType]
+		assert.True(t, ok, "run go generate to add the properties of %s", segmentType)
+	}
+}
+
+func TestStripJSONComments(t *testing.T) {
+	input := "{\n  // comment\n  \"url\": \"https://ohmyposh.dev\", /* block\n comment */ \"a\": 1\n}"
+	expected := "{\n            \n  \"url\": \"https://ohmyposh.dev\",         \n            \"a\": 1\n}"
+	assert.Equal(t, expected, string(stripJSONComments([]byte(input))))
+}
@@ -0,0 +1,85 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+)
+
+const (
+	dialTimeout    = 20 * time.Millisecond
+	requestTimeout = 100 * time.Millisecond
+)
+
+// ErrNotRunning means there's no daemon for this session, which is the default
+var ErrNotRunning = errors.New("no daemon is running for this session")
+
+// Request asks the daemon for the last known value of an async segment.
+// The daemon answers immediately and refreshes the value in the background.
+type Request struct {
+	Flags   *runtime.Flags `json:"flags"`
+	Environ []string       `json:"environ"`
+	Block   int            `json:"block"`
+	Segment int            `json:"segment"`
+}
+
+func (r *Request) key() string {
+	return fmt.Sprintf("%s|%s|%s|%d|%d", r.Flags.Config, r.Flags.Shell, r.Flags.PWD, r.Block, r.Segment)
+}
+
+type Response struct {
+	Value   string `json:"value"`
+	Enabled bool   `json:"enabled"`
+	Found   bool   `json:"found"`
+}
+
+// SocketPath returns the location of the Unix socket of the daemon for the current shell session.
+func SocketPath() string {
+	return filepath.Join(cache.Path(), fmt.Sprintf("omp.daemon.%s.sock", cache.SessionID()))
+}
+
+// Get fetches the last known value of an async segment from the daemon listening on socket.
+func Get(socket string, request *Request) (*Response, error) {
+	defer log.Trace(time.Now(), request.key())
+
+	if _, err := os.Stat(socket); errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotRunning
+	}
+
+	conn, err := net.DialTimeout("unix", socket, dialTimeout)
+	// the socket of a daemon that didn't stop cleanly is left behind
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotRunning
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	if err = conn.SetDeadline(time.Now().Add(requestTimeout)); err != nil {
+		return nil, err
+	}
+
+	if err = json.NewEncoder(conn).Encode(request); err != nil {
+		return nil, err
+	}
+
+	var response Response
+	if err = json.NewDecoder(conn).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,209 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/maps"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/template"
+
+	process "github.com/shirou/gopsutil/v3/process"
+)
+
+const (
+	// IdleTimeout is the time after which the daemon stops when it did not receive any request.
+	IdleTimeout = time.Hour
+
+	queueSize     = 64
+	watchInterval = 10 * time.Second
+)
+
+var ErrRunning = errors.New("a daemon is already running for this session")
+
+type Server struct {
+	listener    net.Listener
+	values      *maps.Concurrent
+	queue       chan *Request
+	pending     sync.Map
+	lastRequest atomic.Int64
+	// PID is the shell process to watch, the daemon stops when it exits.
+	PID         int32
+	IdleTimeout time.Duration
+	socket      string
+}
+
+func New(socket string) *Server {
+	return &Server{
+		socket:      socket,
+		values:      maps.NewConcurrent(),
+		queue:       make(chan *Request, queueSize),
+		IdleTimeout: IdleTimeout,
+	}
+}
+
+// Listen opens the socket, removing a stale one left behind by a daemon that did not stop gracefully.
+func (s *Server) Listen() error {
+	if _, err := os.Stat(s.socket); err == nil {
+		if conn, err := net.DialTimeout("unix", s.socket, dialTimeout); err == nil {
+			conn.Close()
+			return ErrRunning
+		}
+
+		_ = os.Remove(s.socket)
+	}
+
+	listener, err := net.Listen("unix", s.socket)
+	if err != nil {
+		return err
+	}
+
+	s.listener = listener
+	s.lastRequest.Store(time.Now().Unix())
+
+	return nil
+}
+
+// Serve handles requests until the daemon is stopped, goes idle or the watched shell exits.
+func (s *Server) Serve() {
+	go s.refresh()
+	go s.watch()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+
+			log.Error(err)
+			continue
+		}
+
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) Stop() {
+	if s.listener == nil {
+		return
+	}
+
+	_ = s.listener.Close()
+	_ = os.Remove(s.socket)
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	s.lastRequest.Store(time.Now().Unix())
+
+	var request Request
+	if err := json.NewDecoder(conn).Decode(&request); err != nil {
+		log.Error(err)
+		return
+	}
+
+	if request.Flags == nil {
+		log.Error(errors.New("no flags in request"))
+		return
+	}
+
+	response := &Response{}
+	if value, OK := s.values.Get(request.key()); OK {
+		response, _ = value.(*Response)
+	}
+
+	_ = json.NewEncoder(conn).Encode(response)
+
+	// only refresh once at a time for the same segment
+	if _, busy := s.pending.LoadOrStore(request.key(), true); busy {
+		return
+	}
+
+	select {
+	case s.queue <- &request:
+	default:
+		s.pending.Delete(request.key())
+	}
+}
+
+// refresh executes the requested segments one at a time as the environment
+// variables and the template context are shared globally within the process.
+func (s *Server) refresh() {
+	for request := range s.queue {
+		s.values.Set(request.key(), s.execute(request))
+		s.pending.Delete(request.key())
+	}
+}
+
+func (s *Server) execute(request *Request) *Response {
+	defer log.Trace(time.Now(), request.key())
+
+	os.Clearenv()
+	for _, variable := range request.Environ {
+		key, value, _ := strings.Cut(variable, "=")
+		_ = os.Setenv(key, value)
+	}
+
+	// never write the cache files, the shell owns those
+	flags := *request.Flags
+	flags.SaveCache = false
+	flags.Type = ""
+
+	cfg := config.Load(flags.Config, flags.Shell, true)
+	if request.Block < 0 || request.Block >= len(cfg.Blocks) ||
+		request.Segment < 0 || request.Segment >= len(cfg.Blocks[request.Block].Segments) {
+		return &Response{Found: true}
+	}
+
+	env := &runtime.Terminal{}
+	env.Init(&flags)
+	defer env.Close()
+
+	template.Cache = nil
+	template.Init(env, cfg.Var)
+
+	segment := cfg.Blocks[request.Block].Segments[request.Segment]
+	segment.Execute(env)
+
+	value, enabled := segment.AsyncValue()
+
+	return &Response{
+		Value:   value,
+		Enabled: enabled,
+		Found:   true,
+	}
+}
+
+func (s *Server) watch() {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		idle := time.Since(time.Unix(s.lastRequest.Load(), 0))
+		if idle > s.IdleTimeout {
+			log.Debug("daemon idle, stopping")
+			s.Stop()
+			return
+		}
+
+		if s.PID == 0 {
+			continue
+		}
+
+		if exists, err := process.PidExists(s.PID); err == nil && !exists {
+			log.Debug("shell exited, stopping daemon")
+			s.Stop()
+			return
+		}
+	}
+}
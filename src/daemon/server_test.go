@@ -0,0 +1,98 @@
+package daemon
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	stdruntime "runtime"
+	"testing"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testConfig = `{
+	"version": 3,
+	"blocks": [
+		{
+			"type": "prompt",
+			"alignment": "left",
+			"segments": [
+				{
+					"type": "text",
+					"async": true,
+					"template": "hello"
+				}
+			]
+		}
+	]
+}`
+
+func TestServer(t *testing.T) {
+	dir := t.TempDir()
+
+	configFile := filepath.Join(dir, "daemon.omp.json")
+	err := os.WriteFile(configFile, []byte(testConfig), 0o644)
+	assert.NoError(t, err)
+
+	socket := filepath.Join(dir, "omp.sock")
+
+	server := New(socket)
+	err = server.Listen()
+	assert.NoError(t, err)
+
+	defer server.Stop()
+
+	go server.Serve()
+
+	assert.ErrorIs(t, New(socket).Listen(), ErrRunning, "only one daemon per session")
+
+	request := &Request{
+		Flags: &runtime.Flags{
+			Config: configFile,
+			PWD:    dir,
+			Shell:  "pwsh",
+		},
+		Environ: os.Environ(),
+	}
+
+	response, err := Get(socket, request)
+	assert.NoError(t, err)
+	assert.False(t, response.Found, "nothing is known before the first refresh")
+
+	assert.Eventually(t, func() bool {
+		response, err := Get(socket, request)
+		return err == nil && response.Found && response.Enabled
+	}, 5*time.Second, 50*time.Millisecond)
+
+	request.Segment = 1
+	assert.Eventually(t, func() bool {
+		response, err := Get(socket, request)
+		return err == nil && response.Found && !response.Enabled
+	}, 5*time.Second, 50*time.Millisecond, "unknown segments are never enabled")
+}
+
+func TestGetNotRunning(t *testing.T) {
+	dir := t.TempDir()
+	request := &Request{Flags: &runtime.Flags{Shell: "pwsh"}}
+
+	_, err := Get(filepath.Join(dir, "missing.sock"), request)
+	assert.ErrorIs(t, err, ErrNotRunning, "no socket")
+
+	if stdruntime.GOOS == runtime.WINDOWS {
+		return
+	}
+
+	socket := filepath.Join(dir, "stale.sock")
+	listener, err := net.Listen("unix", socket)
+	assert.NoError(t, err)
+
+	// keep the socket file around, like a daemon that crashed
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	_ = listener.Close()
+
+	_, err = Get(socket, request)
+	assert.ErrorIs(t, err, ErrNotRunning, "stale socket")
+}
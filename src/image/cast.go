@@ -0,0 +1,199 @@
+package image
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// resetSequence is the sequence of the default style and colors
+	resetSequence = "\x1b[0m"
+
+	saveCursor    = "\x1b7"
+	restoreCursor = "\x1b8"
+)
+
+// castHeader is the header of an asciinema v2 file
+type castHeader struct {
+	Env     map[string]string `json:"env,omitempty"`
+	Version int               `json:"version"`
+	Width   int               `json:"width"`
+	Height  int               `json:"height"`
+}
+
+// castFrame is a prompt written using plain SGR sequences only
+type castFrame struct {
+	text   string
+	lines  int
+	cursor int
+	width  int
+}
+
+// SaveCast writes an asciinema v2 recording which shows the primary prompt,
+// the command being typed, the transient prompt and the next primary prompt.
+func (ir *Renderer) SaveCast() error {
+	primary := ir.encode(ir.AnsiString)
+
+	command := ir.Command
+	if len(command) == 0 {
+		command = "git status"
+	}
+
+	width := max(80, primary.width+len(command)+1)
+	height := 2*primary.lines + 1
+
+	var events [][]any
+	var milliseconds int
+
+	event := func(delay int, data string) {
+		milliseconds += delay
+		events = append(events, []any{float64(milliseconds) / 1000, "o", data})
+	}
+
+	event(0, primary.text)
+
+	for i, char := range command {
+		delay := 100
+		if i == 0 {
+			delay = 1000
+		}
+
+		event(delay, string(char))
+	}
+
+	var finish string
+
+	// like the shell, go back to the start of the prompt and replace it with the transient prompt
+	if len(ir.Transient) != 0 {
+		transient := ir.encode(ir.Transient)
+
+		finish = "\r"
+		if primary.cursor != 0 {
+			finish += fmt.Sprintf("\x1b[%dA", primary.cursor)
+		}
+
+		finish += "\x1b[0J" + transient.text + command
+		height += transient.lines
+		width = max(width, transient.width+len(command)+1)
+	}
+
+	event(500, finish+"\r\n")
+	event(500, primary.text)
+	event(2000, "")
+
+	header, err := json.Marshal(&castHeader{
+		Version: 2,
+		Width:   width,
+		Height:  height,
+		Env:     map[string]string{"TERM": "xterm-256color"},
+	})
+	if err != nil {
+		return err
+	}
+
+	var cast strings.Builder
+	cast.Write(header)
+	cast.WriteString("\n")
+
+	for _, event := range events {
+		line, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		cast.Write(line)
+		cast.WriteString("\n")
+	}
+
+	return os.WriteFile(ir.Path, []byte(cast.String()), 0644)
+}
+
+// encode parses the prompt and writes it again using plain SGR sequences, keeping the cursor position
+func (ir *Renderer) encode(prompt string) *castFrame {
+	prompt = ir.clean(strings.Trim(prompt, "\n"))
+
+	ir.foregroundColor = nil
+	ir.backgroundColor = nil
+	ir.style = ""
+
+	frame := &castFrame{
+		lines: strings.Count(prompt, "\n") + 1,
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(prompt, saveCursor, ""), "\n") {
+		frame.width = max(frame.width, ir.lenWithoutANSI(line))
+	}
+
+	parts := strings.SplitN(prompt, saveCursor, 2)
+	frame.cursor = strings.Count(parts[0], "\n")
+
+	if len(parts) == 1 {
+		frame.cursor = frame.lines - 1
+		frame.text = ir.sgr(parts[0])
+		return frame
+	}
+
+	frame.text = ir.sgr(parts[0]) + saveCursor + ir.sgr(parts[1]) + restoreCursor
+
+	return frame
+}
+
+// sgr writes the text using a single SGR sequence every time the style or colors change
+func (ir *Renderer) sgr(text string) string {
+	var builder strings.Builder
+
+	current := resetSequence
+	ir.AnsiString = text
+
+	ir.print(func(char rune) {
+		if char == '\n' {
+			if current != resetSequence {
+				builder.WriteString(resetSequence)
+				current = resetSequence
+			}
+
+			builder.WriteString("\r\n")
+			return
+		}
+
+		if sequence := ir.sequence(); sequence != current {
+			builder.WriteString(sequence)
+			current = sequence
+		}
+
+		builder.WriteRune(char)
+	})
+
+	if current != resetSequence {
+		builder.WriteString(resetSequence)
+	}
+
+	return builder.String()
+}
+
+func (ir *Renderer) sequence() string {
+	codes := []string{"0"}
+
+	switch ir.style {
+	case bold:
+		codes = append(codes, "1")
+	case italic:
+		codes = append(codes, "3")
+	case underline:
+		codes = append(codes, "4")
+	case overline:
+		codes = append(codes, "53")
+	}
+
+	if ir.foregroundColor != nil && ir.foregroundColor != ir.defaultForegroundColor {
+		codes = append(codes, fmt.Sprintf("38;2;%d;%d;%d", ir.foregroundColor.r, ir.foregroundColor.g, ir.foregroundColor.b))
+	}
+
+	if ir.backgroundColor != nil {
+		codes = append(codes, fmt.Sprintf("48;2;%d;%d;%d", ir.backgroundColor.r, ir.backgroundColor.g, ir.backgroundColor.b))
+	}
+
+	return fmt.Sprintf("\x1b[%sm", strings.Join(codes, ";"))
+}
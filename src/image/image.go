@@ -60,6 +60,10 @@ const (
 	yellow = "#E1C04C"
 	green  = "#71BD47"
 
+	PNG  = "png"
+	SVG  = "svg"
+	CAST = "cast"
+
 	// known ansi sequences
 
 	fg                  = "FG"
@@ -122,6 +126,9 @@ type Renderer struct {
 	Path                   string
 	AnsiString             string
 	Author                 string
+	Format                 string
+	Transient              string
+	Command                string
 	shadowBaseColor        string
 	style                  string
 	BgColor                string
@@ -141,14 +148,23 @@ type Renderer struct {
 func (ir *Renderer) Init(env runtime.Environment) error {
 	ir.env = env
 
+	if len(ir.Format) == 0 {
+		ir.Format = PNG
+	}
+
 	ir.setOutputPath(env.Flags().Config)
 
-	ir.cleanContent()
+	if ir.Format != CAST {
+		ir.cleanContent()
+	}
 
-	font_.SetCache(env.Cache())
+	// only the PNG is rendered using the bundled fonts
+	if ir.Format == PNG {
+		font_.SetCache(env.Cache())
 
-	if err := ir.loadFonts(); err != nil {
-		return &ConnectionError{reason: err.Error()}
+		if err := ir.loadFonts(); err != nil {
+			return &ConnectionError{reason: err.Error()}
+		}
 	}
 
 	ir.defaultForegroundColor = &RGB{255, 255, 255}
@@ -201,8 +217,13 @@ func (ir *Renderer) setOutputPath(config string) {
 		return
 	}
 
+	extension := ir.Format
+	if len(extension) == 0 {
+		extension = PNG
+	}
+
 	if len(config) == 0 {
-		ir.Path = "prompt.png"
+		ir.Path = fmt.Sprintf("prompt.%s", extension)
 		return
 	}
 
@@ -215,7 +236,19 @@ func (ir *Renderer) setOutputPath(config string) {
 		path = "prompt"
 	}
 
-	ir.Path = fmt.Sprintf("%s.png", path)
+	ir.Path = fmt.Sprintf("%s.%s", path, extension)
+}
+
+// Save writes the image in the requested format.
+func (ir *Renderer) Save() error {
+	switch ir.Format {
+	case SVG:
+		return ir.SaveSVG()
+	case CAST:
+		return ir.SaveCast()
+	default:
+		return ir.SavePNG()
+	}
 }
 
 func (ir *Renderer) loadFonts() error {
@@ -359,15 +392,7 @@ func (ir *Renderer) runeAdditionalWidth(r rune) int {
 func (ir *Renderer) cleanContent() {
 	// clean abundance of empty lines
 	ir.AnsiString = strings.Trim(ir.AnsiString, "\n")
-	ir.AnsiString = "\n" + ir.AnsiString
-
-	// clean string before render
-	ir.AnsiString = strings.ReplaceAll(ir.AnsiString, "\x1b[m", "\x1b[0m")
-	ir.AnsiString = strings.ReplaceAll(ir.AnsiString, "\x1b[K", "")
-	ir.AnsiString = strings.ReplaceAll(ir.AnsiString, "\x1b[0J", "")
-	ir.AnsiString = strings.ReplaceAll(ir.AnsiString, "\x1b[27m", "")
-	ir.AnsiString = strings.ReplaceAll(ir.AnsiString, "\x1b8", "")
-	ir.AnsiString = strings.ReplaceAll(ir.AnsiString, "\u2800", " ")
+	ir.AnsiString = "\n" + ir.clean(ir.AnsiString)
 
 	// cursor indication
 	saveCursorAnsi := "\x1b7"
@@ -376,9 +401,6 @@ func (ir *Renderer) cleanContent() {
 	}
 	ir.AnsiString = strings.ReplaceAll(ir.AnsiString, saveCursorAnsi, "_")
 
-	// replace rprompt with adding and mark right aligned blocks with a pointer
-	ir.AnsiString = strings.ReplaceAll(ir.AnsiString, "\x1b[1000C", strings.Repeat(" ", ir.RPromptOffset))
-
 	// add watermarks
 	ir.AnsiString += "\n\n\x1b[1mohmyposh.dev\x1b[22m"
 	if len(ir.Author) > 0 {
@@ -387,6 +409,19 @@ func (ir *Renderer) cleanContent() {
 	}
 }
 
+// clean removes the escape sequences we don't render
+func (ir *Renderer) clean(text string) string {
+	text = strings.ReplaceAll(text, "\x1b[m", "\x1b[0m")
+	text = strings.ReplaceAll(text, "\x1b[K", "")
+	text = strings.ReplaceAll(text, "\x1b[0J", "")
+	text = strings.ReplaceAll(text, "\x1b[27m", "")
+	text = strings.ReplaceAll(text, "\x1b8", "")
+	text = strings.ReplaceAll(text, "\u2800", " ")
+
+	// replace rprompt with adding and mark right aligned blocks with a pointer
+	return strings.ReplaceAll(text, "\x1b[1000C", strings.Repeat(" ", ir.RPromptOffset))
+}
+
 func (ir *Renderer) measureContent() (width, height float64) {
 	linewidth := 145
 	linewidth += ir.additionalWidth()
@@ -515,16 +550,9 @@ func (ir *Renderer) SavePNG() error {
 	// Apply the actual text into the prepared content area of the window
 	var x, y float64 = xOffset + paddingX, yOffset + paddingY + titleOffset + ir.fontHeight()
 
-	for len(ir.AnsiString) != 0 {
-		if !ir.shouldPrint() {
-			continue
-		}
-		runes := []rune(ir.AnsiString)
-		if len(runes) == 0 {
-			continue
-		}
-		str := string(runes[0:1])
-		ir.AnsiString = string(runes[1:])
+	ir.print(func(char rune) {
+		str := string(char)
+
 		switch ir.style {
 		case bold:
 			dc.SetFontFace(ir.bold)
@@ -539,7 +567,7 @@ func (ir *Renderer) SavePNG() error {
 		// So if we know the glyph to occupy n additional characters in width, allocate that area
 		// e.g. this will double the space for Nerd Fonts, but some could even be 3 or 4 wide
 		// If there's 0 additional characters of width (the common case), this won't add anything
-		w += (w * float64(ir.runeAdditionalWidth(runes[0])))
+		w += (w * float64(ir.runeAdditionalWidth(char)))
 
 		if ir.backgroundColor != nil {
 			dc.SetRGB255(ir.backgroundColor.r, ir.backgroundColor.g, ir.backgroundColor.b)
@@ -558,7 +586,7 @@ func (ir *Renderer) SavePNG() error {
 		if str == "\n" {
 			x = xOffset + paddingX
 			y += h * ir.lineSpacing
-			continue
+			return
 		}
 
 		dc.DrawString(str, x, y)
@@ -576,11 +604,26 @@ func (ir *Renderer) SavePNG() error {
 		}
 
 		x += w
-	}
+	})
 
 	return dc.SavePNG(ir.Path)
 }
 
+// print walks through the ANSI string and calls write for every character to print,
+// the style and colors to use are set on the renderer while parsing the escape sequences.
+func (ir *Renderer) print(write func(char rune)) {
+	for len(ir.AnsiString) != 0 {
+		if !ir.shouldPrint() {
+			continue
+		}
+
+		char, size := utf8.DecodeRuneInString(ir.AnsiString)
+		ir.AnsiString = ir.AnsiString[size:]
+
+		write(char)
+	}
+}
+
 func (ir *Renderer) shouldPrint() bool {
 	for sequence, re := range ir.ansiSequenceRegexMap {
 		match := regex.FindNamedRegexMatch(re, ir.AnsiString)
@@ -1,9 +1,17 @@
 package image
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/mock"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSetOutputPath(t *testing.T) {
@@ -11,6 +19,7 @@ func TestSetOutputPath(t *testing.T) {
 		Case     string
 		Config   string
 		Path     string
+		Format   string
 		Expected string
 	}{
 		{Case: "default config", Expected: "prompt.png"},
@@ -22,11 +31,14 @@ func TestSetOutputPath(t *testing.T) {
 		{Case: "relative, no omp", Config: "~/jandedobbeleer.json", Expected: "jandedobbeleer.png"},
 		{Case: "relative path", Config: "~/jandedobbeleer.omp.json", Expected: "jandedobbeleer.png"},
 		{Case: "invalid config name", Config: "~/jandedobbeleer.omp.foo", Expected: "prompt.png"},
+		{Case: "svg", Config: "~/jandedobbeleer.omp.json", Format: SVG, Expected: "jandedobbeleer.svg"},
+		{Case: "cast, default config", Format: CAST, Expected: "prompt.cast"},
 	}
 
 	for _, tc := range cases {
 		image := &Renderer{
-			Path: tc.Path,
+			Path:   tc.Path,
+			Format: tc.Format,
 		}
 
 		image.setOutputPath(tc.Config)
@@ -34,3 +46,82 @@ func TestSetOutputPath(t *testing.T) {
 		assert.Equal(t, tc.Expected, image.Path, tc.Case)
 	}
 }
+
+func newTestRenderer(t *testing.T, format, prompt string) *Renderer {
+	env := new(mock.Environment)
+	env.On("Flags").Return(&runtime.Flags{})
+
+	renderer := &Renderer{
+		AnsiString: prompt,
+		Format:     format,
+		Path:       filepath.Join(t.TempDir(), "prompt."+format),
+	}
+
+	require.NoError(t, renderer.Init(env))
+
+	return renderer
+}
+
+func TestSaveSVG(t *testing.T) {
+	renderer := newTestRenderer(t, SVG, "\x1b[48;2;0;0;255m\x1b[38;2;255;0;0m a&b \x1b[0m\x1b[1mbold\x1b[22m")
+	require.NoError(t, renderer.Save())
+
+	content, err := os.ReadFile(renderer.Path)
+	require.NoError(t, err)
+
+	svg := string(content)
+	assert.True(t, strings.HasPrefix(svg, "<svg "))
+	assert.Contains(t, svg, `fill="#0000ff"`)
+	assert.Contains(t, svg, `fill="#ff0000"`)
+	assert.Contains(t, svg, "a&amp;b")
+	assert.Contains(t, svg, `fill="#ffffff" font-weight="bold" textLength="57.6" lengthAdjust="spacing">bold</text>`)
+}
+
+func TestSaveCast(t *testing.T) {
+	cases := []struct {
+		Case      string
+		Transient string
+		Expected  []string
+	}{
+		{
+			Case:     "no transient prompt",
+			Expected: []string{"\x1b[0;38;2;255;0;0mtop\x1b[0m\r\n> ", "l", "s", "\r\n", "\x1b[0;38;2;255;0;0mtop\x1b[0m\r\n> ", ""},
+		},
+		{
+			Case:      "transient prompt",
+			Transient: "\x1b[1m$\x1b[22m ",
+			Expected: []string{
+				"\x1b[0;38;2;255;0;0mtop\x1b[0m\r\n> ", "l", "s",
+				"\r\x1b[1A\x1b[0J\x1b[0;1m$\x1b[0m ls\r\n",
+				"\x1b[0;38;2;255;0;0mtop\x1b[0m\r\n> ", "",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		renderer := newTestRenderer(t, CAST, "\x1b[38;2;255;0;0mtop\x1b[0m\n> ")
+		renderer.Transient = tc.Transient
+		renderer.Command = "ls"
+
+		require.NoError(t, renderer.Save(), tc.Case)
+
+		content, err := os.ReadFile(renderer.Path)
+		require.NoError(t, err, tc.Case)
+
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+
+		var header castHeader
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &header), tc.Case)
+		assert.Equal(t, 2, header.Version, tc.Case)
+		assert.Equal(t, 80, header.Width, tc.Case)
+
+		var output []string
+		for _, line := range lines[1:] {
+			var event []any
+			require.NoError(t, json.Unmarshal([]byte(line), &event), tc.Case)
+			output = append(output, event[2].(string))
+		}
+
+		assert.Equal(t, tc.Expected, output, tc.Case)
+	}
+}
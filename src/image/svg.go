@@ -0,0 +1,200 @@
+package image
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"strings"
+)
+
+const (
+	svgFontSize   = 24.0
+	svgCellWidth  = svgFontSize * 0.6
+	svgLineHeight = svgFontSize * 1.4
+	svgFontFamily = `'Hack Nerd Font', 'Hack Nerd Font Mono', 'Hack', monospace`
+)
+
+// svgRun is a sequence of characters sharing the same style and colors.
+type svgRun struct {
+	foreground *RGB
+	background *RGB
+	style      string
+	text       strings.Builder
+	column     int
+	width      int
+}
+
+func (c *RGB) hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.r, c.g, c.b)
+}
+
+// SaveSVG renders the prompt as selectable text in the same window as the PNG.
+func (ir *Renderer) SaveSVG() error {
+	var (
+		corner   = 6.0
+		radius   = 9.0
+		distance = 25.0
+	)
+
+	margin := ir.margin / ir.factor
+	padding := ir.padding / ir.factor
+	titleOffset := 40.0
+	shadowOffsetX := ir.shadowOffsetX / ir.factor
+	shadowOffsetY := ir.shadowOffsetY / ir.factor
+
+	lines := strings.Split(ir.AnsiString, "\n")
+
+	contentWidth := float64(145+ir.additionalWidth()) * svgCellWidth
+	contentHeight := float64(len(lines)) * svgLineHeight
+
+	width := contentWidth + 2*margin + 2*padding
+	height := contentHeight + 2*margin + 2*padding + titleOffset
+
+	xOffset := margin - shadowOffsetX/2
+	yOffset := margin - shadowOffsetY/2
+
+	var svg strings.Builder
+
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f">`, width, height, width, height)
+	svg.WriteString("\n")
+	fmt.Fprintf(&svg, `<style>text { font-family: %s; font-size: %.0fpx; white-space: pre; }</style>`, svgFontFamily, svgFontSize)
+	svg.WriteString("\n")
+	fmt.Fprintf(&svg, `<filter id="shadow" x="-10%%" y="-10%%" width="130%%" height="130%%"><feDropShadow dx="%.0f" dy="%.0f" stdDeviation="%.0f" flood-color="#101010" flood-opacity="0.4"/></filter>`, //nolint:lll
+		shadowOffsetX, shadowOffsetY, float64(ir.shadowRadius)/ir.factor/2)
+	svg.WriteString("\n")
+
+	background := ir.BgColor
+	if len(background) == 0 {
+		background = "#000000"
+	}
+
+	// the window with its controls and content area
+	fmt.Fprintf(&svg, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" rx="%.0f" fill="%s" stroke="#404040" filter="url(#shadow)"/>`,
+		xOffset, yOffset, width-2*margin, height-2*margin, corner, html.EscapeString(background))
+	svg.WriteString("\n")
+
+	for i, color := range []string{red, yellow, green} {
+		fmt.Fprintf(&svg, `<circle cx="%.1f" cy="%.1f" r="%.0f" fill="%s"/>`, xOffset+padding+float64(i)*distance+4, yOffset+padding+4, radius, color)
+		svg.WriteString("\n")
+	}
+
+	x := xOffset + padding
+	y := yOffset + padding + titleOffset + svgFontSize
+
+	var runs []*svgRun
+	var run *svgRun
+	var column, line int
+
+	flush := func() {
+		if run != nil && run.width != 0 {
+			runs = append(runs, run)
+		}
+
+		run = nil
+	}
+
+	ir.print(func(char rune) {
+		if char == '\n' {
+			flush()
+			writeSVGLine(&svg, runs, x, y+float64(line)*svgLineHeight)
+			runs = nil
+			column = 0
+			line++
+			return
+		}
+
+		additionalWidth := ir.runeAdditionalWidth(char)
+
+		foregroundColor := ir.foregroundColor
+		if foregroundColor == nil {
+			foregroundColor = ir.defaultForegroundColor
+		}
+
+		// glyphs wider than a single cell are written on their own to keep the alignment
+		if run == nil || additionalWidth != 0 || run.foreground != foregroundColor || run.background != ir.backgroundColor || run.style != ir.style {
+			flush()
+			run = &svgRun{
+				foreground: foregroundColor,
+				background: ir.backgroundColor,
+				style:      ir.style,
+				column:     column,
+			}
+		}
+
+		run.text.WriteRune(char)
+		run.width += 1 + additionalWidth
+		column += 1 + additionalWidth
+
+		if additionalWidth != 0 {
+			flush()
+		}
+	})
+
+	flush()
+	writeSVGLine(&svg, runs, x, y+float64(line)*svgLineHeight)
+
+	svg.WriteString("</svg>\n")
+
+	return os.WriteFile(ir.Path, []byte(svg.String()), 0644)
+}
+
+func writeSVGLine(svg *strings.Builder, runs []*svgRun, x, y float64) {
+	// backgrounds go first so they don't overlap the text of the previous run,
+	// adjacent runs sharing the same background are drawn as a single rectangle
+	var background *RGB
+	var start, end int
+
+	drawBackground := func() {
+		if background == nil {
+			return
+		}
+
+		fmt.Fprintf(svg, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s" shape-rendering="crispEdges"/>`,
+			x+float64(start)*svgCellWidth, y-svgFontSize, float64(end-start)*svgCellWidth, svgLineHeight, background.hex())
+		svg.WriteString("\n")
+	}
+
+	for _, run := range runs {
+		if background != nil && run.background != nil && *run.background == *background && run.column == end {
+			end += run.width
+			continue
+		}
+
+		drawBackground()
+
+		background = run.background
+		start = run.column
+		end = run.column + run.width
+	}
+
+	drawBackground()
+
+	for _, run := range runs {
+		text := run.text.String()
+		if len(strings.TrimSpace(text)) == 0 {
+			continue
+		}
+
+		var attributes string
+
+		switch run.style {
+		case bold:
+			attributes = ` font-weight="bold"`
+		case italic:
+			attributes = ` font-style="italic"`
+		case underline:
+			attributes = ` text-decoration="underline"`
+		case overline:
+			attributes = ` text-decoration="overline"`
+		}
+
+		// make sure the text spans the same cells, regardless of the font used to display it
+		if run.width > 1 {
+			attributes += fmt.Sprintf(` textLength="%.1f" lengthAdjust="spacing"`, float64(run.width)*svgCellWidth)
+		}
+
+		fmt.Fprintf(svg, `<text x="%.1f" y="%.1f" fill="%s"%s>%s</text>`,
+			x+float64(run.column)*svgCellWidth, y, run.foreground.hex(), attributes, html.EscapeString(text))
+		svg.WriteString("\n")
+	}
+}
@@ -0,0 +1,57 @@
+package prompt
+
+import (
+	"errors"
+	"os"
+	"slices"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/daemon"
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+)
+
+// setAsyncValue fetches the last known value of an async segment from the daemon.
+// When there's no daemon or no value yet, the segment executes as usual.
+func (e *Engine) setAsyncValue(block *config.Block, segment *config.Segment, index int) {
+	if !segment.Async {
+		return
+	}
+
+	// the daemon executes the segment on a terminal using our environment variables,
+	// scenarios, replays and recordings execute the segment themselves instead
+	if _, OK := e.Env.(*runtime.Terminal); !OK {
+		return
+	}
+
+	blockIndex := slices.Index(e.Config.Blocks, block)
+	if blockIndex == -1 {
+		return
+	}
+
+	// the daemon runs elsewhere, make sure it uses our working directory
+	flags := *e.Env.Flags()
+	flags.PWD = e.Env.Pwd()
+
+	response, err := daemon.Get(daemon.SocketPath(), &daemon.Request{
+		Flags:   &flags,
+		Environ: os.Environ(),
+		Block:   blockIndex,
+		Segment: index,
+	})
+	// not running a daemon is the default, only log unexpected errors
+	if errors.Is(err, daemon.ErrNotRunning) {
+		return
+	}
+
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	if !response.Found {
+		return
+	}
+
+	segment.SetAsyncValue(response.Value, response.Enabled)
+}
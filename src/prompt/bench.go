@@ -0,0 +1,236 @@
+package prompt
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/timing"
+)
+
+// Timings contains the measured durations of every run
+type Timings []time.Duration
+
+// Percentile returns the nearest-rank percentile (0-100) of the timings
+func (t Timings) Percentile(percentile float64) time.Duration {
+	if len(t) == 0 {
+		return 0
+	}
+
+	sorted := slices.Clone(t)
+	slices.Sort(sorted)
+
+	rank := int(math.Ceil(percentile / 100 * float64(len(sorted))))
+	rank = max(rank, 1)
+
+	return sorted[rank-1]
+}
+
+type BenchSegment struct {
+	Name    string
+	Timings Timings
+}
+
+type BenchBlock struct {
+	Type      config.BlockType
+	Alignment config.BlockAlignment
+	Segments  []*BenchSegment
+	Timings   Timings
+}
+
+// Benchmark holds the timings of rendering the primary prompt multiple times
+type Benchmark struct {
+	Categories map[timing.Category]Timings
+	Blocks     []*BenchBlock
+	Total      Timings
+	Runs       int
+	Cached     bool
+}
+
+// Bench renders the primary prompt the given number of times and collects the timings of every
+// block and segment. Without cache, segment caches and async segments are disabled so every segment
+// executes on every run. With cache, an initial run that isn't measured fills the caches.
+// The runs use an empty cache folder, so they neither depend on nor change the caches of the user.
+func Bench(flags *runtime.Flags, runs int, cached bool) (*Benchmark, error) {
+	timing.Enable()
+
+	cachePath, err := os.MkdirTemp("", "oh-my-posh-bench")
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.RemoveAll(cachePath)
+
+	cacheDir, isSet := os.LookupEnv("OMP_CACHE_DIR")
+	_ = os.Setenv("OMP_CACHE_DIR", cachePath)
+
+	defer func() {
+		if isSet {
+			_ = os.Setenv("OMP_CACHE_DIR", cacheDir)
+			return
+		}
+
+		_ = os.Unsetenv("OMP_CACHE_DIR")
+	}()
+
+	flags.Type = PRIMARY
+	flags.IsPrimary = true
+	flags.Bench = true
+	flags.SaveCache = cached
+
+	benchmark := &Benchmark{
+		Runs:       runs,
+		Cached:     cached,
+		Categories: make(map[timing.Category]Timings),
+	}
+
+	if cached {
+		benchRun(flags, cached)
+	}
+
+	for i := 0; i < runs; i++ {
+		benchmark.add(benchRun(flags, cached))
+	}
+
+	return benchmark, nil
+}
+
+func benchRun(flags *runtime.Flags, cached bool) (*Engine, time.Duration, map[timing.Category]time.Duration) {
+	timing.Reset()
+
+	start := time.Now()
+
+	eng := New(flags)
+
+	if !cached {
+		eng.disableCaches()
+	}
+
+	eng.Primary()
+	eng.Env.Close()
+
+	return eng, time.Since(start), timing.Reset()
+}
+
+func (e *Engine) disableCaches() {
+	for _, block := range e.Config.Blocks {
+		for _, segment := range block.Segments {
+			segment.Cache = nil
+			segment.Async = false
+		}
+	}
+}
+
+func (b *Benchmark) add(eng *Engine, total time.Duration, categories map[timing.Category]time.Duration) {
+	b.Total = append(b.Total, total)
+
+	for _, category := range []timing.Category{timing.Template, timing.Color, timing.Command} {
+		b.Categories[category] = append(b.Categories[category], categories[category])
+	}
+
+	var blocks []*config.Block
+	for _, block := range eng.Config.Blocks {
+		// status blocks are not part of the prompt
+		if block.Type != config.Status {
+			blocks = append(blocks, block)
+		}
+	}
+
+	if b.Blocks == nil {
+		for _, block := range blocks {
+			benchBlock := &BenchBlock{
+				Type:      block.Type,
+				Alignment: block.Alignment,
+			}
+
+			for _, segment := range block.Segments {
+				benchBlock.Segments = append(benchBlock.Segments, &BenchSegment{Name: segment.Name()})
+			}
+
+			b.Blocks = append(b.Blocks, benchBlock)
+		}
+	}
+
+	// every run loads the same config, so the blocks and segments are in the same order
+	for i, block := range blocks {
+		if i >= len(b.Blocks) {
+			break
+		}
+
+		b.Blocks[i].Timings = append(b.Blocks[i].Timings, block.Duration)
+
+		for j, segment := range block.Segments {
+			if j >= len(b.Blocks[i].Segments) {
+				break
+			}
+
+			b.Blocks[i].Segments[j].Timings = append(b.Blocks[i].Segments[j].Timings, segment.Duration)
+		}
+	}
+}
+
+func (b *Benchmark) String() string {
+	type row struct {
+		name    string
+		timings Timings
+	}
+
+	rows := []*row{{name: "total", timings: b.Total}}
+
+	for i, block := range b.Blocks {
+		name := fmt.Sprintf("block %d (%s", i+1, block.Type)
+		if len(block.Alignment) != 0 {
+			name += ", " + string(block.Alignment)
+		}
+
+		rows = append(rows, &row{name: name + ")", timings: block.Timings})
+
+		for _, segment := range block.Segments {
+			rows = append(rows, &row{name: "  " + segment.Name, timings: segment.Timings})
+		}
+	}
+
+	// parallel work adds up, these can exceed the total
+	rows = append(rows,
+		&row{name: "templates", timings: b.Categories[timing.Template]},
+		&row{name: "colors", timings: b.Categories[timing.Color]},
+		&row{name: "commands", timings: b.Categories[timing.Command]},
+	)
+
+	width := 0
+	for _, r := range rows {
+		width = max(width, len(r.name))
+	}
+
+	var builder strings.Builder
+
+	cache := "without cache"
+	if b.Cached {
+		cache = "with cache"
+	}
+
+	builder.WriteString(fmt.Sprintf("%d runs %s\n\n", b.Runs, cache))
+	builder.WriteString(fmt.Sprintf("%-*s %12s %12s %12s %12s\n", width, "", "min", "median", "p95", "max"))
+
+	format := func(duration time.Duration) string {
+		return duration.Round(time.Microsecond).String()
+	}
+
+	for _, r := range rows {
+		builder.WriteString(fmt.Sprintf("%-*s %12s %12s %12s %12s\n",
+			width,
+			r.name,
+			format(r.timings.Percentile(0)),
+			format(r.timings.Percentile(50)),
+			format(r.timings.Percentile(95)),
+			format(r.timings.Percentile(100)),
+		))
+	}
+
+	return builder.String()
+}
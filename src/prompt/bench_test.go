@@ -0,0 +1,92 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/timing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimingsPercentile(t *testing.T) {
+	timings := Timings{5, 1, 4, 2, 3, 10, 9, 8, 7, 6}
+
+	cases := []struct {
+		Case       string
+		Percentile float64
+		Expected   time.Duration
+	}{
+		{Case: "Min", Percentile: 0, Expected: 1},
+		{Case: "Median", Percentile: 50, Expected: 5},
+		{Case: "P95", Percentile: 95, Expected: 10},
+		{Case: "P90", Percentile: 90, Expected: 9},
+		{Case: "Max", Percentile: 100, Expected: 10},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.Expected, timings.Percentile(tc.Percentile), tc.Case)
+	}
+
+	assert.Equal(t, time.Duration(0), Timings{}.Percentile(50), "Empty")
+}
+
+func TestBench(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "bench.omp.json")
+	err := os.WriteFile(configFile, []byte(`{
+  "version": 3,
+  "blocks": [
+    {
+      "type": "prompt",
+      "alignment": "left",
+      "segments": [
+        { "type": "text", "alias": "Hello", "template": "hello", "cache": { "duration": "1h", "strategy": "folder" } },
+        { "type": "text", "template": "{{ .Shell }}" }
+      ]
+    },
+    {
+      "type": "status",
+      "segments": [{ "type": "text", "template": "status" }]
+    }
+  ]
+}`), 0644)
+	assert.NoError(t, err)
+
+	userCache := t.TempDir()
+	t.Setenv("OMP_CACHE_DIR", userCache)
+
+	benchmark, err := Bench(&runtime.Flags{Config: configFile, Shell: "bash"}, 3, false)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 3, benchmark.Runs)
+	assert.Len(t, benchmark.Total, 3)
+	assert.Len(t, benchmark.Categories[timing.Template], 3)
+	assert.Len(t, benchmark.Blocks, 1)
+	assert.Equal(t, config.Prompt, benchmark.Blocks[0].Type)
+	assert.Len(t, benchmark.Blocks[0].Timings, 3)
+	assert.Len(t, benchmark.Blocks[0].Segments, 2)
+	assert.Equal(t, "Hello", benchmark.Blocks[0].Segments[0].Name)
+	assert.Len(t, benchmark.Blocks[0].Segments[1].Timings, 3)
+
+	for _, duration := range benchmark.Blocks[0].Segments[0].Timings {
+		assert.NotZero(t, duration)
+	}
+
+	assert.Contains(t, benchmark.String(), "3 runs without cache")
+	assert.Contains(t, benchmark.String(), "  Hello")
+
+	benchmark, err = Bench(&runtime.Flags{Config: configFile, Shell: "bash"}, 2, true)
+	assert.NoError(t, err)
+	assert.Len(t, benchmark.Total, 2)
+	assert.Contains(t, benchmark.String(), "2 runs with cache")
+
+	// the caches of the user are left untouched
+	assert.Equal(t, userCache, os.Getenv("OMP_CACHE_DIR"))
+	entries, err := os.ReadDir(userCache)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
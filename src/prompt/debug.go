@@ -2,6 +2,7 @@ package prompt
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/cache"
@@ -57,18 +58,31 @@ func (e *Engine) PrintDebug(startTime time.Time, version string) string {
 	for _, segment := range segments {
 		duration := segment.Duration.Milliseconds()
 		var active log.Text
-		if segment.Enabled {
+		switch {
+		case segment.TimedOut:
+			active = log.Text("timeout").Red()
+		case segment.Enabled:
 			active = log.Text("true").Yellow()
-		} else {
+		default:
 			active = log.Text("false").Purple()
 		}
 		segmentName := fmt.Sprintf("%s(%s)", segment.Name(), active.Plain())
-		e.write(fmt.Sprintf("%-*s - %3d ms\n", largestSegmentNameLength, segmentName, duration))
+
+		// show where the segment is defined when the config extends other configs
+		var origin string
+		if origins := segment.Origins(); len(origins) != 0 {
+			origin = fmt.Sprintf(" (%s)", strings.Join(origins, ", "))
+		}
+
+		e.write(fmt.Sprintf("%-*s - %3d ms%s\n", largestSegmentNameLength, segmentName, duration, origin))
 	}
 
 	e.write(fmt.Sprintf("\n%s %s\n", log.Text("Run duration:").Green().Bold().Plain(), time.Since(startTime)))
 	e.write(fmt.Sprintf("\n%s %s\n", log.Text("Cache path:").Green().Bold().Plain(), cache.Path()))
 
+	hits, misses := cache.CommandStats()
+	e.write(fmt.Sprintf("\n%s %d hits, %d misses\n", log.Text("Command cache:").Green().Bold().Plain(), hits, misses))
+
 	cfg := e.Env.Flags().Config
 	if len(cfg) == 0 {
 		cfg = "no --config set, using default built-in configuration"
@@ -22,6 +22,7 @@ type Engine struct {
 	rprompt               string
 	Overflow              config.Overflow
 	prompt                strings.Builder
+	line                  *lineBlock
 	currentLineLength     int
 	rpromptLength         int
 	Padding               int
@@ -37,6 +38,11 @@ const (
 	TOOLTIP   = "tooltip"
 	VALID     = "valid"
 	ERROR     = "error"
+
+	TMUXLEFT    = "tmux-left"
+	TMUXRIGHT   = "tmux-right"
+	ZELLIJLEFT  = "zellij-left"
+	ZELLIJRIGHT = "zellij-right"
 )
 
 func (e *Engine) write(text string) {
@@ -141,6 +147,7 @@ func (e *Engine) getNewline() string {
 func (e *Engine) writeNewline() {
 	defer func() {
 		e.currentLineLength = 0
+		e.line = nil
 	}()
 
 	e.write(e.getNewline())
@@ -194,6 +201,9 @@ func (e *Engine) getTitleTemplateText() string {
 }
 
 func (e *Engine) renderBlock(block *config.Block, cancelNewline bool) bool {
+	// the colors of a cycle are assigned when writing, keep them to rewrite the block when it needs to fit
+	blockCycle := cycle
+
 	text, length := e.writeBlockSegments(block)
 
 	// do not print anything when we don't have any text unless forced
@@ -213,6 +223,13 @@ func (e *Engine) renderBlock(block *config.Block, cancelNewline bool) bool {
 	switch block.Type {
 	case config.Prompt:
 		if block.Alignment == config.Left {
+			e.line = &lineBlock{
+				block:  block,
+				cycle:  blockCycle,
+				start:  e.prompt.Len(),
+				length: length,
+			}
+
 			e.currentLineLength += length
 			e.write(text)
 			return true
@@ -237,12 +254,23 @@ func (e *Engine) renderBlock(block *config.Block, cancelNewline bool) bool {
 				}
 
 				e.currentLineLength = 0
+				e.line = nil
 				return true
+			case config.Fit:
+				text, length = e.fitBlocks(block, blockCycle)
+
+				// nothing left of the block, or to drop
+				if space, OK = e.canWriteRightBlock(length, false); !OK || length == 0 {
+					e.currentLineLength = 0
+					e.line = nil
+					return true
+				}
 			}
 		}
 
 		defer func() {
 			e.currentLineLength = 0
+			e.line = nil
 			e.Overflow = ""
 		}()
 
@@ -480,12 +508,18 @@ func (e *Engine) rectifyTerminalWidth(diff int) {
 // given configuration options, and is ready to print any
 // of the prompt components.
 func New(flags *runtime.Flags) *Engine {
-	flags.Config = config.Path(flags.Config)
-	cfg := config.Load(flags.Config, flags.Shell, flags.Migrate)
-
 	env := &runtime.Terminal{}
 	env.Init(flags)
 
+	return NewWithEnvironment(flags, env)
+}
+
+// NewWithEnvironment returns a prompt engine like New, but renders
+// the prompt using the given (initialized) environment.
+func NewWithEnvironment(flags *runtime.Flags, env runtime.Environment) *Engine {
+	flags.Config = config.Path(flags.Config)
+	cfg := config.Load(flags.Config, flags.Shell, flags.Migrate)
+
 	template.Init(env, cfg.Var)
 
 	flags.HasExtra = cfg.DebugPrompt != nil ||
@@ -494,6 +528,8 @@ func New(flags *runtime.Flags) *Engine {
 		cfg.ValidLine != nil ||
 		cfg.ErrorLine != nil
 
+	flags.QueryBackground = cfg.QueryTerminalBackground
+
 	terminal.Init(env.Shell())
 	terminal.BackgroundColor = cfg.TerminalBackground.ResolveTemplate()
 	terminal.Colors = cfg.MakeColors(env)
@@ -0,0 +1,173 @@
+package prompt
+
+import (
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+
+	"github.com/mattn/go-runewidth"
+)
+
+const ellipsis = "…"
+
+// lineBlock is the left aligned block written last on the current line
+type lineBlock struct {
+	block  *config.Block
+	cycle  *color.Cycle
+	start  int
+	length int
+}
+
+// fitBlocks drops or truncates the lowest priority segments of the right block and the left block
+// before it on the same line, until both fit. The left block is written again, the text of the right block is returned.
+func (e *Engine) fitBlocks(block *config.Block, blockCycle *color.Cycle) (string, int) {
+	var blocks []*config.Block
+	lineLength := e.currentLineLength
+
+	// the blocks are written again with the colors they started with
+	startCycle := blockCycle
+
+	if e.line != nil {
+		blocks = append(blocks, e.line.block)
+		lineLength -= e.line.length
+		startCycle = e.line.cycle
+	}
+
+	blocks = append(blocks, block)
+
+	truncated := make(map[*config.Segment]bool)
+
+	var leftText, text string
+	var leftLength, length int
+
+	for {
+		cycle = startCycle
+
+		if e.line != nil {
+			leftText, leftLength = e.rewriteBlockSegments(e.line.block)
+		}
+
+		text, length = e.rewriteBlockSegments(block)
+
+		e.currentLineLength = lineLength + leftLength
+
+		// both blocks need to fit on the line, without wrapping the left block
+		overflow := e.overflow(length)
+		if overflow <= 0 {
+			break
+		}
+
+		segment := lowestPrioritySegment(blocks)
+		if segment == nil {
+			break
+		}
+
+		// truncate the segment when it's long enough to make room, drop it otherwise
+		if !truncated[segment] && truncateSegment(segment, overflow) {
+			truncated[segment] = true
+			continue
+		}
+
+		segment.Enabled = false
+	}
+
+	if e.line != nil {
+		prompt := e.prompt.String()
+		e.prompt.Reset()
+		e.write(prompt[:e.line.start])
+		e.write(leftText)
+		e.line.length = leftLength
+	}
+
+	return text, length
+}
+
+// overflow returns how many characters need to go for the right block to fit,
+// including the breathing room canWriteRightBlock keeps between the blocks
+func (e *Engine) overflow(length int) int {
+	consoleWidth, _ := e.Env.TerminalWidth()
+	return e.currentLineLength + length + 5 - consoleWidth
+}
+
+// rewriteBlockSegments writes the rendered segments of the block again, so the separators
+// and diamonds match the segments that are left
+func (e *Engine) rewriteBlockSegments(block *config.Block) (string, int) {
+	for _, segment := range block.Segments {
+		e.writeSegment(block, segment)
+	}
+
+	if e.activeSegment != nil && len(block.TrailingDiamond) > 0 {
+		e.activeSegment.TrailingDiamond = block.TrailingDiamond
+	}
+
+	e.writeSeparator(true)
+
+	e.activeSegment = nil
+	e.previousActiveSegment = nil
+
+	return terminal.String()
+}
+
+// lowestPrioritySegment returns the enabled segment with the lowest priority,
+// the last one in the prompt goes first when segments have the same priority
+func lowestPrioritySegment(blocks []*config.Block) *config.Segment {
+	var lowest *config.Segment
+
+	for _, block := range blocks {
+		for _, segment := range block.Segments {
+			if !segment.Enabled {
+				continue
+			}
+
+			if lowest == nil || segment.Priority <= lowest.Priority {
+				lowest = segment
+			}
+		}
+	}
+
+	return lowest
+}
+
+// truncateSegment shortens the text of the segment by the overflow and adds an ellipsis,
+// text with color overrides can't be cut safely and isn't truncated.
+// Like the terminal writer, it measures the display width, so wide characters count double.
+func truncateSegment(segment *config.Segment, overflow int) bool {
+	text := segment.Text()
+	if strings.Contains(text, "<") {
+		return false
+	}
+
+	trimmed := strings.TrimRight(text, " ")
+	padding := len(text) - len(trimmed)
+
+	var width int
+	for _, char := range trimmed {
+		width += runewidth.RuneWidth(char)
+	}
+
+	available := width - overflow - runewidth.StringWidth(ellipsis)
+
+	var truncated strings.Builder
+	var used int
+
+	for _, char := range trimmed {
+		charWidth := runewidth.RuneWidth(char)
+		if used+charWidth > available {
+			break
+		}
+
+		used += charWidth
+		truncated.WriteRune(char)
+	}
+
+	// keep at least one character next to the ellipsis
+	if used == 0 {
+		return false
+	}
+
+	segment.SetText(truncated.String() + ellipsis + strings.Repeat(" ", padding))
+
+	return true
+}
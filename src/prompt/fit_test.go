@@ -0,0 +1,112 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/mock"
+	"github.com/jandedobbeleer/oh-my-posh/src/shell"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFitBlocks(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "fit.omp.json")
+	err := os.WriteFile(configFile, []byte(`{
+  "version": 3,
+  "blocks": [
+    {
+      "type": "prompt",
+      "alignment": "left",
+      "segments": [
+        { "type": "text", "template": " left-one ", "background": "blue", "style": "powerline", "powerline_symbol": ">", "priority": 10 },
+        { "type": "text", "template": " left-two-long-text ", "background": "red", "style": "powerline", "powerline_symbol": ">", "priority": 1 }
+      ]
+    },
+    {
+      "type": "prompt",
+      "alignment": "right",
+      "overflow": "fit",
+      "segments": [
+        { "type": "text", "template": " right-one ", "background": "green", "style": "diamond", "leading_diamond": "(", "trailing_diamond": ")", "priority": 5 },
+        { "type": "text", "template": " right-two ", "style": "plain" }
+      ]
+    }
+  ]
+}`), 0644)
+	assert.NoError(t, err)
+
+	cases := []struct {
+		Case          string
+		Expected      string
+		TerminalWidth int
+	}{
+		{Case: "Fits", TerminalWidth: 80, Expected: "> left-one > left-two-long-text >                       ( right-one ) right-two "},
+		{Case: "Truncate the lowest priority", TerminalWidth: 55, Expected: "> left-one > left-two-long-text >     ( right-one ) r… "},
+		{Case: "Drop the lowest priority", TerminalWidth: 52, Expected: "> left-one > left-two-long-text >      ( right-one )"},
+		{Case: "Truncate", TerminalWidth: 45, Expected: "> left-one > left-two-lo… >     ( right-one )"},
+		{Case: "Drop from the left block", TerminalWidth: 30, Expected: "> left-one >     ( right-one )"},
+		{Case: "Truncate a diamond", TerminalWidth: 25, Expected: "> left-one >     ( rig… )"},
+		{Case: "Hide the right block", TerminalWidth: 20, Expected: "> left-one >"},
+	}
+
+	for _, tc := range cases {
+		engine := New(&runtime.Flags{
+			Config:        configFile,
+			Shell:         shell.GENERIC,
+			Plain:         true,
+			TerminalWidth: tc.TerminalWidth,
+		})
+
+		assert.Equal(t, tc.Expected, engine.Primary(), tc.Case)
+	}
+}
+
+func TestLowestPrioritySegment(t *testing.T) {
+	first := &config.Segment{Enabled: true, Priority: 1}
+	second := &config.Segment{Enabled: true, Priority: 1}
+	disabled := &config.Segment{Priority: 0}
+	important := &config.Segment{Enabled: true, Priority: 5}
+
+	blocks := []*config.Block{
+		{Segments: []*config.Segment{important, first}},
+		{Segments: []*config.Segment{disabled, second}},
+	}
+
+	assert.Equal(t, second, lowestPrioritySegment(blocks), "last one on a tie")
+
+	second.Enabled = false
+	assert.Equal(t, first, lowestPrioritySegment(blocks))
+
+	first.Enabled = false
+	important.Enabled = false
+	assert.Nil(t, lowestPrioritySegment(blocks))
+}
+
+func TestTruncateSegment(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Text     string
+		Expected string
+		Overflow int
+		OK       bool
+	}{
+		{Case: "Plain text", Text: " right-one ", Overflow: 4, Expected: " righ… ", OK: true},
+		{Case: "Wide characters", Text: " 日本語テキスト ", Overflow: 6, Expected: " 日本語… ", OK: true},
+		{Case: "Wide character at the cut", Text: " 日本語テキスト ", Overflow: 5, Expected: " 日本語テ… ", OK: true},
+		{Case: "Nothing left", Text: " 日本 ", Overflow: 4, Expected: " 日本 "},
+		{Case: "Color override", Text: " <red>right</> ", Overflow: 2, Expected: " <red>right</> "},
+	}
+
+	for _, tc := range cases {
+		segment := &config.Segment{Type: config.TEXT}
+		_ = segment.MapSegmentWithWriter(new(mock.Environment))
+		segment.SetText(tc.Text)
+
+		assert.Equal(t, tc.OK, truncateSegment(segment, tc.Overflow), tc.Case)
+		assert.Equal(t, tc.Expected, segment.Text(), tc.Case)
+	}
+}
@@ -0,0 +1,144 @@
+package prompt
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+)
+
+// Document is the structured representation of a rendered prompt
+type Document struct {
+	Blocks []*DocumentBlock `json:"blocks"`
+}
+
+type DocumentBlock struct {
+	Type      config.BlockType      `json:"type,omitempty"`
+	Alignment config.BlockAlignment `json:"alignment,omitempty"`
+	Segments  []*DocumentSegment    `json:"segments"`
+}
+
+type DocumentSegment struct {
+	Name       string              `json:"name"`
+	Type       config.SegmentType  `json:"type"`
+	Text       string              `json:"text"`
+	Foreground color.Ansi          `json:"foreground"`
+	Background color.Ansi          `json:"background"`
+	Style      config.SegmentStyle `json:"style"`
+	// Duration is the execution time in milliseconds
+	Duration float64 `json:"duration"`
+	Enabled  bool    `json:"enabled"`
+	TimedOut bool    `json:"timed_out"`
+}
+
+// JSON renders the prompt using the regular pipeline and returns
+// the resulting blocks and segments as a JSON document
+func (e *Engine) JSON(promptType, tip string) (string, error) {
+	var blocks []*config.Block
+
+	switch promptType {
+	case PRIMARY:
+		e.Primary()
+
+		for _, block := range e.Config.Blocks {
+			if block.Type != config.Status {
+				blocks = append(blocks, block)
+			}
+		}
+	case RIGHT:
+		e.RPrompt()
+
+		for _, block := range e.Config.Blocks {
+			if block.Type != config.RPrompt {
+				continue
+			}
+
+			blocks = append(blocks, block)
+			break
+		}
+	case TOOLTIP:
+		e.Tooltip(tip)
+
+		block := &config.Block{
+			Alignment: config.Right,
+		}
+
+		tip = strings.Trim(tip, " ")
+		for _, tooltip := range e.Config.Tooltips {
+			if e.shouldInvokeWithTip(tooltip, tip) {
+				block.Segments = append(block.Segments, tooltip)
+			}
+		}
+
+		blocks = append(blocks, block)
+	default:
+		return "", fmt.Errorf("the json format is not supported for the %s prompt", promptType)
+	}
+
+	document := &Document{
+		Blocks: make([]*DocumentBlock, 0, len(blocks)),
+	}
+
+	for _, block := range blocks {
+		documentBlock := &DocumentBlock{
+			Type:      block.Type,
+			Alignment: block.Alignment,
+			Segments:  make([]*DocumentSegment, 0, len(block.Segments)),
+		}
+
+		for _, segment := range block.Segments {
+			documentBlock.Segments = append(documentBlock.Segments, newDocumentSegment(segment))
+		}
+
+		document.Blocks = append(document.Blocks, documentBlock)
+	}
+
+	var builder strings.Builder
+
+	// segment text contains color overrides like <red>text</>
+	encoder := json.NewEncoder(&builder)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(document); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func newDocumentSegment(segment *config.Segment) *DocumentSegment {
+	resolve := func(value color.Ansi) color.Ansi {
+		if terminal.Colors == nil {
+			return value
+		}
+
+		resolved, err := terminal.Colors.Resolve(value)
+		if err != nil {
+			return value
+		}
+
+		return resolved
+	}
+
+	documentSegment := &DocumentSegment{
+		Name:       segment.Name(),
+		Type:       segment.Type,
+		Foreground: resolve(segment.ResolveForeground()),
+		Background: resolve(segment.ResolveBackground()),
+		Style:      segment.ResolveStyle(),
+		Duration:   float64(segment.Duration.Microseconds()) / 1000,
+		Enabled:    segment.Enabled,
+		TimedOut:   segment.TimedOut,
+	}
+
+	// segments that were never executed have no writer to render the text
+	if segment.Enabled {
+		documentSegment.Text = segment.Text()
+	}
+
+	return documentSegment
+}
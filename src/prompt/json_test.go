@@ -0,0 +1,122 @@
+package prompt
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSON(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "json.omp.json")
+	err := os.WriteFile(configFile, []byte(`{
+  "version": 3,
+  "palette": { "blue": "#0000ff" },
+  "blocks": [
+    {
+      "type": "prompt",
+      "alignment": "left",
+      "segments": [
+        { "type": "text", "alias": "Hello", "template": "hello", "foreground": "p:blue", "background": "red", "style": "powerline" },
+        { "type": "text", "template": "" }
+      ]
+    },
+    {
+      "type": "rprompt",
+      "segments": [
+        { "type": "text", "template": "world", "style": "diamond" }
+      ]
+    }
+  ],
+  "tooltips": [
+    { "type": "text", "tips": ["git"], "template": "tip" },
+    { "type": "text", "tips": ["go"], "template": "go" }
+  ]
+}`), 0644)
+	assert.NoError(t, err)
+
+	cases := []struct {
+		Case      string
+		Type      string
+		Tip       string
+		Expected  []*DocumentBlock
+		ShouldErr bool
+	}{
+		{
+			Case: "Primary",
+			Type: PRIMARY,
+			Expected: []*DocumentBlock{
+				{
+					Type:      config.Prompt,
+					Alignment: config.Left,
+					Segments: []*DocumentSegment{
+						{Name: "Hello", Type: config.TEXT, Text: "hello", Foreground: color.Ansi("#0000ff"), Background: "red", Style: config.Powerline, Enabled: true},
+						{Name: "Text", Type: config.TEXT, Style: config.Plain},
+					},
+				},
+				{
+					Type:     config.RPrompt,
+					Segments: []*DocumentSegment{{Name: "Text", Type: config.TEXT, Text: "world", Style: config.Diamond, Enabled: true}},
+				},
+			},
+		},
+		{
+			Case: "Right",
+			Type: RIGHT,
+			Expected: []*DocumentBlock{
+				{
+					Type:     config.RPrompt,
+					Segments: []*DocumentSegment{{Name: "Text", Type: config.TEXT, Text: "world", Style: config.Diamond, Enabled: true}},
+				},
+			},
+		},
+		{
+			Case: "Tooltip",
+			Type: TOOLTIP,
+			Tip:  " git ",
+			Expected: []*DocumentBlock{
+				{
+					Alignment: config.Right,
+					Segments:  []*DocumentSegment{{Name: "Text", Type: config.TEXT, Text: "tip", Style: config.Plain, Enabled: true}},
+				},
+			},
+		},
+		{Case: "Unsupported", Type: TRANSIENT, ShouldErr: true},
+	}
+
+	for _, tc := range cases {
+		engine := New(&runtime.Flags{
+			Config: configFile,
+			Shell:  "bash",
+			Type:   tc.Type,
+			JSON:   true,
+		})
+
+		got, err := engine.JSON(tc.Type, tc.Tip)
+		if tc.ShouldErr {
+			assert.Error(t, err, tc.Case)
+			continue
+		}
+
+		assert.NoError(t, err, tc.Case)
+
+		var document Document
+		err = json.Unmarshal([]byte(got), &document)
+		assert.NoError(t, err, tc.Case)
+
+		// timings differ between runs
+		for _, block := range document.Blocks {
+			for _, segment := range block.Segments {
+				segment.Duration = 0
+			}
+		}
+
+		assert.Equal(t, tc.Expected, document.Blocks, tc.Case)
+	}
+}
@@ -69,6 +69,11 @@ func (e *Engine) writePrimaryPrompt(needsPrimaryRPrompt bool) {
 			continue
 		}
 
+		// status blocks are only rendered in the status line of a terminal multiplexer
+		if block.Type == config.Status {
+			continue
+		}
+
 		if e.renderBlock(block, cancelNewline) {
 			didRender = true
 		}
@@ -97,7 +102,7 @@ func (e *Engine) writePrimaryPrompt(needsPrimaryRPrompt bool) {
 }
 
 func (e *Engine) needsPrimaryRightPrompt() bool {
-	if e.Env.Flags().Debug {
+	if e.Env.Flags().Debug || e.Env.Flags().JSON || e.Env.Flags().Bench {
 		return true
 	}
 
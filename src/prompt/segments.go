@@ -1,8 +1,8 @@
 package prompt
 
 import (
-	"runtime"
 	"slices"
+	"time"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/config"
 	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
@@ -14,6 +14,11 @@ type result struct {
 }
 
 func (e *Engine) writeBlockSegments(block *config.Block) (string, int) {
+	start := time.Now()
+	defer func() {
+		block.Duration = time.Since(start)
+	}()
+
 	length := len(block.Segments)
 
 	if length == 0 {
@@ -23,10 +28,10 @@ func (e *Engine) writeBlockSegments(block *config.Block) (string, int) {
 	out := make(chan result, length)
 
 	for i, segment := range block.Segments {
-		go func(segment *config.Segment) {
-			segment.Execute(e.Env)
-			out <- result{segment, i}
-		}(segment)
+		go func() {
+			e.setAsyncValue(block, segment, i)
+			e.executeSegment(segment, i, out)
+		}()
 	}
 
 	e.writeSegments(out, block)
@@ -43,6 +48,31 @@ func (e *Engine) writeBlockSegments(block *config.Block) (string, int) {
 	return terminal.String()
 }
 
+func (e *Engine) executeSegment(segment *config.Segment, index int, out chan result) {
+	timeout := segment.ExecutionTimeout(e.Config.SegmentTimeout)
+	if timeout == 0 {
+		segment.Execute(e.Env)
+		out <- result{segment, index}
+		return
+	}
+
+	placeholder := segment.Placeholder()
+	done := make(chan bool, 1)
+
+	go func() {
+		segment.Execute(e.Env)
+		done <- true
+	}()
+
+	select {
+	case <-done:
+		out <- result{segment, index}
+	case <-time.After(timeout):
+		placeholder.SetTimedOut(e.Env, timeout)
+		out <- result{placeholder, index}
+	}
+}
+
 func (e *Engine) writeSegments(out chan result, block *config.Block) {
 	count := len(block.Segments)
 	// store the current index
@@ -54,39 +84,41 @@ func (e *Engine) writeSegments(out chan result, block *config.Block) {
 	// store the unique names of executed segments
 	executed := make([]string, count)
 
-	for {
-		select {
-		case res := <-out:
-			executedCount++
+	for res := range out {
+		executedCount++
 
-			finished := executedCount == count
+		finished := executedCount == count
 
-			results[res.index] = res.segment
+		// a segment that timed out is replaced by its placeholder
+		results[res.index] = res.segment
+		block.Segments[res.index] = res.segment
 
-			name := res.segment.Name()
-			if !slices.Contains(executed, name) {
-				executed = append(executed, name)
-			}
+		name := res.segment.Name()
+		if !slices.Contains(executed, name) {
+			executed = append(executed, name)
+		}
 
-			segment := results[current]
+		segment := results[current]
 
-			for segment != nil {
-				if !e.canRenderSegment(segment, executed) && !finished {
-					break
-				}
+		for segment != nil {
+			if !e.canRenderSegment(segment, executed) && !finished {
+				break
+			}
 
-				segment.Render()
-				e.writeSegment(block, segment)
+			segment.Render()
 
-				if current == count-1 {
-					return
-				}
+			if segment.ExecutionTimeout(e.Config.SegmentTimeout) != 0 {
+				segment.SetTimeoutCache()
+			}
+
+			e.writeSegment(block, segment)
 
-				current++
-				segment = results[current]
+			if current == count-1 {
+				return
 			}
-		default:
-			runtime.Gosched()
+
+			current++
+			segment = results[current]
 		}
 	}
 }
@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/properties"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/mock"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -70,3 +72,76 @@ func TestCanRenderSegment(t *testing.T) {
 		assert.Equal(t, c.Expected, got, c.Case)
 	}
 }
+
+type slowWriter struct {
+	release chan struct{}
+	done    chan struct{}
+	text    string
+}
+
+func (s *slowWriter) Enabled() bool {
+	defer close(s.done)
+	<-s.release
+	return true
+}
+
+func (s *slowWriter) Template() string { return "" }
+
+func (s *slowWriter) SetText(text string) { s.text = text }
+
+func (s *slowWriter) Text() string { return s.text }
+
+func (s *slowWriter) Init(_ properties.Properties, _ runtime.Environment) {}
+
+func TestWriteBlockSegmentsTimeout(t *testing.T) {
+	release := make(chan struct{})
+	done := make(chan struct{})
+
+	// only the segment we execute calls Enabled, its placeholder never does
+	config.Segments["slow"] = func() config.SegmentWriter { return &slowWriter{release: release, done: done} }
+	defer delete(config.Segments, "slow")
+
+	engine := New(&runtime.Flags{
+		IsPrimary: true,
+	})
+	engine.Config.SegmentTimeout = 50
+
+	block := &config.Block{
+		Segments: []*config.Segment{
+			{
+				Type:     "text",
+				Template: "Hello",
+			},
+			{
+				Type:            "slow",
+				Template:        "World",
+				TimeoutTemplate: "...",
+			},
+		},
+	}
+
+	_, length := engine.writeBlockSegments(block)
+
+	// the timed out segment is still running, let it finish before we clean up
+	close(release)
+	<-done
+
+	assert.Equal(t, 8, length)
+	assert.False(t, block.Segments[0].TimedOut)
+	assert.True(t, block.Segments[1].TimedOut)
+	assert.Equal(t, "...", block.Segments[1].Text())
+	assert.Zero(t, block.Segments[1].Timeout, "the global timeout is not written to the segment")
+}
+
+func TestSetAsyncValueSkipsOtherEnvironments(t *testing.T) {
+	segment := &config.Segment{Type: "text", Async: true}
+	block := &config.Block{Segments: []*config.Segment{segment}}
+
+	// the mock panics when we ask for the flags or the working directory to contact the daemon
+	engine := &Engine{
+		Env:    new(mock.Environment),
+		Config: &config.Config{Blocks: []*config.Block{block}},
+	}
+
+	assert.NotPanics(t, func() { engine.setAsyncValue(block, segment, 0) })
+}
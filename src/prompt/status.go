@@ -0,0 +1,50 @@
+package prompt
+
+import (
+	"github.com/jandedobbeleer/oh-my-posh/src/config"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+)
+
+// StatusLine renders the status blocks for one side of the status line of a terminal multiplexer.
+// Colors and styles are written using the markup of the multiplexer instead of ANSI escape sequences.
+func (e *Engine) StatusLine(statusType string) string {
+	var alignment config.BlockAlignment
+
+	switch statusType {
+	case TMUXLEFT:
+		terminal.InitMarkup(terminal.Tmux)
+		alignment = config.Left
+	case TMUXRIGHT:
+		terminal.InitMarkup(terminal.Tmux)
+		alignment = config.Right
+	case ZELLIJLEFT:
+		terminal.InitMarkup(terminal.Zellij)
+		alignment = config.Left
+	case ZELLIJRIGHT:
+		terminal.InitMarkup(terminal.Zellij)
+		alignment = config.Right
+	default:
+		return ""
+	}
+
+	for _, block := range e.Config.Blocks {
+		if block.Type != config.Status {
+			continue
+		}
+
+		// status blocks are left aligned unless specified otherwise
+		blockAlignment := block.Alignment
+		if len(blockAlignment) == 0 {
+			blockAlignment = config.Left
+		}
+
+		if blockAlignment != alignment {
+			continue
+		}
+
+		text, _ := e.writeBlockSegments(block)
+		e.write(text)
+	}
+
+	return e.string()
+}
@@ -0,0 +1,75 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/shell"
+	"github.com/jandedobbeleer/oh-my-posh/src/terminal"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusLine(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "status.omp.json")
+	err := os.WriteFile(configFile, []byte(`{
+  "version": 3,
+  "blocks": [
+    {
+      "type": "prompt",
+      "alignment": "left",
+      "segments": [{ "type": "text", "template": "prompt" }]
+    },
+    {
+      "type": "status",
+      "segments": [
+        { "type": "text", "template": "#1", "foreground": "white", "background": "red", "style": "powerline", "powerline_symbol": ">" },
+        { "type": "text", "template": "two", "foreground": "white", "background": "#0000ff", "style": "powerline", "powerline_symbol": ">" }
+      ]
+    },
+    {
+      "type": "status",
+      "alignment": "right",
+      "segments": [{ "type": "text", "template": "right", "foreground": "black", "background": "green" }]
+    }
+  ]
+}`), 0644)
+	assert.NoError(t, err)
+
+	cases := []struct {
+		Case     string
+		Type     string
+		Expected string
+	}{
+		{
+			Case:     "Tmux left",
+			Type:     TMUXLEFT,
+			Expected: "#[default]#[fg=red,bg=default]#[reverse]>#[default]#[bg=red]#[fg=white]##1#[default]#[bg=#0000ff]#[fg=red]>#[default]#[bg=#0000ff]#[fg=white]two#[default]#[fg=#0000ff]>#[default]",
+		},
+		{
+			Case:     "Tmux right",
+			Type:     TMUXRIGHT,
+			Expected: "#[bg=green]#[fg=black]right#[default]",
+		},
+		{
+			Case:     "Zellij right",
+			Type:     ZELLIJRIGHT,
+			Expected: "#[bg=2]#[fg=0]right#[default]",
+		},
+		{Case: "Unknown", Type: PRIMARY},
+	}
+
+	for _, tc := range cases {
+		engine := New(&runtime.Flags{
+			Config: configFile,
+			Shell:  shell.BASH,
+			Type:   tc.Type,
+		})
+
+		assert.Equal(t, tc.Expected, engine.StatusLine(tc.Type), tc.Case)
+	}
+
+	terminal.Init(shell.GENERIC)
+}
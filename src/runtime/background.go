@@ -0,0 +1,101 @@
+package runtime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+)
+
+const (
+	// the time to wait for the terminal to answer the query
+	backgroundQueryTimeout = 100 * time.Millisecond
+
+	// terminals can switch between light and dark mode, so we check again regularly
+	backgroundCacheDuration = cache.Duration("1m")
+
+	// OSC 11 asks for the background color, the primary device attributes (DA1) that follow
+	// are answered by every terminal, so we know when to stop waiting for the color
+	backgroundQuery = "\x1b]11;?\x1b\\\x1b[c"
+)
+
+// TerminalBackground returns the background color reported by the terminal as a hex color,
+// or an empty string when the terminal does not support the query or querying is not enabled.
+func (term *Terminal) TerminalBackground() string {
+	defer log.Trace(time.Now())
+
+	if !term.CmdFlags.QueryBackground {
+		return ""
+	}
+
+	if val, found := term.Session().Get(cache.TERMINALBGCACHE); found {
+		log.Debug(val)
+		return val
+	}
+
+	var background string
+
+	response, err := queryTerminal(backgroundQuery, backgroundQueryTimeout)
+	if err != nil {
+		log.Error(err)
+	} else {
+		background = parseBackgroundResponse(response)
+	}
+
+	log.Debugf("terminal background: %s", background)
+	term.Session().Set(cache.TERMINALBGCACHE, background, backgroundCacheDuration)
+
+	return background
+}
+
+// parseBackgroundResponse converts the OSC 11 response (\x1b]11;rgb:RRRR/GGGG/BBBB) to a hex color
+func parseBackgroundResponse(response string) string {
+	index := strings.Index(response, "\x1b]11;")
+	if index == -1 {
+		return ""
+	}
+
+	response = response[index+5:]
+
+	if end := strings.IndexAny(response, "\x07\x1b"); end != -1 {
+		response = response[:end]
+	}
+
+	var value string
+
+	switch {
+	case strings.HasPrefix(response, "rgb:"):
+		value = strings.TrimPrefix(response, "rgb:")
+	case strings.HasPrefix(response, "rgba:"):
+		value = strings.TrimPrefix(response, "rgba:")
+	default:
+		return ""
+	}
+
+	components := strings.Split(value, "/")
+	if len(components) < 3 {
+		return ""
+	}
+
+	hex := "#"
+
+	for _, component := range components[:3] {
+		if len(component) == 0 || len(component) > 4 {
+			return ""
+		}
+
+		number, err := strconv.ParseUint(component, 16, 16)
+		if err != nil {
+			return ""
+		}
+
+		// components use 1 to 4 hex digits, scale them to 8 bits
+		maximum := uint64(1)<<(4*len(component)) - 1
+		hex += fmt.Sprintf("%02x", (number*255+maximum/2)/maximum)
+	}
+
+	return hex
+}
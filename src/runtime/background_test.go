@@ -0,0 +1,33 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBackgroundResponse(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Response string
+		Expected string
+	}{
+		{Case: "16 bit, ST", Response: "\x1b]11;rgb:1e1e/1e1e/2e2e\x1b\\\x1b[?62;22c", Expected: "#1e1e2e"},
+		{Case: "16 bit, BEL", Response: "\x1b]11;rgb:ffff/ffff/ffff\x07\x1b[?1;2c", Expected: "#ffffff"},
+		{Case: "8 bit", Response: "\x1b]11;rgb:ef/f1/f5\x07", Expected: "#eff1f5"},
+		{Case: "4 bit", Response: "\x1b]11;rgb:f/8/0\x07", Expected: "#ff8800"},
+		{Case: "rgba", Response: "\x1b]11;rgba:0000/0000/0000/ffff\x07", Expected: "#000000"},
+		{Case: "only device attributes", Response: "\x1b[?1;2c"},
+		{Case: "invalid color", Response: "\x1b]11;rgb:zz/00/00\x07"},
+		{Case: "unsupported format", Response: "\x1b]11;#ffffff\x07"},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.Expected, parseBackgroundResponse(tc.Response), tc.Case)
+	}
+}
+
+func TestTerminalBackgroundDisabled(t *testing.T) {
+	term := &Terminal{CmdFlags: &Flags{}}
+	assert.Empty(t, term.TerminalBackground())
+}
@@ -11,9 +11,21 @@ import (
 // Run is used to correctly run a command with a timeout.
 func Run(command string, args ...string) (string, error) {
 	// set a timeout of 4 seconds
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*4)
+	return run("", time.Second*4, command, args...)
+}
+
+// RunWithInput runs a command with the input on stdin, the command is killed after the timeout.
+func RunWithInput(input string, timeout time.Duration, command string, args ...string) (string, error) {
+	return run(input, timeout, command, args...)
+}
+
+func run(input string, timeout time.Duration, command string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 	cmd := exec.CommandContext(ctx, command, args...)
+	if len(input) != 0 {
+		cmd.Stdin = strings.NewReader(input)
+	}
 	var out bytes.Buffer
 	var err bytes.Buffer
 	cmd.Stdout = &out
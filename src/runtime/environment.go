@@ -3,6 +3,7 @@ package runtime
 import (
 	"io"
 	"io/fs"
+	"time"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/cache"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime/battery"
@@ -44,7 +45,9 @@ type Environment interface {
 	HasCommand(command string) bool
 	FileContent(file string) string
 	LsDir(input string) []fs.DirEntry
+	StatFiles(pattern string) []*FileStat
 	RunCommand(command string, args ...string) (string, error)
+	RunCommandWithInput(input string, timeout int, command string, args ...string) (string, error)
 	RunShellCommand(shell, command string) string
 	ExecutionTime() float64
 	Flags() *Flags
@@ -57,6 +60,7 @@ type Environment interface {
 	IsCygwin() bool
 	StackCount() int
 	TerminalWidth() (int, error)
+	TerminalBackground() string
 	Cache() cache.Cache
 	Session() cache.Cache
 	Close()
@@ -67,35 +71,39 @@ type Environment interface {
 	Connection(connectionType ConnectionType) (*Connection, error)
 	CursorPosition() (row, col int)
 	SystemInfo() (*SystemInfo, error)
+	Now() time.Time
 }
 
 type Flags struct {
-	PSWD          string
-	PipeStatus    string
-	Config        string
-	Shell         string
-	ShellVersion  string
-	PWD           string
-	AbsolutePWD   string
-	Type          string
-	ErrorCode     int
-	PromptCount   int
-	StackCount    int
-	Column        int
-	TerminalWidth int
-	ExecutionTime float64
-	JobCount      int
-	IsPrimary     bool
-	HasExtra      bool
-	Debug         bool
-	Plain         bool
-	Strict        bool
-	Cleared       bool
-	NoExitCode    bool
-	SaveCache     bool
-	Init          bool
-	Migrate       bool
-	Eval          bool
+	PSWD            string
+	PipeStatus      string
+	Config          string
+	Shell           string
+	ShellVersion    string
+	PWD             string
+	AbsolutePWD     string
+	Type            string
+	ErrorCode       int
+	PromptCount     int
+	StackCount      int
+	Column          int
+	TerminalWidth   int
+	ExecutionTime   float64
+	JobCount        int
+	IsPrimary       bool
+	HasExtra        bool
+	Debug           bool
+	Plain           bool
+	JSON            bool
+	Bench           bool
+	Strict          bool
+	Cleared         bool
+	NoExitCode      bool
+	SaveCache       bool
+	Init            bool
+	Migrate         bool
+	Eval            bool
+	QueryBackground bool
 }
 
 type CommandError struct {
@@ -113,6 +121,12 @@ type FileInfo struct {
 	IsDir        bool
 }
 
+type FileStat struct {
+	ModTime time.Time
+	Path    string
+	Size    int64
+}
+
 type WindowsRegistryValueType string
 
 const (
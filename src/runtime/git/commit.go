@@ -0,0 +1,236 @@
+package git
+
+import (
+	"bytes"
+	"container/heap"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Signature identifies the author or committer of a commit
+type Signature struct {
+	When  time.Time
+	Name  string
+	Email string
+}
+
+// Commit holds the parsed content of a commit object
+type Commit struct {
+	Author    Signature
+	Committer Signature
+	Subject   string
+	Parents   []Hash
+	Tree      Hash
+}
+
+// Commit reads the commit with the given hash
+func (r *Repository) Commit(hash Hash) (*Commit, error) {
+	defer r.objects.close()
+	return r.commit(hash)
+}
+
+func (r *Repository) commit(hash Hash) (*Commit, error) {
+	obj, err := r.objects.read(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if obj.kind != commitObject {
+		return nil, errors.New("not a commit: " + hash.String())
+	}
+
+	return parseCommit(obj.data)
+}
+
+func parseCommit(data []byte) (*Commit, error) {
+	commit := &Commit{}
+
+	header, message, _ := bytes.Cut(data, []byte("\n\n"))
+
+	for _, line := range strings.Split(string(header), "\n") {
+		key, value, _ := strings.Cut(line, " ")
+
+		switch key {
+		case "tree":
+			hash, err := ParseHash(value)
+			if err != nil {
+				return nil, err
+			}
+
+			commit.Tree = hash
+		case "parent":
+			hash, err := ParseHash(value)
+			if err != nil {
+				return nil, err
+			}
+
+			commit.Parents = append(commit.Parents, hash)
+		case "author":
+			commit.Author = parseSignature(value)
+		case "committer":
+			commit.Committer = parseSignature(value)
+		}
+	}
+
+	// the subject is the first paragraph of the message on a single line
+	subject, _, _ := strings.Cut(strings.TrimLeft(string(message), "\n"), "\n\n")
+	commit.Subject = strings.Join(strings.Fields(subject), " ")
+
+	return commit, nil
+}
+
+// parseSignature parses "Name <email> timestamp timezone"
+func parseSignature(value string) Signature {
+	var signature Signature
+
+	start := strings.LastIndex(value, "<")
+	end := strings.LastIndex(value, ">")
+	if start == -1 || end < start {
+		signature.Name = strings.TrimSpace(value)
+		return signature
+	}
+
+	signature.Name = strings.TrimSpace(value[:start])
+	signature.Email = value[start+1 : end]
+
+	fields := strings.Fields(value[end+1:])
+	if len(fields) == 0 {
+		return signature
+	}
+
+	if timestamp, err := strconv.ParseInt(fields[0], 10, 64); err == nil {
+		signature.When = time.Unix(timestamp, 0)
+	}
+
+	return signature
+}
+
+type queuedCommit struct {
+	when    time.Time
+	parents []Hash
+	hash    Hash
+}
+
+// commitQueue pops the most recent commit first
+type commitQueue []*queuedCommit
+
+func (q commitQueue) Len() int           { return len(q) }
+func (q commitQueue) Less(i, j int) bool { return q[i].when.After(q[j].when) }
+func (q commitQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *commitQueue) Push(x any) {
+	*q = append(*q, x.(*queuedCommit))
+}
+
+func (q *commitQueue) Pop() any {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[:n-1]
+	return item
+}
+
+// AheadBehind counts the commits reachable from local but not from upstream (ahead),
+// and the other way around (behind).
+func (r *Repository) AheadBehind(local, upstream Hash) (int, int, error) {
+	defer r.objects.close()
+
+	if local == upstream {
+		return 0, 0, nil
+	}
+
+	const (
+		left  uint8 = 1
+		right uint8 = 2
+		both        = left | right
+	)
+
+	flags := make(map[Hash]uint8)
+	visited := make(map[Hash][]Hash)
+	queue := &commitQueue{}
+
+	push := func(hash Hash, flag uint8) error {
+		if flags[hash]&flag == flag {
+			return nil
+		}
+
+		commit, err := r.commit(hash)
+		if err != nil {
+			return err
+		}
+
+		flags[hash] |= flag
+
+		parents := commit.Parents
+		if r.isShallow(hash) {
+			parents = nil
+		}
+
+		visited[hash] = parents
+		heap.Push(queue, &queuedCommit{hash: hash, when: commit.Committer.When, parents: parents})
+
+		return nil
+	}
+
+	// the walk can stop as soon as every queued commit is reachable from both sides
+	hasUnique := func() bool {
+		for _, item := range *queue {
+			if flags[item.hash] != both {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if err := push(local, left); err != nil {
+		return 0, 0, err
+	}
+
+	if err := push(upstream, right); err != nil {
+		return 0, 0, err
+	}
+
+	for queue.Len() > 0 && hasUnique() {
+		item := heap.Pop(queue).(*queuedCommit)
+
+		for _, parent := range item.parents {
+			if err := push(parent, flags[item.hash]); err != nil {
+				return 0, 0, err
+			}
+		}
+	}
+
+	// commits with the same or skewed timestamps can be visited before their children,
+	// make sure the flags reached every visited parent
+	for changed := true; changed; {
+		changed = false
+
+		for hash, parents := range visited {
+			for _, parent := range parents {
+				flag, ok := flags[parent]
+				if !ok || flag|flags[hash] == flag {
+					continue
+				}
+
+				flags[parent] |= flags[hash]
+				changed = true
+			}
+		}
+	}
+
+	var ahead, behind int
+
+	for _, flag := range flags {
+		switch flag {
+		case left:
+			ahead++
+		case right:
+			behind++
+		}
+	}
+
+	return ahead, behind, nil
+}
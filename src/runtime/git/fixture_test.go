@@ -0,0 +1,133 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fixture is a repository created with the git CLI to verify the native reader against
+type fixture struct {
+	t   *testing.T
+	dir string
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	// isolate the fixture from the user's configuration
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "Jan De Dobbeleer")
+	t.Setenv("GIT_AUTHOR_EMAIL", "jan@ohmyposh.dev")
+	t.Setenv("GIT_COMMITTER_NAME", "Jan De Dobbeleer")
+	t.Setenv("GIT_COMMITTER_EMAIL", "jan@ohmyposh.dev")
+
+	f := &fixture{t: t, dir: filepath.Join(t.TempDir(), "repo")}
+	f.git("init", "--quiet", "--initial-branch", "main", f.dir)
+
+	return f
+}
+
+func (f *fixture) git(args ...string) string {
+	f.t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = filepath.Dir(f.dir)
+
+	if _, err := os.Stat(f.dir); err == nil {
+		cmd.Dir = f.dir
+	}
+
+	output, err := cmd.CombinedOutput()
+	require.NoError(f.t, err, string(output))
+
+	return strings.TrimSpace(string(output))
+}
+
+// mergeWithConflicts merges branch, which is expected to fail
+func (f *fixture) mergeWithConflicts(branch string) {
+	f.t.Helper()
+
+	cmd := exec.Command("git", "merge", "--quiet", branch)
+	cmd.Dir = f.dir
+
+	output, err := cmd.CombinedOutput()
+	require.Error(f.t, err, string(output))
+}
+
+func (f *fixture) write(path, content string) {
+	f.t.Helper()
+
+	path = filepath.Join(f.dir, filepath.FromSlash(path))
+	require.NoError(f.t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(f.t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func (f *fixture) remove(path string) {
+	f.t.Helper()
+	require.NoError(f.t, os.RemoveAll(filepath.Join(f.dir, filepath.FromSlash(path))))
+}
+
+func (f *fixture) commit(message string) {
+	f.t.Helper()
+	f.git("add", "--all")
+	f.git("commit", "--quiet", "--allow-empty", "--message", message)
+}
+
+func (f *fixture) open() *Repository {
+	f.t.Helper()
+
+	gitDir := filepath.Join(f.dir, ".git")
+	repo, err := Open(f.dir, gitDir, gitDir)
+	require.NoError(f.t, err)
+
+	return repo
+}
+
+func (f *fixture) hash(rev string) Hash {
+	f.t.Helper()
+
+	hash, err := ParseHash(f.git("rev-parse", rev))
+	require.NoError(f.t, err)
+
+	return hash
+}
+
+// cliStatus converts the porcelain output of git status into a Status
+func (f *fixture) cliStatus(options StatusOptions) *Status {
+	f.t.Helper()
+
+	args := []string{"status", "--porcelain=2", "--no-renames", "--untracked-files=" + options.UntrackedFiles}
+	if len(options.IgnoreSubmodules) != 0 {
+		args = append(args, "--ignore-submodules="+options.IgnoreSubmodules)
+	}
+
+	output := f.git(args...)
+	status := &Status{}
+
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, "? "):
+			status.Untracked = append(status.Untracked, line[2:])
+		case strings.HasPrefix(line, "1 "):
+			fields := strings.SplitN(line, " ", 9)
+			status.Changes = append(status.Changes, &Change{Path: fields[8], Staging: fields[1][0], Working: fields[1][1]})
+		case strings.HasPrefix(line, "u "):
+			fields := strings.SplitN(line, " ", 11)
+			status.Changes = append(status.Changes, &Change{Path: fields[10], Staging: fields[1][0], Working: fields[1][1]})
+		}
+	}
+
+	return status
+}
@@ -0,0 +1,220 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+type ignorePattern struct {
+	regex *regexp.Regexp
+	// base is the folder of the .gitignore file, relative to the work tree
+	base     string
+	negate   bool
+	dirOnly  bool
+	basename bool
+}
+
+// parseIgnore parses the content of a .gitignore file located in base
+func parseIgnore(content, base string) []*ignorePattern {
+	var patterns []*ignorePattern
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+
+		// trailing spaces are ignored unless they're escaped
+		for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, `\ `) {
+			line = line[:len(line)-1]
+		}
+
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		pattern := &ignorePattern{base: base}
+
+		if strings.HasPrefix(line, "!") {
+			pattern.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+			line = line[1:]
+		}
+
+		if strings.HasSuffix(line, "/") {
+			pattern.dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+
+		// a pattern without a slash matches at any level
+		pattern.basename = !strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+
+		if len(line) == 0 {
+			continue
+		}
+
+		regex, err := regexp.Compile(globToRegex(line))
+		if err != nil {
+			continue
+		}
+
+		pattern.regex = regex
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns
+}
+
+func globToRegex(glob string) string {
+	var builder strings.Builder
+
+	builder.WriteString("^")
+
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+
+		switch {
+		case strings.HasPrefix(glob[i:], "**/") && (i == 0 || glob[i-1] == '/'):
+			// zero or more folders
+			builder.WriteString("(?:.*/)?")
+			i += 2
+		case glob[i:] == "**" && i > 0 && glob[i-1] == '/':
+			// everything inside
+			builder.WriteString(".*")
+			i++
+		case c == '*':
+			builder.WriteString("[^/]*")
+		case c == '?':
+			builder.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end == -1 {
+				builder.WriteString(`\[`)
+				continue
+			}
+
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+
+			builder.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(glob):
+			i++
+			builder.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	builder.WriteString("$")
+
+	return builder.String()
+}
+
+func (p *ignorePattern) match(path string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+
+	if len(p.base) != 0 {
+		if !strings.HasPrefix(path, p.base+"/") {
+			return false
+		}
+
+		path = path[len(p.base)+1:]
+	}
+
+	if p.basename {
+		path = path[strings.LastIndex(path, "/")+1:]
+	}
+
+	return p.regex.MatchString(path)
+}
+
+// ignored reports whether path, relative to the work tree, is excluded.
+// The last matching pattern wins, so more specific files must come last.
+func ignored(patterns []*ignorePattern, path string, isDir bool) bool {
+	for i := len(patterns) - 1; i >= 0; i-- {
+		if patterns[i].match(path, isDir) {
+			return !patterns[i].negate
+		}
+	}
+
+	return false
+}
+
+// excludes returns the patterns that apply to the whole work tree,
+// the global excludes file followed by the repository's info/exclude.
+func (r *Repository) excludes() []*ignorePattern {
+	var patterns []*ignorePattern
+
+	if file := globalExcludesFile(); len(file) != 0 {
+		content, _ := os.ReadFile(file)
+		patterns = append(patterns, parseIgnore(string(content), "")...)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(r.commonDir, "info", "exclude"))
+	patterns = append(patterns, parseIgnore(string(content), "")...)
+
+	return patterns
+}
+
+func globalExcludesFile() string {
+	home, _ := os.UserHomeDir()
+
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if len(configHome) == 0 && len(home) != 0 {
+		configHome = filepath.Join(home, ".config")
+	}
+
+	var file string
+
+	if len(configHome) != 0 {
+		file = filepath.Join(configHome, "git", "ignore")
+	}
+
+	// core.excludesFile overrides the default location
+	for _, config := range []string{filepath.Join(configHome, "git", "config"), filepath.Join(home, ".gitconfig")} {
+		content, err := os.ReadFile(config)
+		if err != nil {
+			continue
+		}
+
+		if value := readExcludesFile(string(content)); len(value) != 0 {
+			file = value
+		}
+	}
+
+	if strings.HasPrefix(file, "~/") && len(home) != 0 {
+		file = filepath.Join(home, file[2:])
+	}
+
+	return file
+}
+
+func readExcludesFile(config string) string {
+	var section, value string
+
+	for _, line := range strings.Split(config, "\n") {
+		line = strings.TrimSpace(line)
+
+		if strings.HasPrefix(line, "[") {
+			section = strings.ToLower(strings.Trim(line, "[] \t"))
+			continue
+		}
+
+		if section != "core" {
+			continue
+		}
+
+		key, val, found := strings.Cut(line, "=")
+		if found && strings.EqualFold(strings.TrimSpace(key), "excludesfile") {
+			value = strings.Trim(strings.TrimSpace(val), `"`)
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,44 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIgnored(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Ignore   string
+		Base     string
+		Path     string
+		IsDir    bool
+		Expected bool
+	}{
+		{Case: "Basename", Ignore: "*.log", Path: "logs/today.log", Expected: true},
+		{Case: "Basename, no match", Ignore: "*.log", Path: "logs/today.txt"},
+		{Case: "Anchored", Ignore: "/build", Path: "build", IsDir: true, Expected: true},
+		{Case: "Anchored, nested", Ignore: "/build", Path: "src/build", IsDir: true},
+		{Case: "Folder only, file", Ignore: "build/", Path: "build"},
+		{Case: "Folder only, folder", Ignore: "build/", Path: "src/build", IsDir: true, Expected: true},
+		{Case: "Middle slash", Ignore: "doc/*.txt", Path: "doc/notes.txt", Expected: true},
+		{Case: "Middle slash, nested", Ignore: "doc/*.txt", Path: "doc/server/notes.txt"},
+		{Case: "Leading double star", Ignore: "**/cache", Path: "a/b/cache", IsDir: true, Expected: true},
+		{Case: "Trailing double star", Ignore: "vendor/**", Path: "vendor/lib/lib.go", Expected: true},
+		{Case: "Middle double star", Ignore: "a/**/b", Path: "a/x/y/b", Expected: true},
+		{Case: "Middle double star, no folder", Ignore: "a/**/b", Path: "a/b", Expected: true},
+		{Case: "Negation", Ignore: "*.log\n!keep.log", Path: "keep.log"},
+		{Case: "Character class", Ignore: "file[0-9].txt", Path: "file1.txt", Expected: true},
+		{Case: "Negated character class", Ignore: "file[!0-9].txt", Path: "file1.txt"},
+		{Case: "Escaped hash", Ignore: `\#notes`, Path: "#notes", Expected: true},
+		{Case: "Comment", Ignore: "# notes", Path: "# notes"},
+		{Case: "Nested .gitignore", Ignore: "*.tmp", Base: "src", Path: "src/a/b.tmp", Expected: true},
+		{Case: "Nested .gitignore, outside", Ignore: "*.tmp", Base: "src", Path: "b.tmp"},
+		{Case: "Nested .gitignore, anchored", Ignore: "/out", Base: "src", Path: "src/out", IsDir: true, Expected: true},
+	}
+
+	for _, tc := range cases {
+		patterns := parseIgnore(tc.Ignore, tc.Base)
+		assert.Equal(t, tc.Expected, ignored(patterns, tc.Path, tc.IsDir), tc.Case)
+	}
+}
@@ -0,0 +1,277 @@
+package git
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	modeTree      uint32 = 0o040000
+	modeFile      uint32 = 0o100644
+	modeExecFile  uint32 = 0o100755
+	modeSymlink   uint32 = 0o120000
+	modeGitlink   uint32 = 0o160000
+	modeTypeMask  uint32 = 0o170000
+	indexHeader          = 12
+	indexHashSize        = 20
+
+	flagAssumeValid  uint16 = 0x8000
+	flagExtended     uint16 = 0x4000
+	flagSkipWorktree uint16 = 0x4000
+	flagIntentToAdd  uint16 = 0x2000
+)
+
+var errInvalidIndex = errors.New("invalid index file")
+
+type indexEntry struct {
+	path         string
+	mtime        time.Time
+	mode         uint32
+	size         uint32
+	stage        int
+	hash         Hash
+	assumeValid  bool
+	skipWorktree bool
+	intentToAdd  bool
+}
+
+type index struct {
+	// cacheTree contains the tree objects of the index folders that are still valid
+	cacheTree map[string]Hash
+	modTime   time.Time
+	entries   []*indexEntry
+}
+
+func (r *Repository) readIndex() (*index, error) {
+	path := filepath.Join(r.gitDir, "index")
+
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		// nothing was added yet
+		return &index{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := parseIndex(data)
+	if err != nil {
+		return nil, err
+	}
+
+	idx.modTime = info.ModTime()
+
+	return idx, nil
+}
+
+func parseIndex(data []byte) (*index, error) {
+	if len(data) < indexHeader+indexHashSize || !bytes.Equal(data[:4], []byte("DIRC")) {
+		return nil, errInvalidIndex
+	}
+
+	version := binary.BigEndian.Uint32(data[4:8])
+	if version < 2 || version > 4 {
+		return nil, ErrUnsupported
+	}
+
+	count := int(binary.BigEndian.Uint32(data[8:12]))
+	content := data[:len(data)-indexHashSize]
+	pos := indexHeader
+
+	idx := &index{
+		entries: make([]*indexEntry, 0, count),
+	}
+
+	var previous string
+
+	for i := 0; i < count; i++ {
+		start := pos
+
+		if pos+62 > len(content) {
+			return nil, errInvalidIndex
+		}
+
+		entry := &indexEntry{
+			mtime: time.Unix(int64(binary.BigEndian.Uint32(content[pos+8:])), int64(binary.BigEndian.Uint32(content[pos+12:]))),
+			mode:  binary.BigEndian.Uint32(content[pos+24:]),
+			size:  binary.BigEndian.Uint32(content[pos+36:]),
+		}
+
+		copy(entry.hash[:], content[pos+40:pos+60])
+
+		flags := binary.BigEndian.Uint16(content[pos+60:])
+		entry.stage = int(flags>>12) & 3
+		entry.assumeValid = flags&flagAssumeValid != 0
+		pos += 62
+
+		if flags&flagExtended != 0 {
+			if version < 3 || pos+2 > len(content) {
+				return nil, errInvalidIndex
+			}
+
+			extended := binary.BigEndian.Uint16(content[pos:])
+			entry.skipWorktree = extended&flagSkipWorktree != 0
+			entry.intentToAdd = extended&flagIntentToAdd != 0
+			pos += 2
+		}
+
+		if version == 4 {
+			// the path is prefix compressed against the previous entry
+			strip, n := readIndexVarint(content[pos:])
+			if n == 0 || strip > len(previous) {
+				return nil, errInvalidIndex
+			}
+
+			pos += n
+
+			end := bytes.IndexByte(content[pos:], 0)
+			if end == -1 {
+				return nil, errInvalidIndex
+			}
+
+			entry.path = previous[:len(previous)-strip] + string(content[pos:pos+end])
+			pos += end + 1
+		} else {
+			end := bytes.IndexByte(content[pos:], 0)
+			if end == -1 {
+				return nil, errInvalidIndex
+			}
+
+			entry.path = string(content[pos : pos+end])
+
+			// entries are padded with NUL bytes to a multiple of eight bytes
+			length := pos + end - start
+			pos = start + (length+8)&^7
+		}
+
+		previous = entry.path
+		idx.entries = append(idx.entries, entry)
+	}
+
+	// extensions follow the entries
+	for pos+8 <= len(content) {
+		signature := string(content[pos : pos+4])
+		size := int(binary.BigEndian.Uint32(content[pos+4:]))
+		pos += 8
+
+		if pos+size > len(content) {
+			return nil, errInvalidIndex
+		}
+
+		switch signature {
+		case "TREE":
+			idx.cacheTree = parseCacheTree(content[pos : pos+size])
+		case "link", "sdir":
+			// split and sparse indexes don't contain all entries
+			return nil, ErrUnsupported
+		}
+
+		pos += size
+	}
+
+	return idx, nil
+}
+
+// readIndexVarint reads the offset encoded variable length integers used by index version 4
+func readIndexVarint(data []byte) (int, int) {
+	if len(data) == 0 {
+		return 0, 0
+	}
+
+	c := data[0]
+	value := int(c & 0x7f)
+	read := 1
+
+	for c&0x80 != 0 {
+		if read >= len(data) {
+			return 0, 0
+		}
+
+		c = data[read]
+		value = ((value + 1) << 7) | int(c&0x7f)
+		read++
+	}
+
+	return value, read
+}
+
+// parseCacheTree reads the cache tree extension, entries with a negative count are invalidated
+func parseCacheTree(data []byte) map[string]Hash {
+	trees := make(map[string]Hash)
+
+	var parse func(prefix string) bool
+
+	parse = func(prefix string) bool {
+		nul := bytes.IndexByte(data, 0)
+		if nul == -1 {
+			return false
+		}
+
+		name := string(data[:nul])
+		data = data[nul+1:]
+
+		newline := bytes.IndexByte(data, '\n')
+		if newline == -1 {
+			return false
+		}
+
+		counts := bytes.Fields(data[:newline])
+		data = data[newline+1:]
+
+		if len(counts) != 2 {
+			return false
+		}
+
+		entries, err := strconv.Atoi(string(counts[0]))
+		if err != nil {
+			return false
+		}
+
+		subtrees, err := strconv.Atoi(string(counts[1]))
+		if err != nil {
+			return false
+		}
+
+		path := prefix + name
+
+		if entries >= 0 {
+			if len(data) < indexHashSize {
+				return false
+			}
+
+			var hash Hash
+			copy(hash[:], data[:indexHashSize])
+			data = data[indexHashSize:]
+			trees[path] = hash
+		}
+
+		if len(path) != 0 {
+			path += "/"
+		}
+
+		for i := 0; i < subtrees; i++ {
+			if !parse(path) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if !parse("") {
+		return nil
+	}
+
+	return trees
+}
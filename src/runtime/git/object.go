@@ -0,0 +1,244 @@
+package git
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/sha1" //nolint:gosec // git object names are SHA-1
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Hash is the SHA-1 name of a git object
+type Hash [20]byte
+
+// ZeroHash is the hash of an unborn branch
+var ZeroHash Hash
+
+// ParseHash converts a hex object name into a Hash
+func ParseHash(s string) (Hash, error) {
+	var hash Hash
+
+	s = strings.TrimSpace(s)
+	if len(s) != 40 {
+		return hash, fmt.Errorf("invalid object name: %s", s)
+	}
+
+	if _, err := hex.Decode(hash[:], []byte(s)); err != nil {
+		return hash, err
+	}
+
+	return hash, nil
+}
+
+func (h Hash) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+func (h Hash) IsZero() bool {
+	return h == ZeroHash
+}
+
+type objectType int
+
+const (
+	commitObject objectType = 1
+	treeObject   objectType = 2
+	blobObject   objectType = 3
+	tagObject    objectType = 4
+	ofsDelta     objectType = 6
+	refDelta     objectType = 7
+)
+
+var objectTypes = map[string]objectType{
+	"commit": commitObject,
+	"tree":   treeObject,
+	"blob":   blobObject,
+	"tag":    tagObject,
+}
+
+type object struct {
+	data []byte
+	kind objectType
+}
+
+var (
+	errObjectNotFound = errors.New("object not found")
+	errInvalidTree    = errors.New("invalid tree object")
+)
+
+// objectStore reads objects from the loose object folders and pack files
+type objectStore struct {
+	cache map[Hash]*object
+	dirs  []string
+	packs []*pack
+	// packs are discovered on first use
+	loaded bool
+}
+
+func newObjectStore(dir string) *objectStore {
+	store := &objectStore{
+		dirs:  []string{dir},
+		cache: make(map[Hash]*object),
+	}
+
+	// objects can be borrowed from other repositories
+	content, _ := os.ReadFile(filepath.Join(dir, "info", "alternates"))
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if !filepath.IsAbs(line) {
+			line = filepath.Join(dir, line)
+		}
+
+		store.dirs = append(store.dirs, line)
+	}
+
+	return store
+}
+
+func (s *objectStore) read(hash Hash) (*object, error) {
+	if obj, ok := s.cache[hash]; ok {
+		return obj, nil
+	}
+
+	obj, err := s.readLoose(hash)
+	if errors.Is(err, errObjectNotFound) {
+		obj, err = s.readPacked(hash)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// blobs are never read twice, no need to keep them around
+	if obj.kind != blobObject {
+		s.cache[hash] = obj
+	}
+
+	return obj, nil
+}
+
+func (s *objectStore) readLoose(hash Hash) (*object, error) {
+	name := hash.String()
+
+	for _, dir := range s.dirs {
+		obj, err := readLooseFile(filepath.Join(dir, name[:2], name[2:]))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+
+		return obj, err
+	}
+
+	return nil, errObjectNotFound
+}
+
+func readLooseFile(path string) (*object, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	reader, err := zlib.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseLooseObject(data)
+}
+
+func parseLooseObject(data []byte) (*object, error) {
+	nul := bytes.IndexByte(data, 0)
+	if nul == -1 {
+		return nil, errors.New("invalid object header")
+	}
+
+	kindName, sizeStr, found := strings.Cut(string(data[:nul]), " ")
+	if !found {
+		return nil, errors.New("invalid object header")
+	}
+
+	kind, ok := objectTypes[kindName]
+	if !ok {
+		return nil, fmt.Errorf("unknown object type: %s", kindName)
+	}
+
+	size, err := strconv.Atoi(sizeStr)
+	if err != nil || size != len(data)-nul-1 {
+		return nil, errors.New("invalid object size")
+	}
+
+	return &object{kind: kind, data: data[nul+1:]}, nil
+}
+
+func (s *objectStore) readPacked(hash Hash) (*object, error) {
+	if !s.loaded {
+		s.loadPacks()
+	}
+
+	for _, p := range s.packs {
+		offset, ok, err := p.find(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			return p.readAt(s, offset)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", errObjectNotFound, hash)
+}
+
+func (s *objectStore) loadPacks() {
+	s.loaded = true
+
+	for _, dir := range s.dirs {
+		matches, _ := filepath.Glob(filepath.Join(dir, "pack", "*.idx"))
+		for _, match := range matches {
+			p, err := openPack(match)
+			if err != nil {
+				continue
+			}
+
+			s.packs = append(s.packs, p)
+		}
+	}
+}
+
+// close releases the open pack files, these are reopened on demand
+func (s *objectStore) close() {
+	for _, p := range s.packs {
+		p.close()
+	}
+}
+
+// hashObject returns the object name of content with the given type
+func hashObject(kind string, content []byte) Hash {
+	hasher := sha1.New() //nolint:gosec
+	fmt.Fprintf(hasher, "%s %d\x00", kind, len(content))
+	hasher.Write(content)
+
+	var hash Hash
+	copy(hash[:], hasher.Sum(nil))
+
+	return hash
+}
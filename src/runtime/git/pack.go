@@ -0,0 +1,369 @@
+package git
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	idxHeaderSize = 8
+	idxFanoutSize = 256 * 4
+)
+
+var idxSignature = []byte{0xff, 't', 'O', 'c'}
+
+// pack reads objects from a pack file using its version 2 index
+type pack struct {
+	idx    *os.File
+	file   *os.File
+	cache  map[int64]*object
+	path   string
+	fanout [256]uint32
+}
+
+func openPack(idxPath string) (*pack, error) {
+	idx, err := os.Open(idxPath)
+	if err != nil {
+		return nil, err
+	}
+
+	defer idx.Close()
+
+	header := make([]byte, idxHeaderSize+idxFanoutSize)
+	if _, err := io.ReadFull(idx, header); err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(header[:4], idxSignature) || binary.BigEndian.Uint32(header[4:8]) != 2 {
+		return nil, ErrUnsupported
+	}
+
+	p := &pack{
+		path:  strings.TrimSuffix(idxPath, ".idx"),
+		cache: make(map[int64]*object),
+	}
+
+	for i := range p.fanout {
+		start := idxHeaderSize + i*4
+		p.fanout[i] = binary.BigEndian.Uint32(header[start : start+4])
+	}
+
+	return p, nil
+}
+
+func (p *pack) open() error {
+	if p.idx != nil {
+		return nil
+	}
+
+	idx, err := os.Open(p.path + ".idx")
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(p.path + ".pack")
+	if err != nil {
+		idx.Close()
+		return err
+	}
+
+	p.idx = idx
+	p.file = file
+
+	return nil
+}
+
+func (p *pack) close() {
+	if p.idx == nil {
+		return
+	}
+
+	p.idx.Close()
+	p.file.Close()
+	p.idx = nil
+	p.file = nil
+}
+
+// find returns the offset of the object in the pack file
+func (p *pack) find(hash Hash) (int64, bool, error) {
+	var low uint32
+	if hash[0] > 0 {
+		low = p.fanout[hash[0]-1]
+	}
+
+	high := p.fanout[hash[0]]
+	if low == high {
+		return 0, false, nil
+	}
+
+	if err := p.open(); err != nil {
+		return 0, false, err
+	}
+
+	count := int64(p.fanout[255])
+	entry := make([]byte, len(hash))
+
+	for low < high {
+		mid := low + (high-low)/2
+
+		if _, err := p.idx.ReadAt(entry, idxHeaderSize+idxFanoutSize+int64(mid)*int64(len(hash))); err != nil {
+			return 0, false, err
+		}
+
+		switch bytes.Compare(entry, hash[:]) {
+		case 0:
+			offset, err := p.offset(int64(mid), count)
+			return offset, err == nil, err
+		case -1:
+			low = mid + 1
+		default:
+			high = mid
+		}
+	}
+
+	return 0, false, nil
+}
+
+func (p *pack) offset(position, count int64) (int64, error) {
+	// the offsets follow the object names and their CRC32 checksums
+	offsets := idxHeaderSize + idxFanoutSize + count*(20+4)
+
+	buf := make([]byte, 8)
+	if _, err := p.idx.ReadAt(buf[:4], offsets+position*4); err != nil {
+		return 0, err
+	}
+
+	offset := binary.BigEndian.Uint32(buf[:4])
+	if offset&0x80000000 == 0 {
+		return int64(offset), nil
+	}
+
+	// large pack files store 64 bit offsets in a separate table
+	largeOffsets := offsets + count*4
+	if _, err := p.idx.ReadAt(buf, largeOffsets+int64(offset&0x7fffffff)*8); err != nil {
+		return 0, err
+	}
+
+	return int64(binary.BigEndian.Uint64(buf)), nil
+}
+
+func (p *pack) readAt(store *objectStore, offset int64) (*object, error) {
+	if obj, ok := p.cache[offset]; ok {
+		return obj, nil
+	}
+
+	if err := p.open(); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 32)
+	n, err := p.file.ReadAt(header, offset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	header = header[:n]
+	if len(header) == 0 {
+		return nil, errors.New("invalid pack offset")
+	}
+
+	kind := objectType((header[0] >> 4) & 7)
+	size := int64(header[0] & 0x0f)
+	pos := 1
+	shift := 4
+
+	for header[pos-1]&0x80 != 0 {
+		if pos >= len(header) {
+			return nil, errors.New("invalid pack object header")
+		}
+
+		size |= int64(header[pos]&0x7f) << shift
+		shift += 7
+		pos++
+	}
+
+	var base *object
+
+	switch kind {
+	case ofsDelta:
+		relative, read := readOffset(header[pos:])
+		if read == 0 {
+			return nil, errors.New("invalid delta offset")
+		}
+
+		pos += read
+
+		base, err = p.readAt(store, offset-relative)
+	case refDelta:
+		if len(header) < pos+20 {
+			return nil, errors.New("invalid delta base")
+		}
+
+		var baseHash Hash
+		copy(baseHash[:], header[pos:pos+20])
+		pos += 20
+
+		base, err = store.read(baseHash)
+	case commitObject, treeObject, blobObject, tagObject:
+	default:
+		return nil, fmt.Errorf("unknown pack object type: %d", kind)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := p.inflate(offset+int64(pos), size)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &object{kind: kind, data: data}
+
+	if base != nil {
+		data, err = applyDelta(base.data, data)
+		if err != nil {
+			return nil, err
+		}
+
+		obj = &object{kind: base.kind, data: data}
+	}
+
+	if obj.kind != blobObject {
+		p.cache[offset] = obj
+	}
+
+	return obj, nil
+}
+
+func (p *pack) inflate(offset, size int64) ([]byte, error) {
+	reader, err := zlib.NewReader(io.NewSectionReader(p.file, offset, 1<<62))
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// readOffset reads the negative offset of an ofs-delta base object
+func readOffset(data []byte) (int64, int) {
+	if len(data) == 0 {
+		return 0, 0
+	}
+
+	c := data[0]
+	offset := int64(c & 0x7f)
+	read := 1
+
+	for c&0x80 != 0 {
+		if read >= len(data) {
+			return 0, 0
+		}
+
+		c = data[read]
+		offset = ((offset + 1) << 7) | int64(c&0x7f)
+		read++
+	}
+
+	return offset, read
+}
+
+func readVarint(data []byte) (int, int) {
+	var value, shift int
+
+	for i, c := range data {
+		value |= int(c&0x7f) << shift
+		shift += 7
+
+		if c&0x80 == 0 {
+			return value, i + 1
+		}
+	}
+
+	return 0, 0
+}
+
+func applyDelta(base, delta []byte) ([]byte, error) {
+	errInvalid := errors.New("invalid delta")
+
+	baseSize, n := readVarint(delta)
+	if n == 0 || baseSize != len(base) {
+		return nil, errInvalid
+	}
+
+	delta = delta[n:]
+
+	targetSize, n := readVarint(delta)
+	if n == 0 {
+		return nil, errInvalid
+	}
+
+	delta = delta[n:]
+	target := make([]byte, 0, targetSize)
+
+	for len(delta) > 0 {
+		op := delta[0]
+		delta = delta[1:]
+
+		if op&0x80 == 0 {
+			// insert the next op bytes
+			if op == 0 || int(op) > len(delta) {
+				return nil, errInvalid
+			}
+
+			target = append(target, delta[:op]...)
+			delta = delta[op:]
+			continue
+		}
+
+		// copy from the base object, the op bits indicate which offset and size bytes follow
+		var offset, size int
+
+		for i := 0; i < 7; i++ {
+			if op&(1<<i) == 0 {
+				continue
+			}
+
+			if len(delta) == 0 {
+				return nil, errInvalid
+			}
+
+			if i < 4 {
+				offset |= int(delta[0]) << (8 * i)
+			} else {
+				size |= int(delta[0]) << (8 * (i - 4))
+			}
+
+			delta = delta[1:]
+		}
+
+		if size == 0 {
+			size = 0x10000
+		}
+
+		if offset+size > len(base) {
+			return nil, errInvalid
+		}
+
+		target = append(target, base[offset:offset+size]...)
+	}
+
+	if len(target) != targetSize {
+		return nil, errInvalid
+	}
+
+	return target, nil
+}
@@ -0,0 +1,318 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	headsPrefix   = "refs/heads/"
+	remotesPrefix = "refs/remotes/"
+	tagsPrefix    = "refs/tags/"
+)
+
+type packedRef struct {
+	hash   Hash
+	peeled Hash
+}
+
+// Head returns the branch HEAD points to and the commit it resolves to.
+// The branch is empty when HEAD is detached, the hash is zero on an unborn branch.
+func (r *Repository) Head() (string, Hash, error) {
+	content, err := os.ReadFile(filepath.Join(r.gitDir, "HEAD"))
+	if err != nil {
+		return "", ZeroHash, err
+	}
+
+	head := strings.TrimSpace(string(content))
+
+	if target, ok := strings.CutPrefix(head, "ref: "); ok {
+		hash, _ := r.resolveRef(target)
+		return strings.TrimPrefix(target, headsPrefix), hash, nil
+	}
+
+	hash, err := ParseHash(head)
+	return "", hash, err
+}
+
+// Upstream returns the short name of the upstream branch configured for branch and the commit
+// it points to. The name is empty when there's no upstream, found is false when it's gone.
+func (r *Repository) Upstream(branch string) (name string, hash Hash, found bool) {
+	section := `branch "` + branch + `"`
+	remote := r.configValue(section, "remote")
+	merge := r.configValue(section, "merge")
+
+	if len(remote) == 0 || len(merge) == 0 {
+		return "", ZeroHash, false
+	}
+
+	ref := merge
+
+	// the upstream is a remote tracking branch unless it's a local one
+	if remote != "." {
+		var ok bool
+		ref, ok = r.trackingRef(remote, merge)
+		if !ok {
+			return "", ZeroHash, false
+		}
+	}
+
+	name = strings.TrimPrefix(strings.TrimPrefix(ref, headsPrefix), remotesPrefix)
+	hash, found = r.resolveRef(ref)
+
+	return name, hash, found
+}
+
+// trackingRef maps a branch on the remote to the local ref using the remote's fetch refspecs
+func (r *Repository) trackingRef(remote, ref string) (string, bool) {
+	var result string
+	var found bool
+
+	for _, spec := range r.configValues(`remote "`+remote+`"`, "fetch") {
+		spec = strings.TrimPrefix(spec, "+")
+
+		// negative refspecs exclude refs, they don't map them
+		if strings.HasPrefix(spec, "^") {
+			continue
+		}
+
+		src, dst, ok := strings.Cut(spec, ":")
+		if !ok {
+			continue
+		}
+
+		if before, after, wildcard := strings.Cut(src, "*"); wildcard {
+			if !strings.HasPrefix(ref, before) || !strings.HasSuffix(ref, after) || len(ref) < len(before)+len(after) {
+				continue
+			}
+
+			match := ref[len(before) : len(ref)-len(after)]
+			result, found = strings.Replace(dst, "*", match, 1), true
+			continue
+		}
+
+		if src == ref {
+			result, found = dst, true
+		}
+	}
+
+	return result, found
+}
+
+// BranchAt returns the name of a local or remote tracking branch pointing to hash
+func (r *Repository) BranchAt(hash Hash) string {
+	for _, prefix := range []string{headsPrefix, remotesPrefix} {
+		refs := r.refs(prefix)
+
+		names := make([]string, 0, len(refs))
+		for name := range refs {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			if refs[name] == hash && !strings.HasSuffix(name, "/HEAD") {
+				return strings.TrimPrefix(name, headsPrefix)
+			}
+		}
+	}
+
+	return ""
+}
+
+// TagsAt returns the tags pointing to hash, annotated tags come first
+func (r *Repository) TagsAt(hash Hash) []string {
+	defer r.objects.close()
+
+	var annotated, lightweight []string
+
+	for name, target := range r.refs(tagsPrefix) {
+		peeled, isAnnotated := r.peel(name, target)
+		if peeled != hash {
+			continue
+		}
+
+		name = strings.TrimPrefix(name, tagsPrefix)
+		if isAnnotated {
+			annotated = append(annotated, name)
+			continue
+		}
+
+		lightweight = append(lightweight, name)
+	}
+
+	sort.Strings(annotated)
+	sort.Strings(lightweight)
+
+	return append(annotated, lightweight...)
+}
+
+// RefsAt returns the full names of all branches and tags pointing to hash
+func (r *Repository) RefsAt(hash Hash) []string {
+	defer r.objects.close()
+
+	var names []string
+
+	for name, target := range r.refs("refs/") {
+		if strings.HasPrefix(name, tagsPrefix) {
+			target, _ = r.peel(name, target)
+		}
+
+		if target == hash {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// peel resolves annotated tags to the commit they point to
+func (r *Repository) peel(name string, hash Hash) (Hash, bool) {
+	if packed, ok := r.readPackedRefs()[name]; ok && packed.hash == hash && !packed.peeled.IsZero() {
+		return packed.peeled, true
+	}
+
+	annotated := false
+
+	for i := 0; i < 10; i++ {
+		obj, err := r.objects.read(hash)
+		if err != nil || obj.kind != tagObject {
+			return hash, annotated
+		}
+
+		target, _, _ := bytes.Cut(obj.data, []byte("\n"))
+		parsed, err := ParseHash(string(bytes.TrimPrefix(target, []byte("object "))))
+		if err != nil {
+			return hash, annotated
+		}
+
+		hash = parsed
+		annotated = true
+	}
+
+	return hash, annotated
+}
+
+func (r *Repository) refPath(name string) string {
+	for _, prefix := range []string{"refs/bisect/", "refs/worktree/", "refs/rewritten/"} {
+		if strings.HasPrefix(name, prefix) {
+			return filepath.Join(r.gitDir, filepath.FromSlash(name))
+		}
+	}
+
+	if name == "HEAD" {
+		return filepath.Join(r.gitDir, name)
+	}
+
+	return filepath.Join(r.commonDir, filepath.FromSlash(name))
+}
+
+func (r *Repository) resolveRef(name string) (Hash, bool) {
+	// symbolic refs can point to other symbolic refs, but not endlessly
+	for i := 0; i < 10; i++ {
+		content, err := os.ReadFile(r.refPath(name))
+		if err != nil {
+			packed, ok := r.readPackedRefs()[name]
+			if !ok {
+				return ZeroHash, false
+			}
+
+			return packed.hash, true
+		}
+
+		value := strings.TrimSpace(string(content))
+		if target, ok := strings.CutPrefix(value, "ref: "); ok {
+			name = target
+			continue
+		}
+
+		hash, err := ParseHash(value)
+		return hash, err == nil
+	}
+
+	return ZeroHash, false
+}
+
+// refs returns all refs starting with prefix, loose refs take precedence over packed ones
+func (r *Repository) refs(prefix string) map[string]Hash {
+	refs := make(map[string]Hash)
+
+	for name, packed := range r.readPackedRefs() {
+		if strings.HasPrefix(name, prefix) {
+			refs[name] = packed.hash
+		}
+	}
+
+	root := filepath.Join(r.commonDir, filepath.FromSlash(prefix))
+
+	_ = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(r.commonDir, path)
+		if err != nil {
+			return nil
+		}
+
+		name := filepath.ToSlash(rel)
+		if hash, ok := r.resolveRef(name); ok {
+			refs[name] = hash
+		}
+
+		return nil
+	})
+
+	return refs
+}
+
+func (r *Repository) readPackedRefs() map[string]*packedRef {
+	if r.packedRefs != nil {
+		return r.packedRefs
+	}
+
+	r.packedRefs = make(map[string]*packedRef)
+
+	file, err := os.Open(filepath.Join(r.commonDir, "packed-refs"))
+	if err != nil {
+		return r.packedRefs
+	}
+
+	defer file.Close()
+
+	var previous *packedRef
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case len(line) == 0, strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "^"):
+			// the peeled value of the annotated tag on the previous line
+			if hash, err := ParseHash(line[1:]); err == nil && previous != nil {
+				previous.peeled = hash
+			}
+		default:
+			value, name, found := strings.Cut(line, " ")
+			hash, err := ParseHash(value)
+			if !found || err != nil {
+				continue
+			}
+
+			previous = &packedRef{hash: hash}
+			r.packedRefs[name] = previous
+		}
+	}
+
+	return r.packedRefs
+}
@@ -0,0 +1,126 @@
+package git
+
+import (
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHead(t *testing.T) {
+	f := newFixture(t)
+	f.commit("initial commit")
+	f.commit("second commit")
+
+	repo := f.open()
+	branch, hash, err := repo.Head()
+	require.NoError(t, err)
+	assert.Equal(t, "main", branch)
+	assert.Equal(t, f.hash("HEAD"), hash)
+
+	f.git("checkout", "--quiet", "--detach", "HEAD~1")
+
+	branch, hash, err = repo.Head()
+	require.NoError(t, err)
+	assert.Empty(t, branch)
+	assert.Equal(t, f.hash("HEAD"), hash)
+
+	// packed refs are used when there's no loose ref
+	f.git("checkout", "--quiet", "main")
+	f.git("pack-refs", "--all")
+
+	branch, hash, err = f.open().Head()
+	require.NoError(t, err)
+	assert.Equal(t, "main", branch)
+	assert.Equal(t, f.hash("main"), hash)
+}
+
+func TestUpstream(t *testing.T) {
+	remote := newFixture(t)
+	remote.commit("initial commit")
+	remote.commit("second commit")
+
+	f := &fixture{t: t, dir: filepath.Join(t.TempDir(), "clone")}
+	f.git("clone", "--quiet", remote.dir, f.dir)
+
+	// two commits ahead
+	f.commit("local one")
+	f.commit("local two")
+
+	// three commits behind
+	remote.commit("remote one")
+	remote.commit("remote two")
+	remote.commit("remote three")
+	f.git("fetch", "--quiet")
+
+	repo := f.open()
+	name, upstream, found := repo.Upstream("main")
+	assert.Equal(t, "origin/main", name)
+	assert.True(t, found)
+	assert.Equal(t, f.hash("origin/main"), upstream)
+
+	_, head, err := repo.Head()
+	require.NoError(t, err)
+
+	ahead, behind, err := repo.AheadBehind(head, upstream)
+	require.NoError(t, err)
+	assert.Equal(t, 2, ahead)
+	assert.Equal(t, 3, behind)
+
+	// packed objects must give the same result
+	f.git("gc", "--quiet")
+
+	ahead, behind, err = f.open().AheadBehind(head, upstream)
+	require.NoError(t, err)
+	assert.Equal(t, 2, ahead)
+	assert.Equal(t, 3, behind)
+
+	f.git("checkout", "--quiet", "-b", "feature")
+	name, _, _ = repo.Upstream("feature")
+	assert.Empty(t, name, "no upstream")
+
+	f.git("push", "--quiet", "--set-upstream", "origin", "feature")
+	f.git("push", "--quiet", "origin", "--delete", "feature")
+
+	name, _, found = f.open().Upstream("feature")
+	assert.Equal(t, "origin/feature", name)
+	assert.False(t, found, "upstream gone")
+}
+
+func TestTagsAt(t *testing.T) {
+	f := newFixture(t)
+	f.commit("initial commit")
+	f.git("tag", "lightweight")
+	f.git("tag", "--annotate", "--message", "release", "v1.0.0")
+	f.commit("second commit")
+	f.git("tag", "latest")
+
+	repo := f.open()
+	assert.Equal(t, []string{"v1.0.0", "lightweight"}, repo.TagsAt(f.hash("HEAD~1")))
+	assert.Equal(t, []string{"latest"}, repo.TagsAt(f.hash("HEAD")))
+
+	f.git("pack-refs", "--all")
+
+	repo = f.open()
+	assert.Equal(t, []string{"v1.0.0", "lightweight"}, repo.TagsAt(f.hash("HEAD~1")))
+	assert.Equal(t, []string{"refs/heads/main", "refs/tags/latest"}, repo.RefsAt(f.hash("HEAD")))
+	assert.Equal(t, "main", repo.BranchAt(f.hash("HEAD")))
+	assert.Empty(t, repo.BranchAt(f.hash("HEAD~1")))
+}
+
+func TestCommit(t *testing.T) {
+	f := newFixture(t)
+	f.commit("initial commit")
+	f.git("commit", "--quiet", "--allow-empty", "--message", "add the\nnative reader", "--message", "with a body")
+
+	commit, err := f.open().Commit(f.hash("HEAD"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "add the native reader", commit.Subject)
+	assert.Equal(t, "Jan De Dobbeleer", commit.Author.Name)
+	assert.Equal(t, "jan@ohmyposh.dev", commit.Committer.Email)
+	assert.Equal(t, f.git("log", "-1", "--format=%at"), strconv.FormatInt(commit.Author.When.Unix(), 10))
+	assert.Equal(t, []Hash{f.hash("HEAD~1")}, commit.Parents)
+}
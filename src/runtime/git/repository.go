@@ -0,0 +1,132 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/ini.v1"
+)
+
+// ErrUnsupported is returned when the repository uses a feature that can't be read natively,
+// callers are expected to fall back to the git CLI.
+var ErrUnsupported = errors.New("unsupported repository feature")
+
+// Repository reads the state of a git repository directly from disk.
+type Repository struct {
+	config     *ini.File
+	objects    *objectStore
+	packedRefs map[string]*packedRef
+	shallow    map[Hash]bool
+	gitDir     string
+	commonDir  string
+	workTree   string
+}
+
+// Open opens the repository with the given work tree. The git dir holds the files that
+// belong to the work tree (HEAD, index, ...), the common dir holds the shared objects,
+// refs and config. Both are identical unless the work tree is a linked worktree.
+func Open(workTree, gitDir, commonDir string) (*Repository, error) {
+	data, err := os.ReadFile(filepath.Join(commonDir, "config"))
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := ini.LoadSources(ini.LoadOptions{
+		AllowShadows:             true,
+		AllowBooleanKeys:         true,
+		InsensitiveKeys:          true,
+		SpaceBeforeInlineComment: true,
+	}, data)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := &Repository{
+		config:    config,
+		gitDir:    gitDir,
+		commonDir: commonDir,
+		workTree:  strings.TrimSuffix(workTree, "/"),
+	}
+
+	if format := repo.configValue("extensions", "objectformat"); len(format) != 0 && format != "sha1" {
+		return nil, ErrUnsupported
+	}
+
+	if storage := repo.configValue("extensions", "refstorage"); len(storage) != 0 && storage != "files" {
+		return nil, ErrUnsupported
+	}
+
+	// submodules point to their work tree from within the config
+	if dir := repo.configValue("core", "worktree"); len(dir) != 0 {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(gitDir, dir)
+		}
+
+		repo.workTree = filepath.Clean(dir)
+	}
+
+	repo.objects = newObjectStore(filepath.Join(commonDir, "objects"))
+
+	return repo, nil
+}
+
+func (r *Repository) configValue(section, key string) string {
+	return r.configValues(section, key).last()
+}
+
+type values []string
+
+func (v values) last() string {
+	if len(v) == 0 {
+		return ""
+	}
+
+	return v[len(v)-1]
+}
+
+func (r *Repository) configValues(section, key string) values {
+	s, err := r.config.GetSection(section)
+	if err != nil {
+		return nil
+	}
+
+	k, err := s.GetKey(key)
+	if err != nil {
+		return nil
+	}
+
+	return k.ValueWithShadows()
+}
+
+func (r *Repository) configBool(section, key string, defaultValue bool) bool {
+	s, err := r.config.GetSection(section)
+	if err != nil || !s.HasKey(key) {
+		return defaultValue
+	}
+
+	switch strings.ToLower(r.configValue(section, key)) {
+	case "", "true", "yes", "on", "1":
+		return true
+	case "false", "no", "off", "0":
+		return false
+	default:
+		return defaultValue
+	}
+}
+
+func (r *Repository) isShallow(hash Hash) bool {
+	if r.shallow == nil {
+		r.shallow = make(map[Hash]bool)
+
+		content, _ := os.ReadFile(filepath.Join(r.commonDir, "shallow"))
+		for _, line := range strings.Split(string(content), "\n") {
+			if hash, err := ParseHash(line); err == nil {
+				r.shallow[hash] = true
+			}
+		}
+	}
+
+	return r.shallow[hash]
+}
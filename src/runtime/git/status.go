@@ -0,0 +1,645 @@
+package git
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Untracked files modes, equal to the values of git status --untracked-files
+const (
+	UntrackedNo     = "no"
+	UntrackedNormal = "normal"
+	UntrackedAll    = "all"
+)
+
+// Submodule modes, equal to the values of git status --ignore-submodules
+const (
+	SubmodulesNone      = "none"
+	SubmodulesUntracked = "untracked"
+	SubmodulesDirty     = "dirty"
+	SubmodulesAll       = "all"
+)
+
+// StatusOptions alter which changes are reported
+type StatusOptions struct {
+	// UntrackedFiles is one of the untracked files modes, defaults to normal
+	UntrackedFiles string
+	// IgnoreSubmodules is one of the submodule modes, defaults to none
+	IgnoreSubmodules string
+}
+
+// Change is a path that differs between HEAD, the index and the work tree.
+// Staging and Working use the same codes as git status --porcelain, a dot means unchanged.
+type Change struct {
+	Path    string
+	Staging byte
+	Working byte
+}
+
+// Status holds the changes in a repository
+type Status struct {
+	Changes []*Change
+	// Untracked contains the untracked files, folders end with a slash
+	Untracked []string
+}
+
+type treeEntry struct {
+	name string
+	mode uint32
+	hash Hash
+}
+
+type statusContext struct {
+	options   StatusOptions
+	idx       *index
+	changes   map[string]*Change
+	conflicts map[string]uint8
+	// convertEOL indicates line endings might be converted on checkout
+	convertEOL bool
+	fileMode   bool
+	symlinks   bool
+	filters    bool
+}
+
+func (c *statusContext) change(path string) *Change {
+	if change, ok := c.changes[path]; ok {
+		return change
+	}
+
+	change := &Change{Path: path, Staging: '.', Working: '.'}
+	c.changes[path] = change
+
+	return change
+}
+
+// Status compares HEAD with the index (staging) and the index with the work tree (working)
+func (r *Repository) Status(head Hash, options StatusOptions) (*Status, error) {
+	defer r.objects.close()
+
+	if len(options.UntrackedFiles) == 0 {
+		options.UntrackedFiles = UntrackedNormal
+	}
+
+	if len(options.IgnoreSubmodules) == 0 {
+		options.IgnoreSubmodules = SubmodulesNone
+	}
+
+	idx, err := r.readIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := r.attributes()
+	autocrlf := strings.ToLower(r.configValue("core", "autocrlf"))
+
+	ctx := &statusContext{
+		options:    options,
+		idx:        idx,
+		changes:    make(map[string]*Change),
+		conflicts:  make(map[string]uint8),
+		convertEOL: autocrlf == "true" || autocrlf == "input" || strings.Contains(attributes, "text") || strings.Contains(attributes, "eol"),
+		filters:    strings.Contains(attributes, "filter=") || strings.Contains(attributes, "working-tree-encoding"),
+		fileMode:   r.configBool("core", "filemode", true),
+		symlinks:   r.configBool("core", "symlinks", true),
+	}
+
+	// unmerged paths have an entry per side instead of a single entry at stage 0
+	for _, entry := range idx.entries {
+		if entry.stage > 0 {
+			ctx.conflicts[entry.path] |= 1 << (entry.stage - 1)
+		}
+	}
+
+	for path, stages := range ctx.conflicts {
+		change := ctx.change(path)
+		change.Staging, change.Working = unmergedCodes(stages)
+	}
+
+	if err := r.compareHead(ctx, head); err != nil {
+		return nil, err
+	}
+
+	if err := r.compareWorkTree(ctx); err != nil {
+		return nil, err
+	}
+
+	status := &Status{}
+
+	for _, change := range ctx.changes {
+		status.Changes = append(status.Changes, change)
+	}
+
+	sort.Slice(status.Changes, func(i, j int) bool {
+		return status.Changes[i].Path < status.Changes[j].Path
+	})
+
+	if options.UntrackedFiles != UntrackedNo {
+		status.Untracked = r.untracked(idx, options.UntrackedFiles)
+	}
+
+	return status, nil
+}
+
+func unmergedCodes(stages uint8) (byte, byte) {
+	const (
+		base   uint8 = 1
+		ours   uint8 = 2
+		theirs uint8 = 4
+	)
+
+	switch stages {
+	case base | ours | theirs:
+		return 'U', 'U'
+	case ours | theirs:
+		return 'A', 'A'
+	case base | ours:
+		return 'U', 'D'
+	case base | theirs:
+		return 'D', 'U'
+	case ours:
+		return 'A', 'U'
+	case theirs:
+		return 'U', 'A'
+	default:
+		return 'D', 'D'
+	}
+}
+
+// compareHead finds the staged changes. Folders for which the index still has a valid
+// tree object identical to the one in HEAD are skipped.
+func (r *Repository) compareHead(ctx *statusContext, head Hash) error {
+	files := make(map[string]treeEntry)
+	unchanged := make(map[string]bool)
+
+	if !head.IsZero() {
+		commit, err := r.commit(head)
+		if err != nil {
+			return err
+		}
+
+		if err := r.flattenTree(commit.Tree, "", ctx.idx.cacheTree, files, unchanged); err != nil {
+			return err
+		}
+	}
+
+	isUnchanged := func(path string) bool {
+		if unchanged[""] {
+			return true
+		}
+
+		for i := 0; i < len(path); i++ {
+			if path[i] == '/' && unchanged[path[:i]] {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for _, entry := range ctx.idx.entries {
+		if entry.stage > 0 || entry.intentToAdd || isUnchanged(entry.path) {
+			continue
+		}
+
+		file, ok := files[entry.path]
+		delete(files, entry.path)
+
+		switch {
+		case !ok:
+			ctx.change(entry.path).Staging = 'A'
+		case file.mode&modeTypeMask != entry.mode&modeTypeMask:
+			ctx.change(entry.path).Staging = 'T'
+		case file.hash != entry.hash || file.mode != entry.mode:
+			ctx.change(entry.path).Staging = 'M'
+		}
+	}
+
+	for path := range files {
+		if _, conflict := ctx.conflicts[path]; conflict {
+			continue
+		}
+
+		ctx.change(path).Staging = 'D'
+	}
+
+	return nil
+}
+
+func (r *Repository) flattenTree(hash Hash, path string, cacheTree map[string]Hash, files map[string]treeEntry, unchanged map[string]bool) error {
+	if cached, ok := cacheTree[path]; ok && cached == hash {
+		unchanged[path] = true
+		return nil
+	}
+
+	entries, err := r.tree(hash)
+	if err != nil {
+		return err
+	}
+
+	prefix := path
+	if len(prefix) != 0 {
+		prefix += "/"
+	}
+
+	for _, entry := range entries {
+		if entry.mode == modeTree {
+			if err := r.flattenTree(entry.hash, prefix+entry.name, cacheTree, files, unchanged); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		files[prefix+entry.name] = entry
+	}
+
+	return nil
+}
+
+func (r *Repository) tree(hash Hash) ([]treeEntry, error) {
+	obj, err := r.objects.read(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if obj.kind != treeObject {
+		return nil, errInvalidTree
+	}
+
+	var entries []treeEntry
+
+	data := obj.data
+
+	for len(data) > 0 {
+		space := bytes.IndexByte(data, ' ')
+		nul := bytes.IndexByte(data, 0)
+
+		if space == -1 || nul < space || len(data) < nul+1+len(hash) {
+			return nil, errInvalidTree
+		}
+
+		mode, err := strconv.ParseUint(string(data[:space]), 8, 32)
+		if err != nil {
+			return nil, errInvalidTree
+		}
+
+		entry := treeEntry{
+			name: string(data[space+1 : nul]),
+			mode: uint32(mode),
+		}
+
+		copy(entry.hash[:], data[nul+1:nul+1+len(hash)])
+		entries = append(entries, entry)
+
+		data = data[nul+1+len(hash):]
+	}
+
+	return entries, nil
+}
+
+// compareWorkTree finds the unstaged changes
+func (r *Repository) compareWorkTree(ctx *statusContext) error {
+	for _, entry := range ctx.idx.entries {
+		if entry.stage > 0 || entry.skipWorktree || entry.assumeValid {
+			continue
+		}
+
+		if entry.intentToAdd {
+			ctx.change(entry.path).Working = 'A'
+			continue
+		}
+
+		code, err := r.workTreeCode(ctx, entry)
+		if err != nil {
+			return err
+		}
+
+		if code != '.' {
+			ctx.change(entry.path).Working = code
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) workTreeCode(ctx *statusContext, entry *indexEntry) (byte, error) {
+	path := filepath.Join(r.workTree, filepath.FromSlash(entry.path))
+
+	info, err := os.Lstat(path)
+	if err != nil {
+		return 'D', nil
+	}
+
+	if entry.mode == modeGitlink {
+		return r.submoduleCode(ctx, entry, path), nil
+	}
+
+	var mode uint32
+
+	switch {
+	case info.IsDir():
+		return 'D', nil
+	case info.Mode()&os.ModeSymlink != 0:
+		mode = modeSymlink
+	case info.Mode()&0o111 != 0:
+		mode = modeExecFile
+	default:
+		mode = modeFile
+	}
+
+	// without symlink support, links are checked out as plain files containing the target
+	if !ctx.symlinks && entry.mode == modeSymlink && mode != modeSymlink {
+		mode = modeSymlink
+	}
+
+	if !ctx.fileMode && mode != modeSymlink && entry.mode != modeSymlink {
+		mode = entry.mode
+	}
+
+	if mode&modeTypeMask != entry.mode&modeTypeMask {
+		return 'T', nil
+	}
+
+	if mode != entry.mode {
+		return 'M', nil
+	}
+
+	sameSize := uint32(info.Size()) == entry.size
+	if !sameSize && !ctx.convertEOL {
+		return 'M', nil
+	}
+
+	// an entry modified in the same instant the index was written can't be trusted
+	racy := !entry.mtime.Before(ctx.idx.modTime)
+
+	if sameSize && sameTime(info.ModTime().Unix(), int64(info.ModTime().Nanosecond()), entry) && !racy {
+		return '.', nil
+	}
+
+	equal, err := r.sameContent(ctx, path, mode, entry.hash)
+	if err != nil {
+		return 0, err
+	}
+
+	if equal {
+		return '.', nil
+	}
+
+	return 'M', nil
+}
+
+// submoduleCode reports a submodule as modified when it's on another commit or, depending on
+// the submodule mode, has changes itself
+func (r *Repository) submoduleCode(ctx *statusContext, entry *indexEntry, path string) byte {
+	if ctx.options.IgnoreSubmodules == SubmodulesAll {
+		return '.'
+	}
+
+	// a submodule that isn't initialized is an empty folder
+	submodule, err := openSubmodule(path)
+	if err != nil {
+		return '.'
+	}
+
+	_, head, err := submodule.Head()
+	if err != nil {
+		return '.'
+	}
+
+	if head != entry.hash {
+		return 'M'
+	}
+
+	if ctx.options.IgnoreSubmodules == SubmodulesDirty {
+		return '.'
+	}
+
+	untracked := UntrackedNo
+	if ctx.options.IgnoreSubmodules == SubmodulesNone {
+		untracked = UntrackedNormal
+	}
+
+	status, err := submodule.Status(head, StatusOptions{UntrackedFiles: untracked, IgnoreSubmodules: ctx.options.IgnoreSubmodules})
+	if err != nil || (len(status.Changes) == 0 && len(status.Untracked) == 0) {
+		return '.'
+	}
+
+	return 'M'
+}
+
+func openSubmodule(workTree string) (*Repository, error) {
+	gitDir := filepath.Join(workTree, ".git")
+
+	info, err := os.Stat(gitDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// absorbed submodules point to a folder inside the parent's .git/modules
+	if !info.IsDir() {
+		content, err := os.ReadFile(gitDir)
+		if err != nil {
+			return nil, err
+		}
+
+		dir, found := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir: ")
+		if !found {
+			return nil, ErrUnsupported
+		}
+
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(workTree, dir)
+		}
+
+		gitDir = filepath.Clean(dir)
+	}
+
+	commonDir := gitDir
+
+	if content, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		commonDir = strings.TrimSpace(string(content))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitDir, commonDir)
+		}
+	}
+
+	return Open(workTree, gitDir, commonDir)
+}
+
+func sameTime(seconds, nanoseconds int64, entry *indexEntry) bool {
+	if entry.mtime.Unix() != seconds {
+		return false
+	}
+
+	// not every file system stores nanoseconds
+	return entry.mtime.Nanosecond() == 0 || int64(entry.mtime.Nanosecond()) == nanoseconds
+}
+
+func (r *Repository) sameContent(ctx *statusContext, path string, mode uint32, hash Hash) (bool, error) {
+	var content []byte
+
+	if mode == modeSymlink && ctx.symlinks {
+		target, err := os.Readlink(path)
+		if err != nil {
+			return false, err
+		}
+
+		content = []byte(filepath.ToSlash(target))
+	} else {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return false, err
+		}
+
+		content = data
+	}
+
+	if hashObject("blob", content) == hash {
+		return true, nil
+	}
+
+	if ctx.convertEOL && bytes.Contains(content, []byte("\r\n")) {
+		converted := bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+		if hashObject("blob", converted) == hash {
+			return true, nil
+		}
+	}
+
+	// clean filters (like Git LFS) change the content before it's stored
+	if ctx.filters {
+		return false, ErrUnsupported
+	}
+
+	return false, nil
+}
+
+// attributes returns the content of the repository wide attributes files
+func (r *Repository) attributes() string {
+	var builder strings.Builder
+
+	for _, file := range []string{filepath.Join(r.workTree, ".gitattributes"), filepath.Join(r.commonDir, "info", "attributes")} {
+		content, _ := os.ReadFile(file)
+		builder.Write(content)
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
+
+// untracked walks the work tree in search of files that aren't in the index. In normal mode,
+// folders without tracked files are reported as a whole, like git does.
+func (r *Repository) untracked(idx *index, mode string) []string {
+	tracked := make(map[string]bool)
+	folders := make(map[string]bool)
+
+	for _, entry := range idx.entries {
+		tracked[entry.path] = true
+
+		for i := 0; i < len(entry.path); i++ {
+			if entry.path[i] == '/' {
+				folders[entry.path[:i]] = true
+			}
+		}
+	}
+
+	var untracked []string
+
+	var walk func(folder string, patterns []*ignorePattern, add func(string) bool) bool
+
+	walk = func(folder string, patterns []*ignorePattern, add func(string) bool) bool {
+		abs := filepath.Join(r.workTree, filepath.FromSlash(folder))
+
+		entries, err := os.ReadDir(abs)
+		if err != nil {
+			return true
+		}
+
+		if content, err := os.ReadFile(filepath.Join(abs, ".gitignore")); err == nil {
+			patterns = append(patterns[:len(patterns):len(patterns)], parseIgnore(string(content), folder)...)
+		}
+
+		for _, entry := range entries {
+			name := entry.Name()
+			if name == ".git" {
+				continue
+			}
+
+			path := name
+			if len(folder) != 0 {
+				path = folder + "/" + name
+			}
+
+			if tracked[path] {
+				continue
+			}
+
+			// symbolic links to folders are files to git
+			isDir := entry.IsDir()
+
+			if ignored(patterns, path, isDir) {
+				continue
+			}
+
+			if !isDir {
+				if !add(path) {
+					return false
+				}
+
+				continue
+			}
+
+			if folders[path] {
+				if !walk(path, patterns, add) {
+					return false
+				}
+
+				continue
+			}
+
+			nested := r.isNestedRepository(path)
+
+			if mode == UntrackedAll && !nested {
+				if !walk(path, patterns, add) {
+					return false
+				}
+
+				continue
+			}
+
+			if nested || r.hasUntracked(path, patterns, walk) {
+				if !add(path + "/") {
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+
+	walk("", r.excludes(), func(path string) bool {
+		untracked = append(untracked, path)
+		return true
+	})
+
+	return untracked
+}
+
+func (r *Repository) hasUntracked(folder string, patterns []*ignorePattern, walk func(string, []*ignorePattern, func(string) bool) bool) bool {
+	found := false
+
+	walk(folder, patterns, func(string) bool {
+		found = true
+		return false
+	})
+
+	return found
+}
+
+func (r *Repository) isNestedRepository(folder string) bool {
+	_, err := os.Stat(filepath.Join(r.workTree, filepath.FromSlash(folder), ".git"))
+	return err == nil
+}
@@ -0,0 +1,256 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatus(t *testing.T) {
+	cases := []struct {
+		Setup          func(f *fixture)
+		Case           string
+		UntrackedMode  string
+		ExpectedChange []*Change
+	}{
+		{
+			Case:  "Clean",
+			Setup: func(_ *fixture) {},
+		},
+		{
+			Case: "Modified in work tree",
+			Setup: func(f *fixture) {
+				f.write("README.md", "# changed")
+			},
+			ExpectedChange: []*Change{{Path: "README.md", Staging: '.', Working: 'M'}},
+		},
+		{
+			Case: "Modified with the same size",
+			Setup: func(f *fixture) {
+				f.write("README.md", "# Posh")
+			},
+			ExpectedChange: []*Change{{Path: "README.md", Staging: '.', Working: 'M'}},
+		},
+		{
+			Case: "Staged and modified",
+			Setup: func(f *fixture) {
+				f.write("docs/guide.md", "# Guide\n\nstaged")
+				f.git("add", "docs/guide.md")
+				f.write("docs/guide.md", "# Guide\n\nmodified")
+			},
+			ExpectedChange: []*Change{{Path: "docs/guide.md", Staging: 'M', Working: 'M'}},
+		},
+		{
+			Case: "Added and deleted",
+			Setup: func(f *fixture) {
+				f.write("src/new.go", "package main\n")
+				f.git("add", "src/new.go")
+				f.git("rm", "--quiet", "docs/guide.md")
+				f.remove("README.md")
+			},
+			ExpectedChange: []*Change{
+				{Path: "README.md", Staging: '.', Working: 'D'},
+				{Path: "docs/guide.md", Staging: 'D', Working: '.'},
+				{Path: "src/new.go", Staging: 'A', Working: '.'},
+			},
+		},
+		{
+			Case: "Executable bit",
+			Setup: func(f *fixture) {
+				require.NoError(f.t, os.Chmod(filepath.Join(f.dir, "run.sh"), 0o755))
+			},
+			ExpectedChange: []*Change{{Path: "run.sh", Staging: '.', Working: 'M'}},
+		},
+		{
+			Case: "Type change",
+			Setup: func(f *fixture) {
+				f.remove("README.md")
+				require.NoError(f.t, os.Symlink("run.sh", filepath.Join(f.dir, "README.md")))
+			},
+			ExpectedChange: []*Change{{Path: "README.md", Staging: '.', Working: 'T'}},
+		},
+		{
+			Case: "Intent to add",
+			Setup: func(f *fixture) {
+				f.write("later.txt", "later")
+				f.git("add", "--intent-to-add", "later.txt")
+			},
+			ExpectedChange: []*Change{{Path: "later.txt", Staging: '.', Working: 'A'}},
+		},
+		{
+			Case:          "Untracked, normal mode",
+			UntrackedMode: UntrackedNormal,
+			Setup: func(f *fixture) {
+				f.write("notes.txt", "notes")
+				f.write("docs/draft.md", "draft")
+				f.write("vendor/lib/lib.go", "package lib")
+				f.write("build/output.bin", "ignored")
+				f.write("logs/today.log", "ignored")
+				f.write("logs/keep.log", "negated")
+				f.write("empty/.gitkeep.log", "ignored")
+			},
+		},
+		{
+			Case:          "Untracked, all mode",
+			UntrackedMode: UntrackedAll,
+			Setup: func(f *fixture) {
+				f.write("notes.txt", "notes")
+				f.write("vendor/lib/lib.go", "package lib")
+				f.write("vendor/lib/lib_test.go", "package lib")
+				f.write("build/output.bin", "ignored")
+			},
+		},
+		{
+			Case:          "Untracked, no mode",
+			UntrackedMode: UntrackedNo,
+			Setup: func(f *fixture) {
+				f.write("notes.txt", "notes")
+			},
+		},
+		{
+			Case: "Packed objects",
+			Setup: func(f *fixture) {
+				f.git("gc", "--quiet", "--aggressive")
+				f.write("docs/guide.md", "# Guide\n\nchanged")
+			},
+			ExpectedChange: []*Change{{Path: "docs/guide.md", Staging: '.', Working: 'M'}},
+		},
+		{
+			Case: "Merge conflict",
+			Setup: func(f *fixture) {
+				f.git("checkout", "--quiet", "-b", "feature")
+				f.write("README.md", "# Feature")
+				f.commit("feature")
+				f.git("checkout", "--quiet", "main")
+				f.write("README.md", "# Main")
+				f.commit("main")
+				f.mergeWithConflicts("feature")
+			},
+			ExpectedChange: []*Change{{Path: "README.md", Staging: 'U', Working: 'U'}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			f := newFixture(t)
+			f.write("README.md", "# posh")
+			f.write("run.sh", "echo posh")
+			f.write("docs/guide.md", "# Guide")
+			f.write(".gitignore", "build/\n*.log\n!logs/keep.log\n")
+			f.commit("initial commit")
+
+			tc.Setup(f)
+
+			mode := tc.UntrackedMode
+			if len(mode) == 0 {
+				mode = UntrackedNormal
+			}
+
+			repo := f.open()
+			_, head, err := repo.Head()
+			require.NoError(t, err)
+
+			options := StatusOptions{UntrackedFiles: mode}
+
+			got, err := repo.Status(head, options)
+			require.NoError(t, err)
+
+			expected := f.cliStatus(options)
+
+			assert.Equal(t, tc.ExpectedChange, got.Changes, "expected changes")
+			assert.Equal(t, expected.Changes, got.Changes, "git status changes")
+			assert.ElementsMatch(t, expected.Untracked, got.Untracked, "git status untracked")
+		})
+	}
+}
+
+func TestStatusUnbornBranch(t *testing.T) {
+	f := newFixture(t)
+	f.write("README.md", "# posh")
+	f.git("add", "README.md")
+	f.write("notes.txt", "notes")
+
+	repo := f.open()
+	branch, head, err := repo.Head()
+	require.NoError(t, err)
+	assert.Equal(t, "main", branch)
+	assert.True(t, head.IsZero())
+
+	got, err := repo.Status(head, StatusOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []*Change{{Path: "README.md", Staging: 'A', Working: '.'}}, got.Changes)
+	assert.Equal(t, []string{"notes.txt"}, got.Untracked)
+}
+
+func TestStatusSubmodule(t *testing.T) {
+	cases := []struct {
+		Setup            func(sub *fixture)
+		Case             string
+		IgnoreSubmodules string
+		Expected         []*Change
+	}{
+		{Case: "Clean", Setup: func(_ *fixture) {}},
+		{
+			Case:     "Modified content",
+			Setup:    func(sub *fixture) { sub.write("lib.go", "package lib\n\nconst Name = \"posh\"") },
+			Expected: []*Change{{Path: "lib", Staging: '.', Working: 'M'}},
+		},
+		{
+			Case:             "Modified content, dirty ignored",
+			IgnoreSubmodules: SubmodulesDirty,
+			Setup:            func(sub *fixture) { sub.write("lib.go", "package lib\n\nconst Name = \"posh\"") },
+		},
+		{
+			Case:     "Untracked content",
+			Setup:    func(sub *fixture) { sub.write("notes.txt", "notes") },
+			Expected: []*Change{{Path: "lib", Staging: '.', Working: 'M'}},
+		},
+		{
+			Case:             "Untracked content, untracked ignored",
+			IgnoreSubmodules: SubmodulesUntracked,
+			Setup:            func(sub *fixture) { sub.write("notes.txt", "notes") },
+		},
+		{
+			Case:             "New commit",
+			IgnoreSubmodules: SubmodulesDirty,
+			Setup:            func(sub *fixture) { sub.commit("new commit") },
+			Expected:         []*Change{{Path: "lib", Staging: '.', Working: 'M'}},
+		},
+		{
+			Case:             "New commit, all ignored",
+			IgnoreSubmodules: SubmodulesAll,
+			Setup:            func(sub *fixture) { sub.commit("new commit") },
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Case, func(t *testing.T) {
+			lib := newFixture(t)
+			lib.write("lib.go", "package lib")
+			lib.commit("initial commit")
+
+			f := newFixture(t)
+			f.write("README.md", "# posh")
+			f.git("-c", "protocol.file.allow=always", "submodule", "--quiet", "add", lib.dir, "lib")
+			f.commit("add submodule")
+
+			tc.Setup(&fixture{t: t, dir: filepath.Join(f.dir, "lib")})
+
+			repo := f.open()
+			_, head, err := repo.Head()
+			require.NoError(t, err)
+
+			options := StatusOptions{UntrackedFiles: UntrackedNormal, IgnoreSubmodules: tc.IgnoreSubmodules}
+
+			got, err := repo.Status(head, options)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.Expected, got.Changes, "expected changes")
+			assert.Equal(t, f.cliStatus(options).Changes, got.Changes, "git status changes")
+		})
+	}
+}
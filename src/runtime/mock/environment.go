@@ -62,6 +62,11 @@ func (env *Environment) LsDir(input string) []fs.DirEntry {
 	return args.Get(0).([]fs.DirEntry)
 }
 
+func (env *Environment) StatFiles(pattern string) []*runtime.FileStat {
+	args := env.Called(pattern)
+	return args.Get(0).([]*runtime.FileStat)
+}
+
 func (env *Environment) User() string {
 	args := env.Called()
 	return args.String(0)
@@ -97,6 +102,11 @@ func (env *Environment) RunCommand(command string, args ...string) (string, erro
 	return arguments.String(0), arguments.Error(1)
 }
 
+func (env *Environment) RunCommandWithInput(input string, timeout int, command string, args ...string) (string, error) {
+	arguments := env.Called(input, timeout, command, args)
+	return arguments.String(0), arguments.Error(1)
+}
+
 func (env *Environment) RunShellCommand(shell, command string) string {
 	args := env.Called(shell, command)
 	return args.String(0)
@@ -177,6 +187,11 @@ func (env *Environment) TerminalWidth() (int, error) {
 	return args.Int(0), args.Error(1)
 }
 
+func (env *Environment) TerminalBackground() string {
+	args := env.Called()
+	return args.String(0)
+}
+
 func (env *Environment) CachePath() string {
 	args := env.Called()
 	return args.String(0)
@@ -277,6 +292,11 @@ func (env *Environment) SystemInfo() (*runtime.SystemInfo, error) {
 	return args.Get(0).(*runtime.SystemInfo), args.Error(1)
 }
 
+func (env *Environment) Now() time.Time {
+	args := env.Called()
+	return args.Get(0).(time.Time)
+}
+
 func (env *Environment) Unset(name string) {
 	for i := 0; i < len(env.ExpectedCalls); i++ {
 		f := env.ExpectedCalls[i]
@@ -0,0 +1,281 @@
+package runtime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"golang.org/x/sys/unix"
+)
+
+// hardware types as found in include/uapi/linux/if_arp.h
+const (
+	arphrdEther = 1
+	arphrdPPP   = 512
+	arphrdRawIP = 519
+)
+
+const (
+	// SIOCGIWESSID gets the ESSID of a wireless interface, see include/uapi/linux/wireless.h
+	siocgiwessid    = 0x8B1B
+	iwEssidMaxSize  = 32
+	countersKeyBase = "network_counters_"
+)
+
+type sysfs struct {
+	// counters stores the previous transmit and receive counters to calculate the rates
+	counters cache.Cache
+	now      func() time.Time
+	ssid     func(name string) string
+	root     string
+}
+
+func (term *Terminal) getConnections() []*Connection {
+	fs := &sysfs{
+		root:     "/",
+		counters: term.Session(),
+		now:      time.Now,
+		ssid:     wirelessSSID,
+	}
+
+	return fs.connections()
+}
+
+func (fs *sysfs) path(elem ...string) string {
+	return filepath.Join(append([]string{fs.root}, elem...)...)
+}
+
+func (fs *sysfs) read(elem ...string) string {
+	content, err := os.ReadFile(fs.path(elem...))
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(content))
+}
+
+func (fs *sysfs) exists(elem ...string) bool {
+	_, err := os.Stat(fs.path(elem...))
+	return err == nil
+}
+
+func (fs *sysfs) connections() []*Connection {
+	entries, err := os.ReadDir(fs.path("sys", "class", "net"))
+	if err != nil {
+		log.Error(err)
+		return nil
+	}
+
+	wireless := fs.wirelessInterfaces()
+	networks := make([]*Connection, 0)
+
+	for _, entry := range entries {
+		name := entry.Name()
+
+		if !fs.isUp(name) {
+			continue
+		}
+
+		connectionType := fs.connectionType(name, wireless)
+
+		// skip connections which aren't relevant
+		if len(connectionType) == 0 {
+			continue
+		}
+
+		log.Debugf("Found network interface: %s", name)
+
+		network := &Connection{
+			Type: connectionType,
+			Name: name,
+		}
+
+		network.TransmitRate, network.ReceiveRate = fs.rates(name)
+
+		if connectionType == WIFI && fs.ssid != nil {
+			if ssid := fs.ssid(name); len(ssid) != 0 {
+				network.SSID = ssid
+				network.Name = ssid
+				log.Debugf("Found wifi interface: %s", ssid)
+			}
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks
+}
+
+func (fs *sysfs) isUp(name string) bool {
+	switch fs.read("sys", "class", "net", name, "operstate") {
+	case "up":
+		return true
+	case "unknown":
+		// point-to-point interfaces (ppp, wwan) don't report their state
+		return fs.read("sys", "class", "net", name, "carrier") == "1"
+	default:
+		return false
+	}
+}
+
+func (fs *sysfs) connectionType(name string, wireless map[string]bool) ConnectionType {
+	var devType string
+
+	for _, line := range strings.Split(fs.read("sys", "class", "net", name, "uevent"), "\n") {
+		if value, ok := strings.CutPrefix(line, "DEVTYPE="); ok {
+			devType = value
+			break
+		}
+	}
+
+	switch devType {
+	case "wlan":
+		return WIFI
+	case "wwan":
+		return CELLULAR
+	case "bluetooth":
+		return BLUETOOTH
+	}
+
+	if wireless[name] || fs.exists("sys", "class", "net", name, "wireless") || fs.exists("sys", "class", "net", name, "phy80211") {
+		return WIFI
+	}
+
+	// Bluetooth PAN
+	if strings.HasPrefix(name, "bnep") {
+		return BLUETOOTH
+	}
+
+	hardwareType, _ := strconv.Atoi(fs.read("sys", "class", "net", name, "type"))
+
+	switch hardwareType {
+	case arphrdPPP, arphrdRawIP:
+		return CELLULAR
+	case arphrdEther:
+		// rule out software interfaces (bridges, veth, docker, ...) which have no device
+		if len(devType) != 0 || !fs.exists("sys", "class", "net", name, "device") {
+			return ""
+		}
+
+		return ETHERNET
+	default:
+		return ""
+	}
+}
+
+// wirelessInterfaces lists the interfaces found in /proc/net/wireless
+func (fs *sysfs) wirelessInterfaces() map[string]bool {
+	interfaces := make(map[string]bool)
+
+	lines := strings.Split(fs.read("proc", "net", "wireless"), "\n")
+
+	// the first two lines are the header
+	if len(lines) < 3 {
+		return interfaces
+	}
+
+	for _, line := range lines[2:] {
+		name, _, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+
+		interfaces[strings.TrimSpace(name)] = true
+	}
+
+	return interfaces
+}
+
+// rates calculates the transmit and receive rates in bits per second
+// based on the counters stored during the previous prompt
+func (fs *sysfs) rates(name string) (transmit, receive uint64) {
+	tx, txErr := strconv.ParseUint(fs.read("sys", "class", "net", name, "statistics", "tx_bytes"), 10, 64)
+	rx, rxErr := strconv.ParseUint(fs.read("sys", "class", "net", name, "statistics", "rx_bytes"), 10, 64)
+	if txErr != nil || rxErr != nil || fs.counters == nil {
+		return 0, 0
+	}
+
+	now := fs.now()
+	key := countersKeyBase + name
+
+	defer func() {
+		fs.counters.Set(key, fmt.Sprintf("%d %d %d", tx, rx, now.UnixNano()), cache.ONEDAY)
+	}()
+
+	value, found := fs.counters.Get(key)
+	if !found {
+		return 0, 0
+	}
+
+	var previousTx, previousRx uint64
+	var previousTime int64
+	if _, err := fmt.Sscanf(value, "%d %d %d", &previousTx, &previousRx, &previousTime); err != nil {
+		return 0, 0
+	}
+
+	elapsed := now.Sub(time.Unix(0, previousTime)).Seconds()
+	if elapsed <= 0 {
+		return 0, 0
+	}
+
+	rate := func(current, previous uint64) uint64 {
+		// the counters were reset
+		if current < previous {
+			return 0
+		}
+
+		return uint64(float64(current-previous) * 8 / elapsed)
+	}
+
+	return rate(tx, previousTx), rate(rx, previousRx)
+}
+
+type iwreq struct {
+	name    [unix.IFNAMSIZ]byte
+	pointer uintptr
+	length  uint16
+	flags   uint16
+	_       [8]byte
+}
+
+// wirelessSSID queries the SSID using the wireless extensions
+func wirelessSSID(name string) string {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM, 0)
+	if err != nil {
+		log.Error(err)
+		return ""
+	}
+
+	defer unix.Close(fd)
+
+	var essid [iwEssidMaxSize + 1]byte
+
+	request := iwreq{
+		pointer: uintptr(unsafe.Pointer(&essid[0])),
+		length:  uint16(len(essid)),
+	}
+
+	copy(request.name[:], name)
+
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), siocgiwessid, uintptr(unsafe.Pointer(&request)))
+	runtime.KeepAlive(&essid)
+
+	if errno != 0 {
+		log.Error(errno)
+		return ""
+	}
+
+	length := int(request.length)
+	if length > iwEssidMaxSize {
+		length = iwEssidMaxSize
+	}
+
+	return strings.TrimRight(string(essid[:length]), "\x00")
+}
@@ -0,0 +1,162 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeInterface struct {
+	files  map[string]string
+	name   string
+	device bool
+}
+
+func newFakeSysfs(t *testing.T, interfaces []*fakeInterface, wireless string) string {
+	root := t.TempDir()
+
+	write := func(path, content string) {
+		path = filepath.Join(root, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content+"\n"), 0o644))
+	}
+
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "sys", "class", "net"), 0o755))
+
+	for _, networkInterface := range interfaces {
+		base := filepath.Join("sys", "class", "net", networkInterface.name)
+
+		for file, content := range networkInterface.files {
+			write(filepath.Join(base, file), content)
+		}
+
+		if networkInterface.device {
+			require.NoError(t, os.MkdirAll(filepath.Join(root, base, "device"), 0o755))
+		}
+	}
+
+	if len(wireless) != 0 {
+		write(filepath.Join("proc", "net", "wireless"), wireless)
+	}
+
+	return root
+}
+
+func TestSysfsConnections(t *testing.T) {
+	wireless := `Inter-| sta-|   Quality        |   Discarded packets               | Missed | WE
+ face | tus | link level noise |  nwid  crypt   frag  retry   misc | beacon | 22
+wlp2s0: 0000   58.  -52.  -256        0      0      0      0     25        0`
+
+	interfaces := []*fakeInterface{
+		{name: "lo", files: map[string]string{"operstate": "unknown", "type": "772"}},
+		{name: "enp3s0", device: true, files: map[string]string{"operstate": "up", "type": "1", "statistics/tx_bytes": "1000", "statistics/rx_bytes": "5000"}},
+		{name: "enp4s0", device: true, files: map[string]string{"operstate": "down", "type": "1"}},
+		{name: "docker0", files: map[string]string{"operstate": "up", "type": "1", "uevent": "DEVTYPE=bridge\nINTERFACE=docker0"}},
+		{name: "veth1234", files: map[string]string{"operstate": "up", "type": "1"}},
+		{name: "wlp2s0", device: true, files: map[string]string{"operstate": "up", "type": "1"}},
+		{name: "wwan0", files: map[string]string{"operstate": "unknown", "carrier": "1", "type": "519"}},
+		{name: "ppp0", files: map[string]string{"operstate": "unknown", "carrier": "0", "type": "512"}},
+		{name: "bnep0", files: map[string]string{"operstate": "up", "type": "1", "uevent": "DEVTYPE=bluetooth"}},
+	}
+
+	fs := &sysfs{
+		root: newFakeSysfs(t, interfaces, wireless),
+		ssid: func(name string) string {
+			if name == "wlp2s0" {
+				return "oh-my-posh"
+			}
+			return ""
+		},
+	}
+
+	expected := []*Connection{
+		{Name: "bnep0", Type: BLUETOOTH},
+		{Name: "enp3s0", Type: ETHERNET},
+		{Name: "oh-my-posh", Type: WIFI, SSID: "oh-my-posh"},
+		{Name: "wwan0", Type: CELLULAR},
+	}
+
+	assert.Equal(t, expected, fs.connections())
+}
+
+func TestSysfsConnectionType(t *testing.T) {
+	cases := []struct {
+		Case      string
+		Interface *fakeInterface
+		Wireless  bool
+		Expected  ConnectionType
+	}{
+		{Case: "ethernet", Interface: &fakeInterface{device: true, files: map[string]string{"type": "1"}}, Expected: ETHERNET},
+		{Case: "virtual ethernet", Interface: &fakeInterface{files: map[string]string{"type": "1"}}},
+		{Case: "vlan", Interface: &fakeInterface{device: true, files: map[string]string{"type": "1", "uevent": "DEVTYPE=vlan"}}},
+		{Case: "wifi devtype", Interface: &fakeInterface{device: true, files: map[string]string{"type": "1", "uevent": "DEVTYPE=wlan"}}, Expected: WIFI},
+		{Case: "wifi phy", Interface: &fakeInterface{device: true, files: map[string]string{"type": "1", "phy80211/name": "phy0"}}, Expected: WIFI},
+		{Case: "wifi proc", Interface: &fakeInterface{device: true, files: map[string]string{"type": "1"}}, Wireless: true, Expected: WIFI},
+		{Case: "wwan devtype", Interface: &fakeInterface{device: true, files: map[string]string{"type": "1", "uevent": "DEVTYPE=wwan"}}, Expected: CELLULAR},
+		{Case: "raw ip", Interface: &fakeInterface{files: map[string]string{"type": "519"}}, Expected: CELLULAR},
+		{Case: "ppp", Interface: &fakeInterface{files: map[string]string{"type": "512"}}, Expected: CELLULAR},
+		{Case: "loopback", Interface: &fakeInterface{files: map[string]string{"type": "772"}}},
+		{Case: "tunnel", Interface: &fakeInterface{files: map[string]string{"type": "65534"}}},
+	}
+
+	for _, tc := range cases {
+		tc.Interface.name = "eth0"
+		fs := &sysfs{root: newFakeSysfs(t, []*fakeInterface{tc.Interface}, "")}
+		wireless := map[string]bool{"eth0": tc.Wireless}
+		assert.Equal(t, tc.Expected, fs.connectionType("eth0", wireless), tc.Case)
+	}
+}
+
+func TestSysfsRates(t *testing.T) {
+	networkInterface := &fakeInterface{
+		name:   "eth0",
+		device: true,
+		files: map[string]string{
+			"statistics/tx_bytes": "1000",
+			"statistics/rx_bytes": "2000",
+		},
+	}
+
+	root := newFakeSysfs(t, []*fakeInterface{networkInterface}, "")
+
+	counters := &cache.Store{}
+	counters.Init(filepath.Join(t.TempDir(), "cache"), false)
+
+	now := time.Unix(1000, 0)
+	fs := &sysfs{
+		root:     root,
+		counters: counters,
+		now:      func() time.Time { return now },
+	}
+
+	// no previous sample available
+	transmit, receive := fs.rates("eth0")
+	assert.Zero(t, transmit)
+	assert.Zero(t, receive)
+
+	write := func(file, content string) {
+		path := filepath.Join(root, "sys", "class", "net", "eth0", "statistics", file)
+		require.NoError(t, os.WriteFile(path, []byte(content+"\n"), 0o644))
+	}
+
+	write("tx_bytes", "3000")
+	write("rx_bytes", "12000")
+	now = now.Add(2 * time.Second)
+
+	transmit, receive = fs.rates("eth0")
+	assert.Equal(t, uint64(8000), transmit)
+	assert.Equal(t, uint64(40000), receive)
+
+	// the counters were reset
+	write("tx_bytes", "10")
+	now = now.Add(time.Second)
+
+	transmit, _ = fs.rates("eth0")
+	assert.Zero(t, transmit)
+}
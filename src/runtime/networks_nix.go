@@ -0,0 +1,7 @@
+//go:build !windows && !linux
+
+package runtime
+
+func (term *Terminal) getConnections() []*Connection {
+	return nil
+}
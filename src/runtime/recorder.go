@@ -0,0 +1,644 @@
+package runtime
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	httplib "net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/battery"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/http"
+)
+
+const (
+	// Redacted replaces secrets in a recording
+	Redacted = "REDACTED"
+
+	// secretPattern matches the names of environment variables, HTTP headers, query parameters,
+	// JSON fields and cache keys which contain a secret
+	secretPattern = `(?i)(token|secret|passw|api_?key|credential|cookie|authorization|private_?key|access_?key|signature)`
+)
+
+var jsonStringField = regexp.MustCompile(`"([^"\\]*)"(\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// Recording contains every call made to the environment while rendering,
+// it can be replayed to render the same prompt on another machine.
+type Recording struct {
+	Flags  *Flags   `json:"flags"`
+	Redact []string `json:"redact,omitempty"`
+	Calls  []*Call  `json:"calls"`
+}
+
+type Call struct {
+	Headers  map[string]string `json:"headers,omitempty"`
+	Method   string            `json:"method"`
+	Error    string            `json:"error,omitempty"`
+	Result   json.RawMessage   `json:"result,omitempty"`
+	Args     []string          `json:"args,omitempty"`
+	ExitCode int               `json:"exit_code,omitempty"`
+	Duration float64           `json:"duration"`
+}
+
+type dirEntry struct {
+	EntryName string `json:"name"`
+	Directory bool   `json:"is_dir"`
+}
+
+type statusCodes struct {
+	PipeStatus string `json:"pipe_status"`
+	Code       int    `json:"code"`
+}
+
+type cacheEntry struct {
+	Value string `json:"value"`
+	Found bool   `json:"found"`
+}
+
+// content is recorded as a JSON string, unless it's binary (not valid UTF-8),
+// which is stored base64 encoded to keep the exact bytes
+type content string
+
+type binaryContent struct {
+	Base64 string `json:"base64"`
+}
+
+func (c content) MarshalJSON() ([]byte, error) {
+	if utf8.ValidString(string(c)) {
+		return json.Marshal(string(c))
+	}
+
+	return json.Marshal(&binaryContent{Base64: base64.StdEncoding.EncodeToString([]byte(c))})
+}
+
+func (c *content) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*c = content(text)
+		return nil
+	}
+
+	var binary binaryContent
+	if err := json.Unmarshal(data, &binary); err != nil {
+		return err
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(binary.Base64)
+	if err != nil {
+		return err
+	}
+
+	*c = content(decoded)
+
+	return nil
+}
+
+// redactor removes secrets based on the name of the value,
+// the custom patterns are also removed from command and HTTP output.
+type redactor struct {
+	patterns []string
+	secrets  []*regexp.Regexp
+	custom   []*regexp.Regexp
+}
+
+func newRedactor(patterns []string) (*redactor, error) {
+	r := &redactor{
+		patterns: patterns,
+		secrets:  []*regexp.Regexp{regexp.MustCompile(secretPattern)},
+	}
+
+	for _, pattern := range patterns {
+		secret, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %s: %w", pattern, err)
+		}
+
+		r.secrets = append(r.secrets, secret)
+		r.custom = append(r.custom, secret)
+	}
+
+	return r, nil
+}
+
+func (r *redactor) isSecret(name string) bool {
+	for _, secret := range r.secrets {
+		if secret.MatchString(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// url redacts the values of query parameters which contain a secret.
+func (r *redactor) url(input string) string {
+	target, err := url.Parse(input)
+	if err != nil || len(target.RawQuery) == 0 {
+		return input
+	}
+
+	query := target.Query()
+	for key := range query {
+		if r.isSecret(key) {
+			query.Set(key, Redacted)
+		}
+	}
+
+	target.RawQuery = query.Encode()
+
+	return target.String()
+}
+
+// json redacts the string values of JSON fields which contain a secret.
+func (r *redactor) json(body []byte) []byte {
+	return jsonStringField.ReplaceAllFunc(body, func(match []byte) []byte {
+		groups := jsonStringField.FindSubmatch(match)
+		if !r.isSecret(string(groups[1])) {
+			return match
+		}
+
+		return []byte(fmt.Sprintf(`"%s"%s"%s"`, groups[1], groups[2], Redacted))
+	})
+}
+
+// output redacts JSON fields which contain a secret and the matches of the custom patterns.
+func (r *redactor) output(output string) string {
+	output = string(r.json([]byte(output)))
+
+	for _, secret := range r.custom {
+		output = secret.ReplaceAllString(output, Redacted)
+	}
+
+	return output
+}
+
+// Recorder is an Environment which records every call to the wrapped Environment.
+type Recorder struct {
+	env          Environment
+	deviceCache  *recordedCache
+	sessionCache *recordedCache
+	redactor     *redactor
+	calls        []*Call
+	mutex        sync.Mutex
+}
+
+// NewRecorder wraps the environment, values of which the name matches one of the
+// given patterns (on top of the default ones) are redacted from the recording.
+func NewRecorder(env Environment, patterns []string) (*Recorder, error) {
+	redactor, err := newRedactor(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Recorder{
+		env:      env,
+		redactor: redactor,
+	}
+
+	r.deviceCache = &recordedCache{Cache: env.Cache(), recorder: r, method: "Cache"}
+	r.sessionCache = &recordedCache{Cache: env.Session(), recorder: r, method: "Session"}
+
+	return r, nil
+}
+
+// Save writes the recording to the given file.
+func (r *Recorder) Save(file string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	recording := &Recording{
+		Flags:  r.env.Flags(),
+		Redact: r.redactor.patterns,
+		Calls:  r.calls,
+	}
+
+	content, err := json.MarshalIndent(recording, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, content, 0o644)
+}
+
+func (r *Recorder) record(start time.Time, method string, args []string, result any, err error) {
+	r.add(newCall(start, method, args, result, err))
+}
+
+func (r *Recorder) add(call *Call) {
+	r.mutex.Lock()
+	r.calls = append(r.calls, call)
+	r.mutex.Unlock()
+}
+
+func newCall(start time.Time, method string, args []string, result any, err error) *Call {
+	call := &Call{
+		Method:   method,
+		Args:     args,
+		Duration: float64(time.Since(start).Microseconds()) / 1000,
+	}
+
+	if content, marshalErr := json.Marshal(result); marshalErr == nil {
+		call.Result = content
+	}
+
+	if err != nil {
+		call.Error = err.Error()
+	}
+
+	if commandError, ok := err.(*CommandError); ok {
+		call.ExitCode = commandError.ExitCode
+	}
+
+	return call
+}
+
+func (r *Recorder) Getenv(key string) string {
+	start := time.Now()
+	value := r.env.Getenv(key)
+
+	recorded := value
+	if len(recorded) != 0 && r.redactor.isSecret(key) {
+		recorded = Redacted
+	}
+
+	r.record(start, "Getenv", []string{key}, recorded, nil)
+	return value
+}
+
+func (r *Recorder) Pwd() string {
+	start := time.Now()
+	pwd := r.env.Pwd()
+	r.record(start, "Pwd", nil, pwd, nil)
+	return pwd
+}
+
+func (r *Recorder) Home() string {
+	start := time.Now()
+	home := r.env.Home()
+	r.record(start, "Home", nil, home, nil)
+	return home
+}
+
+func (r *Recorder) User() string {
+	start := time.Now()
+	user := r.env.User()
+	r.record(start, "User", nil, user, nil)
+	return user
+}
+
+func (r *Recorder) Root() bool {
+	start := time.Now()
+	root := r.env.Root()
+	r.record(start, "Root", nil, root, nil)
+	return root
+}
+
+func (r *Recorder) Host() (string, error) {
+	start := time.Now()
+	host, err := r.env.Host()
+	r.record(start, "Host", nil, host, err)
+	return host, err
+}
+
+func (r *Recorder) GOOS() string {
+	start := time.Now()
+	goos := r.env.GOOS()
+	r.record(start, "GOOS", nil, goos, nil)
+	return goos
+}
+
+func (r *Recorder) Shell() string {
+	start := time.Now()
+	shell := r.env.Shell()
+	r.record(start, "Shell", nil, shell, nil)
+	return shell
+}
+
+func (r *Recorder) Platform() string {
+	start := time.Now()
+	platform := r.env.Platform()
+	r.record(start, "Platform", nil, platform, nil)
+	return platform
+}
+
+func (r *Recorder) StatusCodes() (int, string) {
+	start := time.Now()
+	code, pipeStatus := r.env.StatusCodes()
+	r.record(start, "StatusCodes", nil, &statusCodes{Code: code, PipeStatus: pipeStatus}, nil)
+	return code, pipeStatus
+}
+
+func (r *Recorder) HasFiles(pattern string) bool {
+	start := time.Now()
+	found := r.env.HasFiles(pattern)
+	r.record(start, "HasFiles", []string{pattern}, found, nil)
+	return found
+}
+
+func (r *Recorder) HasFilesInDir(dir, pattern string) bool {
+	start := time.Now()
+	found := r.env.HasFilesInDir(dir, pattern)
+	r.record(start, "HasFilesInDir", []string{dir, pattern}, found, nil)
+	return found
+}
+
+func (r *Recorder) HasFolder(folder string) bool {
+	start := time.Now()
+	found := r.env.HasFolder(folder)
+	r.record(start, "HasFolder", []string{folder}, found, nil)
+	return found
+}
+
+func (r *Recorder) HasParentFilePath(input string, followSymlinks bool) (*FileInfo, error) {
+	start := time.Now()
+	fileInfo, err := r.env.HasParentFilePath(input, followSymlinks)
+	r.record(start, "HasParentFilePath", []string{input, strconv.FormatBool(followSymlinks)}, fileInfo, err)
+	return fileInfo, err
+}
+
+func (r *Recorder) HasFileInParentDirs(pattern string, depth uint) bool {
+	start := time.Now()
+	found := r.env.HasFileInParentDirs(pattern, depth)
+	r.record(start, "HasFileInParentDirs", []string{pattern, strconv.FormatUint(uint64(depth), 10)}, found, nil)
+	return found
+}
+
+func (r *Recorder) ResolveSymlink(input string) (string, error) {
+	start := time.Now()
+	link, err := r.env.ResolveSymlink(input)
+	r.record(start, "ResolveSymlink", []string{input}, link, err)
+	return link, err
+}
+
+func (r *Recorder) DirMatchesOneOf(dir string, regexes []string) bool {
+	start := time.Now()
+	match := r.env.DirMatchesOneOf(dir, regexes)
+	r.record(start, "DirMatchesOneOf", append([]string{dir}, regexes...), match, nil)
+	return match
+}
+
+func (r *Recorder) DirIsWritable(input string) bool {
+	start := time.Now()
+	writable := r.env.DirIsWritable(input)
+	r.record(start, "DirIsWritable", []string{input}, writable, nil)
+	return writable
+}
+
+func (r *Recorder) CommandPath(command string) string {
+	start := time.Now()
+	commandPath := r.env.CommandPath(command)
+	r.record(start, "CommandPath", []string{command}, commandPath, nil)
+	return commandPath
+}
+
+func (r *Recorder) HasCommand(command string) bool {
+	start := time.Now()
+	found := r.env.HasCommand(command)
+	r.record(start, "HasCommand", []string{command}, found, nil)
+	return found
+}
+
+func (r *Recorder) FileContent(file string) string {
+	start := time.Now()
+	fileContent := r.env.FileContent(file)
+	r.record(start, "FileContent", []string{file}, content(fileContent), nil)
+	return fileContent
+}
+
+func (r *Recorder) LsDir(input string) []fs.DirEntry {
+	start := time.Now()
+	entries := r.env.LsDir(input)
+
+	recorded := make([]*dirEntry, 0, len(entries))
+	for _, entry := range entries {
+		recorded = append(recorded, &dirEntry{EntryName: entry.Name(), Directory: entry.IsDir()})
+	}
+
+	r.record(start, "LsDir", []string{input}, recorded, nil)
+	return entries
+}
+
+func (r *Recorder) StatFiles(pattern string) []*FileStat {
+	start := time.Now()
+	files := r.env.StatFiles(pattern)
+	r.record(start, "StatFiles", []string{pattern}, files, nil)
+	return files
+}
+
+func (r *Recorder) RunCommand(command string, args ...string) (string, error) {
+	start := time.Now()
+	output, err := r.env.RunCommand(command, args...)
+	r.record(start, "RunCommand", append([]string{command}, args...), r.redactor.output(output), err)
+	return output, err
+}
+
+func (r *Recorder) RunCommandWithInput(input string, timeout int, command string, args ...string) (string, error) {
+	start := time.Now()
+	output, err := r.env.RunCommandWithInput(input, timeout, command, args...)
+	r.record(start, "RunCommandWithInput", append([]string{command}, args...), r.redactor.output(output), err)
+	return output, err
+}
+
+func (r *Recorder) RunShellCommand(shell, command string) string {
+	start := time.Now()
+	output := r.env.RunShellCommand(shell, command)
+	r.record(start, "RunShellCommand", []string{shell, command}, r.redactor.output(output), nil)
+	return output
+}
+
+func (r *Recorder) ExecutionTime() float64 {
+	start := time.Now()
+	executionTime := r.env.ExecutionTime()
+	r.record(start, "ExecutionTime", nil, executionTime, nil)
+	return executionTime
+}
+
+func (r *Recorder) Flags() *Flags {
+	return r.env.Flags()
+}
+
+func (r *Recorder) BatteryState() (*battery.Info, error) {
+	start := time.Now()
+	info, err := r.env.BatteryState()
+	r.record(start, "BatteryState", nil, info, err)
+	return info, err
+}
+
+func (r *Recorder) QueryWindowTitles(processName, windowTitleRegex string) (string, error) {
+	start := time.Now()
+	title, err := r.env.QueryWindowTitles(processName, windowTitleRegex)
+	r.record(start, "QueryWindowTitles", []string{processName, windowTitleRegex}, title, err)
+	return title, err
+}
+
+func (r *Recorder) WindowsRegistryKeyValue(key string) (*WindowsRegistryValue, error) {
+	start := time.Now()
+	value, err := r.env.WindowsRegistryKeyValue(key)
+	r.record(start, "WindowsRegistryKeyValue", []string{key}, value, err)
+	return value, err
+}
+
+func (r *Recorder) HTTPRequest(targetURL string, body io.Reader, timeout int, requestModifiers ...http.RequestModifier) ([]byte, error) {
+	start := time.Now()
+	response, err := r.env.HTTPRequest(targetURL, body, timeout, requestModifiers...)
+
+	call := newCall(start, "HTTPRequest", []string{r.redactor.url(targetURL)}, content(r.redactor.output(string(response))), err)
+
+	// apply the modifiers to an empty request to find out which headers were sent
+	if request, requestErr := httplib.NewRequest(httplib.MethodGet, targetURL, nil); requestErr == nil {
+		for _, modifier := range requestModifiers {
+			modifier(request)
+		}
+
+		call.Headers = make(map[string]string, len(request.Header))
+		for key := range request.Header {
+			value := request.Header.Get(key)
+			if r.redactor.isSecret(key) {
+				value = Redacted
+			}
+
+			call.Headers[key] = value
+		}
+	}
+
+	r.add(call)
+
+	return response, err
+}
+
+func (r *Recorder) IsWsl() bool {
+	start := time.Now()
+	wsl := r.env.IsWsl()
+	r.record(start, "IsWsl", nil, wsl, nil)
+	return wsl
+}
+
+func (r *Recorder) IsWsl2() bool {
+	start := time.Now()
+	wsl2 := r.env.IsWsl2()
+	r.record(start, "IsWsl2", nil, wsl2, nil)
+	return wsl2
+}
+
+func (r *Recorder) IsCygwin() bool {
+	start := time.Now()
+	cygwin := r.env.IsCygwin()
+	r.record(start, "IsCygwin", nil, cygwin, nil)
+	return cygwin
+}
+
+func (r *Recorder) StackCount() int {
+	start := time.Now()
+	count := r.env.StackCount()
+	r.record(start, "StackCount", nil, count, nil)
+	return count
+}
+
+func (r *Recorder) TerminalWidth() (int, error) {
+	start := time.Now()
+	width, err := r.env.TerminalWidth()
+	r.record(start, "TerminalWidth", nil, width, err)
+	return width, err
+}
+
+func (r *Recorder) TerminalBackground() string {
+	start := time.Now()
+	background := r.env.TerminalBackground()
+	r.record(start, "TerminalBackground", nil, background, nil)
+	return background
+}
+
+func (r *Recorder) Cache() cache.Cache {
+	return r.deviceCache
+}
+
+func (r *Recorder) Session() cache.Cache {
+	return r.sessionCache
+}
+
+func (r *Recorder) Close() {
+	r.env.Close()
+}
+
+func (r *Recorder) Logs() string {
+	return r.env.Logs()
+}
+
+func (r *Recorder) InWSLSharedDrive() bool {
+	start := time.Now()
+	shared := r.env.InWSLSharedDrive()
+	r.record(start, "InWSLSharedDrive", nil, shared, nil)
+	return shared
+}
+
+func (r *Recorder) ConvertToLinuxPath(input string) string {
+	start := time.Now()
+	linuxPath := r.env.ConvertToLinuxPath(input)
+	r.record(start, "ConvertToLinuxPath", []string{input}, linuxPath, nil)
+	return linuxPath
+}
+
+func (r *Recorder) ConvertToWindowsPath(input string) string {
+	start := time.Now()
+	windowsPath := r.env.ConvertToWindowsPath(input)
+	r.record(start, "ConvertToWindowsPath", []string{input}, windowsPath, nil)
+	return windowsPath
+}
+
+func (r *Recorder) Connection(connectionType ConnectionType) (*Connection, error) {
+	start := time.Now()
+	connection, err := r.env.Connection(connectionType)
+	r.record(start, "Connection", []string{string(connectionType)}, connection, err)
+	return connection, err
+}
+
+func (r *Recorder) CursorPosition() (row, col int) {
+	start := time.Now()
+	row, col = r.env.CursorPosition()
+	r.record(start, "CursorPosition", nil, []int{row, col}, nil)
+	return row, col
+}
+
+func (r *Recorder) SystemInfo() (*SystemInfo, error) {
+	start := time.Now()
+	info, err := r.env.SystemInfo()
+	r.record(start, "SystemInfo", nil, info, err)
+	return info, err
+}
+
+func (r *Recorder) Now() time.Time {
+	start := time.Now()
+	now := r.env.Now()
+	r.record(start, "Now", nil, now, nil)
+	return now
+}
+
+// recordedCache records the lookups in the wrapped cache,
+// the values of keys containing a secret are redacted.
+type recordedCache struct {
+	cache.Cache
+	recorder *Recorder
+	method   string
+}
+
+func (c *recordedCache) Get(key string) (string, bool) {
+	start := time.Now()
+	value, found := c.Cache.Get(key)
+
+	recorded := value
+	if found && c.recorder.redactor.isSecret(key) {
+		recorded = Redacted
+	}
+
+	c.recorder.record(start, c.method, []string{key}, cacheEntry{Value: recorded, Found: found}, nil)
+	return value, found
+}
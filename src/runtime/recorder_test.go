@@ -0,0 +1,127 @@
+package runtime
+
+import (
+	httplib "net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/http"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	content := testScenario + `
+  https://example.com/login?user=posh&api_key=abc123: '{"access_token": "abc123", "expires_in": 3600}'
+`
+	content = strings.Replace(content, "  AWS_PROFILE: dev\n", "  AWS_PROFILE: dev\n  GITHUB_TOKEN: abc123\n  COMPANY_ID: acme-corp\n", 1)
+	content = strings.Replace(content, "commands:\n", "commands:\n  az account show: '{\"name\": \"posh\", \"password\": \"abc123\", \"state\": \"Enabled\"} tenant: Company Inc'\n", 1)
+
+	scenario := newTestScenario(t, content, &Flags{Shell: "zsh", TerminalWidth: 80})
+
+	// terminfo files are binary
+	binary := string([]byte{0x1a, 0x01, 0xff, 0xfe, 0x00, 0x80})
+	scenario.fileSystem["usr/share/terminfo/x/xterm"] = &fstest.MapFile{Data: []byte(binary)}
+	scenario.Session().Set("strava_access_token", "abc123", cache.ONEDAY)
+	scenario.Cache().Set("segment", "cached", cache.ONEDAY)
+
+	recorder, err := NewRecorder(scenario, []string{"(?i)company"})
+	require.NoError(t, err)
+
+	var authorization http.RequestModifier = func(request *httplib.Request) {
+		request.Header.Set("Authorization", "Bearer abc123")
+		request.Header.Set("Accept", "application/json")
+	}
+
+	render := func(env Environment) []any {
+		output, commandErr := env.RunCommand("git", "--version")
+		account, _ := env.RunCommand("az", "account", "show")
+		login, _ := env.HTTPRequest("https://example.com/login?user=posh&api_key=abc123", nil, 20, authorization)
+		token, _ := env.Session().Get("strava_access_token")
+		segment, _ := env.Cache().Get("segment")
+		code, _ := env.StatusCodes()
+		width, _ := env.TerminalWidth()
+
+		return []any{
+			env.Pwd(),
+			env.Getenv("AWS_PROFILE"),
+			env.Getenv("GITHUB_TOKEN"),
+			env.Getenv("COMPANY_ID"),
+			env.HasFiles("go.mod"),
+			env.FileContent("go.mod"),
+			len(env.LsDir(env.Pwd())),
+			output,
+			commandErr,
+			string(login),
+			token,
+			segment,
+			code,
+			width,
+			env.Now(),
+			account,
+			env.FileContent("/usr/share/terminfo/x/xterm"),
+		}
+	}
+
+	recorded := render(recorder)
+
+	file := filepath.Join(t.TempDir(), "recording.json")
+	require.NoError(t, recorder.Save(file))
+
+	recording, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.NotContains(t, string(recording), "abc123", "secrets are redacted")
+	assert.NotContains(t, string(recording), "acme-corp", "custom patterns are redacted")
+	assert.NotContains(t, string(recording), "Company Inc", "custom patterns are redacted from command output")
+
+	replay, err := LoadReplay(file)
+	require.NoError(t, err)
+
+	flags := &Flags{Type: PRIMARY}
+	replay.Init(flags)
+	assert.Equal(t, "zsh", flags.Shell)
+	assert.Equal(t, PRIMARY, flags.Type)
+
+	expected := recorded
+	expected[2] = Redacted
+	expected[3] = Redacted
+	expected[9] = `{"access_token": "REDACTED", "expires_in": 3600}`
+	expected[10] = Redacted
+	expected[15] = `{"name": "posh", "password": "REDACTED", "state": "Enabled"} tenant: REDACTED Inc`
+
+	assert.Equal(t, expected, render(replay))
+	assert.Equal(t, binary, expected[16], "binary content is replayed as is")
+	assert.Empty(t, replay.Missing())
+
+	// calls which weren't made while recording
+	assert.False(t, replay.HasFolder("/tmp"))
+	assert.Equal(t, []string{"HasFolder /tmp"}, replay.Missing())
+}
+
+func TestRecorderRedactURL(t *testing.T) {
+	cases := []struct {
+		Case     string
+		URL      string
+		Expected string
+	}{
+		{Case: "no query", URL: "https://example.com/api", Expected: "https://example.com/api"},
+		{Case: "no secret", URL: "https://example.com/api?user=posh", Expected: "https://example.com/api?user=posh"},
+		{Case: "secret", URL: "https://example.com/api?user=posh&access_token=abc", Expected: "https://example.com/api?access_token=REDACTED&user=posh"},
+		{Case: "api key", URL: "https://example.com/api?apiKey=abc", Expected: "https://example.com/api?apiKey=REDACTED"},
+	}
+
+	redactor, err := newRedactor(nil)
+	require.NoError(t, err)
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.Expected, redactor.url(tc.URL), tc.Case)
+	}
+
+	_, err = NewRecorder(&Scenario{}, []string{"("})
+	assert.Error(t, err)
+}
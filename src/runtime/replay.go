@@ -0,0 +1,481 @@
+package runtime
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/battery"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/http"
+)
+
+// Replay is an Environment which answers every call using a recording,
+// rendering the same prompt as the one on the machine it was recorded on.
+type Replay struct {
+	CmdFlags     *Flags
+	recorded     *Flags
+	redactor     *redactor
+	deviceCache  *replayedCache
+	sessionCache *replayedCache
+	calls        map[string][]*Call
+	used         map[string]int
+	missing      []string
+	mutex        sync.Mutex
+}
+
+// LoadReplay reads the recording at the given location.
+func LoadReplay(file string) (*Replay, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var recording Recording
+	if err := json.Unmarshal(content, &recording); err != nil {
+		return nil, fmt.Errorf("invalid recording %s: %w", file, err)
+	}
+
+	redactor, err := newRedactor(recording.Redact)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Replay{
+		recorded: recording.Flags,
+		redactor: redactor,
+		calls:    make(map[string][]*Call),
+		used:     make(map[string]int),
+	}
+
+	for _, call := range recording.Calls {
+		key := callKey(call.Method, call.Args)
+		r.calls[key] = append(r.calls[key], call)
+	}
+
+	return r, nil
+}
+
+// Init merges the recorded flags describing the context of the prompt,
+// like the working directory and exit code, with the given flags.
+func (r *Replay) Init(flags *Flags) {
+	defer log.Trace(time.Now())
+
+	r.CmdFlags = flags
+	if r.CmdFlags == nil {
+		r.CmdFlags = &Flags{}
+	}
+
+	replayCache := func(method string) *replayedCache {
+		store := &cache.Store{}
+		store.Init("", false)
+		return &replayedCache{Cache: store, replay: r, method: method}
+	}
+
+	r.deviceCache = replayCache("Cache")
+	r.sessionCache = replayCache("Session")
+
+	recorded := r.recorded
+	if recorded == nil {
+		return
+	}
+
+	set := func(value *string, recorded string) {
+		if len(recorded) != 0 {
+			*value = recorded
+		}
+	}
+
+	set(&r.CmdFlags.PWD, recorded.PWD)
+	set(&r.CmdFlags.PSWD, recorded.PSWD)
+	set(&r.CmdFlags.AbsolutePWD, recorded.AbsolutePWD)
+	set(&r.CmdFlags.Shell, recorded.Shell)
+	set(&r.CmdFlags.ShellVersion, recorded.ShellVersion)
+	set(&r.CmdFlags.PipeStatus, recorded.PipeStatus)
+
+	r.CmdFlags.ErrorCode = recorded.ErrorCode
+	r.CmdFlags.NoExitCode = recorded.NoExitCode
+	r.CmdFlags.ExecutionTime = recorded.ExecutionTime
+	r.CmdFlags.StackCount = recorded.StackCount
+	r.CmdFlags.TerminalWidth = recorded.TerminalWidth
+	r.CmdFlags.JobCount = recorded.JobCount
+	r.CmdFlags.Column = recorded.Column
+	r.CmdFlags.PromptCount = recorded.PromptCount
+	r.CmdFlags.Cleared = recorded.Cleared
+}
+
+// Missing returns the calls which were made while rendering, but are not in the recording.
+func (r *Replay) Missing() []string {
+	return r.missing
+}
+
+func callKey(method string, args []string) string {
+	return method + "\x00" + strings.Join(args, "\x00")
+}
+
+// next returns the recorded call, when a call was recorded multiple times
+// the results are returned in order, repeating the last one.
+func (r *Replay) next(method string, args ...string) (*Call, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := callKey(method, args)
+
+	calls := r.calls[key]
+	if len(calls) == 0 {
+		call := strings.TrimSpace(method + " " + strings.Join(args, " "))
+		log.Error(fmt.Errorf("%s is not recorded", call))
+
+		if !slices.Contains(r.missing, call) {
+			r.missing = append(r.missing, call)
+		}
+
+		return nil, false
+	}
+
+	index := r.used[key]
+	if index < len(calls)-1 {
+		r.used[key]++
+	}
+
+	return calls[index], true
+}
+
+func (c *Call) err() error {
+	switch {
+	case len(c.Error) == 0:
+		return nil
+	case c.ExitCode != 0:
+		return &CommandError{Err: c.Error, ExitCode: c.ExitCode}
+	case c.Error == (&NotImplemented{}).Error():
+		return &NotImplemented{}
+	case c.Error == (&battery.NoBatteryError{}).Error():
+		return &battery.NoBatteryError{}
+	default:
+		return errors.New(c.Error)
+	}
+}
+
+func replayed[T any](r *Replay, method string, args ...string) (T, error) {
+	var result T
+
+	call, ok := r.next(method, args...)
+	if !ok {
+		return result, errors.New("not recorded")
+	}
+
+	if len(call.Result) != 0 {
+		if err := json.Unmarshal(call.Result, &result); err != nil {
+			log.Error(err)
+		}
+	}
+
+	return result, call.err()
+}
+
+func (r *Replay) Getenv(key string) string {
+	value, _ := replayed[string](r, "Getenv", key)
+	return value
+}
+
+func (r *Replay) Pwd() string {
+	pwd, _ := replayed[string](r, "Pwd")
+	return pwd
+}
+
+func (r *Replay) Home() string {
+	home, _ := replayed[string](r, "Home")
+	return home
+}
+
+func (r *Replay) User() string {
+	user, _ := replayed[string](r, "User")
+	return user
+}
+
+func (r *Replay) Root() bool {
+	root, _ := replayed[bool](r, "Root")
+	return root
+}
+
+func (r *Replay) Host() (string, error) {
+	return replayed[string](r, "Host")
+}
+
+func (r *Replay) GOOS() string {
+	goos, _ := replayed[string](r, "GOOS")
+	return goos
+}
+
+func (r *Replay) Shell() string {
+	shell, err := replayed[string](r, "Shell")
+	if err != nil {
+		return r.CmdFlags.Shell
+	}
+
+	return shell
+}
+
+func (r *Replay) Platform() string {
+	platform, _ := replayed[string](r, "Platform")
+	return platform
+}
+
+func (r *Replay) StatusCodes() (int, string) {
+	codes, err := replayed[*statusCodes](r, "StatusCodes")
+	if err != nil || codes == nil {
+		return r.CmdFlags.ErrorCode, r.CmdFlags.PipeStatus
+	}
+
+	return codes.Code, codes.PipeStatus
+}
+
+func (r *Replay) HasFiles(pattern string) bool {
+	found, _ := replayed[bool](r, "HasFiles", pattern)
+	return found
+}
+
+func (r *Replay) HasFilesInDir(dir, pattern string) bool {
+	found, _ := replayed[bool](r, "HasFilesInDir", dir, pattern)
+	return found
+}
+
+func (r *Replay) HasFolder(folder string) bool {
+	found, _ := replayed[bool](r, "HasFolder", folder)
+	return found
+}
+
+func (r *Replay) HasParentFilePath(input string, followSymlinks bool) (*FileInfo, error) {
+	return replayed[*FileInfo](r, "HasParentFilePath", input, strconv.FormatBool(followSymlinks))
+}
+
+func (r *Replay) HasFileInParentDirs(pattern string, depth uint) bool {
+	found, _ := replayed[bool](r, "HasFileInParentDirs", pattern, strconv.FormatUint(uint64(depth), 10))
+	return found
+}
+
+func (r *Replay) ResolveSymlink(input string) (string, error) {
+	return replayed[string](r, "ResolveSymlink", input)
+}
+
+func (r *Replay) DirMatchesOneOf(dir string, regexes []string) bool {
+	match, _ := replayed[bool](r, "DirMatchesOneOf", append([]string{dir}, regexes...)...)
+	return match
+}
+
+func (r *Replay) DirIsWritable(input string) bool {
+	writable, _ := replayed[bool](r, "DirIsWritable", input)
+	return writable
+}
+
+func (r *Replay) CommandPath(command string) string {
+	commandPath, _ := replayed[string](r, "CommandPath", command)
+	return commandPath
+}
+
+func (r *Replay) HasCommand(command string) bool {
+	found, _ := replayed[bool](r, "HasCommand", command)
+	return found
+}
+
+func (r *Replay) FileContent(file string) string {
+	fileContent, _ := replayed[content](r, "FileContent", file)
+	return string(fileContent)
+}
+
+func (r *Replay) LsDir(input string) []fs.DirEntry {
+	recorded, _ := replayed[[]*dirEntry](r, "LsDir", input)
+
+	entries := make([]fs.DirEntry, 0, len(recorded))
+	for _, entry := range recorded {
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func (r *Replay) StatFiles(pattern string) []*FileStat {
+	files, _ := replayed[[]*FileStat](r, "StatFiles", pattern)
+	return files
+}
+
+func (r *Replay) RunCommand(command string, args ...string) (string, error) {
+	return replayed[string](r, "RunCommand", append([]string{command}, args...)...)
+}
+
+func (r *Replay) RunCommandWithInput(_ string, _ int, command string, args ...string) (string, error) {
+	return replayed[string](r, "RunCommandWithInput", append([]string{command}, args...)...)
+}
+
+func (r *Replay) RunShellCommand(shell, command string) string {
+	output, _ := replayed[string](r, "RunShellCommand", shell, command)
+	return output
+}
+
+func (r *Replay) ExecutionTime() float64 {
+	executionTime, _ := replayed[float64](r, "ExecutionTime")
+	return executionTime
+}
+
+func (r *Replay) Flags() *Flags {
+	return r.CmdFlags
+}
+
+func (r *Replay) BatteryState() (*battery.Info, error) {
+	return replayed[*battery.Info](r, "BatteryState")
+}
+
+func (r *Replay) QueryWindowTitles(processName, windowTitleRegex string) (string, error) {
+	return replayed[string](r, "QueryWindowTitles", processName, windowTitleRegex)
+}
+
+func (r *Replay) WindowsRegistryKeyValue(key string) (*WindowsRegistryValue, error) {
+	return replayed[*WindowsRegistryValue](r, "WindowsRegistryKeyValue", key)
+}
+
+func (r *Replay) HTTPRequest(targetURL string, _ io.Reader, _ int, _ ...http.RequestModifier) ([]byte, error) {
+	body, err := replayed[content](r, "HTTPRequest", r.redactor.url(targetURL))
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(body), nil
+}
+
+func (r *Replay) IsWsl() bool {
+	wsl, _ := replayed[bool](r, "IsWsl")
+	return wsl
+}
+
+func (r *Replay) IsWsl2() bool {
+	wsl2, _ := replayed[bool](r, "IsWsl2")
+	return wsl2
+}
+
+func (r *Replay) IsCygwin() bool {
+	cygwin, _ := replayed[bool](r, "IsCygwin")
+	return cygwin
+}
+
+func (r *Replay) StackCount() int {
+	count, _ := replayed[int](r, "StackCount")
+	return count
+}
+
+func (r *Replay) TerminalWidth() (int, error) {
+	return replayed[int](r, "TerminalWidth")
+}
+
+func (r *Replay) TerminalBackground() string {
+	background, _ := replayed[string](r, "TerminalBackground")
+	return background
+}
+
+func (r *Replay) Cache() cache.Cache {
+	return r.deviceCache
+}
+
+func (r *Replay) Session() cache.Cache {
+	return r.sessionCache
+}
+
+func (r *Replay) Close() {}
+
+func (r *Replay) Logs() string {
+	return log.String()
+}
+
+func (r *Replay) InWSLSharedDrive() bool {
+	shared, _ := replayed[bool](r, "InWSLSharedDrive")
+	return shared
+}
+
+func (r *Replay) ConvertToLinuxPath(input string) string {
+	linuxPath, err := replayed[string](r, "ConvertToLinuxPath", input)
+	if err != nil {
+		return input
+	}
+
+	return linuxPath
+}
+
+func (r *Replay) ConvertToWindowsPath(input string) string {
+	windowsPath, err := replayed[string](r, "ConvertToWindowsPath", input)
+	if err != nil {
+		return input
+	}
+
+	return windowsPath
+}
+
+func (r *Replay) Connection(connectionType ConnectionType) (*Connection, error) {
+	return replayed[*Connection](r, "Connection", string(connectionType))
+}
+
+func (r *Replay) CursorPosition() (row, col int) {
+	position, _ := replayed[[]int](r, "CursorPosition")
+	if len(position) != 2 {
+		return 0, 0
+	}
+
+	return position[0], position[1]
+}
+
+func (r *Replay) SystemInfo() (*SystemInfo, error) {
+	return replayed[*SystemInfo](r, "SystemInfo")
+}
+
+func (r *Replay) Now() time.Time {
+	now, err := replayed[time.Time](r, "Now")
+	if err != nil {
+		return time.Now()
+	}
+
+	return now
+}
+
+func (e *dirEntry) Name() string {
+	return e.EntryName
+}
+
+func (e *dirEntry) IsDir() bool {
+	return e.Directory
+}
+
+func (e *dirEntry) Type() fs.FileMode {
+	if e.Directory {
+		return fs.ModeDir
+	}
+
+	return 0
+}
+
+func (e *dirEntry) Info() (fs.FileInfo, error) {
+	return nil, &NotImplemented{}
+}
+
+// replayedCache returns the recorded cache lookups,
+// keys which weren't looked up use an in-memory cache.
+type replayedCache struct {
+	cache.Cache
+	replay *Replay
+	method string
+}
+
+func (c *replayedCache) Get(key string) (string, bool) {
+	entry, err := replayed[*cacheEntry](c.replay, c.method, key)
+	if err != nil || entry == nil {
+		return c.Cache.Get(key)
+	}
+
+	return entry.Value, entry.Found
+}
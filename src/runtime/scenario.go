@@ -0,0 +1,562 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing/fstest"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/battery"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/http"
+
+	yaml "github.com/goccy/go-yaml"
+)
+
+// Scenario is an Environment backed by the canned answers of a scenario file
+// instead of the actual system. It renders a configuration deterministically,
+// without running commands or accessing the network.
+type Scenario struct {
+	CmdFlags     *Flags
+	deviceCache  *cache.Store
+	sessionCache *cache.Store
+	fileSystem   fstest.MapFS
+	data         *scenarioData
+	missing      []string
+	mutex        sync.Mutex
+}
+
+type scenarioData struct {
+	Time               time.Time            `yaml:"time"`
+	Env                map[string]string    `yaml:"env"`
+	Files              map[string]string    `yaml:"files"`
+	Commands           map[string]*response `yaml:"commands"`
+	HTTP               map[string]*response `yaml:"http"`
+	OS                 string               `yaml:"os"`
+	Platform           string               `yaml:"platform"`
+	Shell              string               `yaml:"shell"`
+	ShellVersion       string               `yaml:"shell_version"`
+	Pwd                string               `yaml:"pwd"`
+	Home               string               `yaml:"home"`
+	User               string               `yaml:"user"`
+	Host               string               `yaml:"host"`
+	PipeStatus         string               `yaml:"pipestatus"`
+	Directories        []string             `yaml:"directories"`
+	Status             int                  `yaml:"status"`
+	ExecutionTime      float64              `yaml:"execution_time"`
+	TerminalWidth      int                  `yaml:"terminal_width"`
+	TerminalBackground string               `yaml:"terminal_background"`
+	StackCount         int                  `yaml:"stack_count"`
+	JobCount           int                  `yaml:"job_count"`
+	Root               bool                 `yaml:"root"`
+}
+
+// response is the canned answer to a command or HTTP request,
+// either a plain string or an object with an output and exit code.
+type response struct {
+	Output   string `yaml:"output"`
+	ExitCode int    `yaml:"exit_code"`
+}
+
+func (r *response) UnmarshalYAML(unmarshal func(any) error) error {
+	if err := unmarshal(&r.Output); err == nil {
+		return nil
+	}
+
+	type plain response
+	return unmarshal((*plain)(r))
+}
+
+// LoadScenario reads and parses the scenario file at the given location.
+func LoadScenario(file string) (*Scenario, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &scenarioData{}
+	if err := yaml.Unmarshal(content, data); err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %w", file, err)
+	}
+
+	if len(data.OS) == 0 {
+		data.OS = runtime.GOOS
+	}
+
+	return &Scenario{data: data}, nil
+}
+
+// Init merges the scenario with the given flags, values set
+// by the scenario take precedence over the defaults.
+func (s *Scenario) Init(flags *Flags) {
+	defer log.Trace(time.Now())
+
+	s.CmdFlags = flags
+	if s.CmdFlags == nil {
+		s.CmdFlags = &Flags{}
+	}
+
+	data := s.data
+
+	if len(data.Pwd) == 0 {
+		data.Pwd = s.CmdFlags.PWD
+	}
+
+	if len(data.Pwd) == 0 {
+		data.Pwd = data.Home
+	}
+
+	s.CmdFlags.PWD = data.Pwd
+	s.CmdFlags.AbsolutePWD = data.Pwd
+
+	if len(data.Shell) != 0 {
+		s.CmdFlags.Shell = data.Shell
+	}
+
+	if len(data.ShellVersion) != 0 {
+		s.CmdFlags.ShellVersion = data.ShellVersion
+	}
+
+	if data.TerminalWidth != 0 {
+		s.CmdFlags.TerminalWidth = data.TerminalWidth
+	}
+
+	if data.Status != 0 {
+		s.CmdFlags.ErrorCode = data.Status
+	}
+
+	if len(data.PipeStatus) != 0 {
+		s.CmdFlags.PipeStatus = data.PipeStatus
+	}
+
+	if data.ExecutionTime != 0 {
+		s.CmdFlags.ExecutionTime = data.ExecutionTime
+	}
+
+	if data.StackCount != 0 {
+		s.CmdFlags.StackCount = data.StackCount
+	}
+
+	if data.JobCount != 0 {
+		s.CmdFlags.JobCount = data.JobCount
+	}
+
+	s.CmdFlags.PromptCount = 1
+
+	// the caches only live in memory, a scenario never depends on previous runs
+	initCache := func() *cache.Store {
+		store := &cache.Store{}
+		store.Init("", false)
+		return store
+	}
+
+	s.deviceCache = initCache()
+	s.sessionCache = initCache()
+
+	s.fileSystem = fstest.MapFS{}
+
+	for file, content := range data.Files {
+		s.fileSystem[s.fsPath(file)] = &fstest.MapFile{Data: []byte(content), ModTime: data.Time}
+	}
+
+	for _, dir := range data.Directories {
+		s.fileSystem[s.fsPath(dir)] = &fstest.MapFile{Mode: fs.ModeDir}
+	}
+}
+
+// fsPath converts a scenario location into a path of the in-memory file system,
+// relative locations are resolved against the working directory.
+func (s *Scenario) fsPath(input string) string {
+	if !filepath.IsAbs(input) && !strings.HasPrefix(input, "/") {
+		input = filepath.Join(s.data.Pwd, input)
+	}
+
+	input = filepath.ToSlash(filepath.Clean(input))
+	input = strings.TrimPrefix(input, "/")
+
+	if len(input) == 0 {
+		return "."
+	}
+
+	return input
+}
+
+func (s *Scenario) stat(input string) (fs.FileInfo, bool) {
+	info, err := fs.Stat(s.fileSystem, s.fsPath(input))
+	if err != nil {
+		return nil, false
+	}
+
+	return info, true
+}
+
+func (s *Scenario) Getenv(key string) string {
+	return s.data.Env[key]
+}
+
+func (s *Scenario) Pwd() string {
+	return s.data.Pwd
+}
+
+func (s *Scenario) Home() string {
+	return s.data.Home
+}
+
+func (s *Scenario) User() string {
+	return s.data.User
+}
+
+func (s *Scenario) Root() bool {
+	return s.data.Root
+}
+
+func (s *Scenario) Host() (string, error) {
+	if len(s.data.Host) == 0 {
+		return "", errors.New("no host in scenario")
+	}
+
+	return s.data.Host, nil
+}
+
+func (s *Scenario) GOOS() string {
+	return s.data.OS
+}
+
+func (s *Scenario) Shell() string {
+	if len(s.CmdFlags.Shell) != 0 {
+		return s.CmdFlags.Shell
+	}
+
+	return UNKNOWN
+}
+
+func (s *Scenario) Platform() string {
+	if len(s.data.Platform) != 0 {
+		return s.data.Platform
+	}
+
+	return s.data.OS
+}
+
+func (s *Scenario) StatusCodes() (int, string) {
+	return s.CmdFlags.ErrorCode, s.CmdFlags.PipeStatus
+}
+
+func (s *Scenario) HasFiles(pattern string) bool {
+	return s.HasFilesInDir(s.Pwd(), pattern)
+}
+
+func (s *Scenario) HasFilesInDir(dir, pattern string) bool {
+	pattern = strings.ToLower(pattern)
+
+	for _, entry := range s.LsDir(dir) {
+		if entry.IsDir() {
+			continue
+		}
+
+		if match, err := filepath.Match(pattern, strings.ToLower(entry.Name())); err == nil && match {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Scenario) HasFolder(folder string) bool {
+	info, ok := s.stat(folder)
+	return ok && info.IsDir()
+}
+
+func (s *Scenario) HasParentFilePath(parent string, _ bool) (*FileInfo, error) {
+	pwd := s.Pwd()
+
+	for {
+		path := filepath.Join(pwd, parent)
+		if info, ok := s.stat(path); ok {
+			return &FileInfo{
+				ParentFolder: pwd,
+				Path:         path,
+				IsDir:        info.IsDir(),
+			}, nil
+		}
+
+		dir := filepath.Dir(pwd)
+		if dir == pwd {
+			return nil, errors.New("no match at root level")
+		}
+
+		pwd = dir
+	}
+}
+
+func (s *Scenario) HasFileInParentDirs(pattern string, depth uint) bool {
+	currentFolder := s.Pwd()
+
+	for c := 0; c < int(depth); c++ {
+		if s.HasFilesInDir(currentFolder, pattern) {
+			return true
+		}
+
+		dir := filepath.Dir(currentFolder)
+		if dir == currentFolder {
+			return false
+		}
+
+		currentFolder = dir
+	}
+
+	return false
+}
+
+func (s *Scenario) ResolveSymlink(input string) (string, error) {
+	return input, nil
+}
+
+func (s *Scenario) DirMatchesOneOf(dir string, regexes []string) bool {
+	return dirMatchesOneOf(dir, s.Home(), s.GOOS(), regexes)
+}
+
+func (s *Scenario) DirIsWritable(input string) bool {
+	return s.HasFolder(input)
+}
+
+// CommandPath returns the command itself when the scenario contains output for it.
+func (s *Scenario) CommandPath(command string) string {
+	for commandLine := range s.data.Commands {
+		name, _, _ := strings.Cut(commandLine, " ")
+		if name == command {
+			return command
+		}
+	}
+
+	return ""
+}
+
+func (s *Scenario) HasCommand(command string) bool {
+	return len(s.CommandPath(command)) != 0
+}
+
+func (s *Scenario) FileContent(file string) string {
+	content, err := fs.ReadFile(s.fileSystem, s.fsPath(file))
+	if err != nil {
+		return ""
+	}
+
+	return string(content)
+}
+
+func (s *Scenario) LsDir(input string) []fs.DirEntry {
+	entries, err := fs.ReadDir(s.fileSystem, s.fsPath(input))
+	if err != nil {
+		return nil
+	}
+
+	return entries
+}
+
+func (s *Scenario) StatFiles(pattern string) []*FileStat {
+	matches, err := fs.Glob(s.fileSystem, s.fsPath(pattern))
+	if err != nil {
+		return nil
+	}
+
+	files := make([]*FileStat, 0, len(matches))
+
+	for _, match := range matches {
+		info, ok := s.stat("/" + match)
+		if !ok {
+			continue
+		}
+
+		files = append(files, &FileStat{Path: "/" + match, ModTime: info.ModTime(), Size: info.Size()})
+	}
+
+	return files
+}
+
+// RunCommand returns the output for the command line (the command and its arguments
+// separated by a space) in the scenario. A non-zero exit code results in an error.
+func (s *Scenario) RunCommand(command string, args ...string) (string, error) {
+	commandLine := strings.Join(append([]string{command}, args...), " ")
+
+	answer, ok := s.data.Commands[commandLine]
+	if !ok {
+		s.miss(commandLine)
+		return "", fmt.Errorf("no output for %s in scenario", commandLine)
+	}
+
+	output := strings.TrimSpace(answer.Output)
+
+	if answer.ExitCode != 0 {
+		return output, &CommandError{
+			Err:      "exit status " + strconv.Itoa(answer.ExitCode),
+			ExitCode: answer.ExitCode,
+		}
+	}
+
+	return output, nil
+}
+
+// RunCommandWithInput ignores the input, the scenario only knows the command line.
+func (s *Scenario) RunCommandWithInput(_ string, _ int, command string, args ...string) (string, error) {
+	return s.RunCommand(command, args...)
+}
+
+func (s *Scenario) miss(request string) {
+	log.Error(fmt.Errorf("no answer for %s in scenario", request))
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if slices.Contains(s.missing, request) {
+		return
+	}
+
+	s.missing = append(s.missing, request)
+}
+
+// Missing returns the command lines and URLs which were requested
+// while rendering, but have no answer in the scenario.
+func (s *Scenario) Missing() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return slices.Clone(s.missing)
+}
+
+func (s *Scenario) RunShellCommand(shell, command string) string {
+	if out, err := s.RunCommand(shell, "-c", command); err == nil {
+		return out
+	}
+
+	return ""
+}
+
+func (s *Scenario) ExecutionTime() float64 {
+	if s.CmdFlags.ExecutionTime < 0 {
+		return 0
+	}
+
+	return s.CmdFlags.ExecutionTime
+}
+
+func (s *Scenario) Flags() *Flags {
+	return s.CmdFlags
+}
+
+func (s *Scenario) BatteryState() (*battery.Info, error) {
+	return nil, &battery.NoBatteryError{}
+}
+
+func (s *Scenario) QueryWindowTitles(_, _ string) (string, error) {
+	return "", &NotImplemented{}
+}
+
+func (s *Scenario) WindowsRegistryKeyValue(_ string) (*WindowsRegistryValue, error) {
+	return nil, &NotImplemented{}
+}
+
+// HTTPRequest returns the body for the URL in the scenario, a non-zero
+// exit code is used as the HTTP status code of a failed request.
+func (s *Scenario) HTTPRequest(targetURL string, _ io.Reader, _ int, _ ...http.RequestModifier) ([]byte, error) {
+	answer, ok := s.data.HTTP[targetURL]
+	if !ok {
+		s.miss(targetURL)
+		return nil, fmt.Errorf("no response for %s in scenario", targetURL)
+	}
+
+	if answer.ExitCode != 0 {
+		return nil, errors.New("HTTP status code " + strconv.Itoa(answer.ExitCode))
+	}
+
+	return []byte(answer.Output), nil
+}
+
+func (s *Scenario) IsWsl() bool {
+	return false
+}
+
+func (s *Scenario) IsWsl2() bool {
+	return false
+}
+
+func (s *Scenario) IsCygwin() bool {
+	return false
+}
+
+func (s *Scenario) StackCount() int {
+	if s.CmdFlags.StackCount < 0 {
+		return 0
+	}
+
+	return s.CmdFlags.StackCount
+}
+
+func (s *Scenario) TerminalWidth() (int, error) {
+	if s.CmdFlags.TerminalWidth > 0 {
+		return s.CmdFlags.TerminalWidth, nil
+	}
+
+	return 0, errors.New("no terminal width in scenario")
+}
+
+func (s *Scenario) TerminalBackground() string {
+	return s.data.TerminalBackground
+}
+
+func (s *Scenario) Cache() cache.Cache {
+	return s.deviceCache
+}
+
+func (s *Scenario) Session() cache.Cache {
+	return s.sessionCache
+}
+
+func (s *Scenario) Close() {}
+
+func (s *Scenario) Logs() string {
+	return log.String()
+}
+
+func (s *Scenario) InWSLSharedDrive() bool {
+	return false
+}
+
+func (s *Scenario) ConvertToLinuxPath(input string) string {
+	return input
+}
+
+func (s *Scenario) ConvertToWindowsPath(input string) string {
+	return input
+}
+
+func (s *Scenario) Connection(_ ConnectionType) (*Connection, error) {
+	return nil, &NotImplemented{}
+}
+
+func (s *Scenario) CursorPosition() (row, col int) {
+	return 0, s.CmdFlags.Column
+}
+
+func (s *Scenario) SystemInfo() (*SystemInfo, error) {
+	return nil, &NotImplemented{}
+}
+
+// Now returns the time set in the scenario, or the current time when there is none.
+func (s *Scenario) Now() time.Time {
+	if s.data.Time.IsZero() {
+		return time.Now()
+	}
+
+	return s.data.Time
+}
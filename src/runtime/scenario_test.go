@@ -0,0 +1,137 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testScenario = `
+os: linux
+shell: zsh
+user: posh
+home: /home/posh
+pwd: /home/posh/code/project
+time: 2024-06-01T13:37:00Z
+terminal_width: 120
+status: 2
+env:
+  AWS_PROFILE: dev
+files:
+  go.mod: module project
+  /home/posh/.config/app.yaml: "name: app"
+directories:
+  - .git
+commands:
+  go version: go version go1.22.4 linux/amd64
+  git --version:
+    output: fatal
+    exit_code: 128
+http:
+  https://example.com/api: '{"status": "ok"}'
+`
+
+func newTestScenario(t *testing.T, content string, flags *Flags) *Scenario {
+	file := filepath.Join(t.TempDir(), "scenario.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o644))
+
+	scenario, err := LoadScenario(file)
+	require.NoError(t, err)
+
+	scenario.Init(flags)
+
+	return scenario
+}
+
+func TestScenarioInit(t *testing.T) {
+	flags := &Flags{Shell: "pwsh", ErrorCode: 1, Plain: true}
+	scenario := newTestScenario(t, testScenario, flags)
+
+	assert.Equal(t, "zsh", scenario.Shell())
+	assert.Equal(t, "/home/posh/code/project", scenario.Pwd())
+	assert.Equal(t, "/home/posh/code/project", flags.AbsolutePWD)
+	assert.True(t, flags.Plain)
+
+	code, _ := scenario.StatusCodes()
+	assert.Equal(t, 2, code)
+
+	width, err := scenario.TerminalWidth()
+	assert.NoError(t, err)
+	assert.Equal(t, 120, width)
+
+	assert.Equal(t, "dev", scenario.Getenv("AWS_PROFILE"))
+	assert.Empty(t, scenario.Getenv("HOME"))
+
+	assert.Equal(t, time.Date(2024, 6, 1, 13, 37, 0, 0, time.UTC), scenario.Now())
+
+	_, err = scenario.Host()
+	assert.Error(t, err)
+
+	defaults := newTestScenario(t, "home: /home/posh", &Flags{})
+	assert.Equal(t, "/home/posh", defaults.Pwd())
+	assert.Equal(t, UNKNOWN, defaults.Shell())
+
+	_, err = defaults.TerminalWidth()
+	assert.Error(t, err)
+}
+
+func TestScenarioFileSystem(t *testing.T) {
+	scenario := newTestScenario(t, testScenario, &Flags{})
+
+	assert.True(t, scenario.HasFiles("*.MOD"))
+	assert.False(t, scenario.HasFiles("*.json"))
+	assert.True(t, scenario.HasFilesInDir("/home/posh/.config", "app.yaml"))
+	assert.True(t, scenario.HasFolder("/home/posh/code/project/.git"))
+	assert.True(t, scenario.HasFolder("/home/posh"))
+	assert.False(t, scenario.HasFolder("/home/posh/code/project/go.mod"))
+	assert.True(t, scenario.HasFileInParentDirs("go.mod", 1))
+	assert.Equal(t, "module project", scenario.FileContent("go.mod"))
+	assert.Equal(t, "name: app", scenario.FileContent("/home/posh/.config/app.yaml"))
+	assert.Empty(t, scenario.FileContent("missing.txt"))
+	assert.Len(t, scenario.LsDir("/home/posh/code/project"), 2)
+
+	info, err := scenario.HasParentFilePath(".config", false)
+	require.NoError(t, err)
+	assert.Equal(t, "/home/posh", filepath.ToSlash(info.ParentFolder))
+	assert.True(t, info.IsDir)
+
+	_, err = scenario.HasParentFilePath("package.json", false)
+	assert.Error(t, err)
+}
+
+func TestScenarioRequests(t *testing.T) {
+	scenario := newTestScenario(t, testScenario, &Flags{})
+
+	assert.True(t, scenario.HasCommand("go"))
+	assert.True(t, scenario.HasCommand("git"))
+	assert.False(t, scenario.HasCommand("kubectl"))
+
+	output, err := scenario.RunCommand("go", "version")
+	assert.NoError(t, err)
+	assert.Equal(t, "go version go1.22.4 linux/amd64", output)
+
+	output, err = scenario.RunCommand("git", "--version")
+	assert.Equal(t, "fatal", output)
+
+	var commandError *CommandError
+	require.ErrorAs(t, err, &commandError)
+	assert.Equal(t, 128, commandError.ExitCode)
+
+	body, err := scenario.HTTPRequest("https://example.com/api", nil, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"status": "ok"}`, string(body))
+
+	_, err = scenario.RunCommand("kubectl", "config", "view")
+	assert.Error(t, err)
+
+	_, err = scenario.HTTPRequest("https://example.com/other", nil, 20)
+	assert.Error(t, err)
+
+	_, _ = scenario.RunCommand("kubectl", "config", "view")
+
+	assert.Equal(t, []string{"kubectl config view", "https://example.com/other"}, scenario.Missing())
+}
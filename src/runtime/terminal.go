@@ -23,6 +23,7 @@ import (
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime/cmd"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime/http"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime/path"
+	"github.com/jandedobbeleer/oh-my-posh/src/timing"
 
 	disk "github.com/shirou/gopsutil/v3/disk"
 	load "github.com/shirou/gopsutil/v3/load"
@@ -32,8 +33,8 @@ import (
 type Terminal struct {
 	CmdFlags     *Flags
 	cmdCache     *cache.Command
-	deviceCache  *cache.File
-	sessionCache *cache.File
+	deviceCache  *cache.Store
+	sessionCache *cache.Store
 	lsDirMap     maps.Concurrent
 	cwd          string
 	host         string
@@ -54,8 +55,8 @@ func (term *Terminal) Init(flags *Flags) {
 		log.Debug("plain mode enabled")
 	}
 
-	initCache := func(fileName string) *cache.File {
-		fileCache := &cache.File{}
+	initCache := func(fileName string) *cache.Store {
+		fileCache := &cache.Store{}
 		fileCache.Init(filepath.Join(cache.Path(), fileName), term.CmdFlags.SaveCache)
 		return fileCache
 	}
@@ -69,6 +70,9 @@ func (term *Terminal) Init(flags *Flags) {
 	term.cmdCache = &cache.Command{
 		Commands: maps.NewConcurrent(),
 	}
+
+	// PATHEXT changes which executables are found on Windows
+	term.cmdCache.Persist(term.deviceCache, os.Getenv("PATH")+os.Getenv("PATHEXT"))
 }
 
 func (term *Terminal) Getenv(key string) string {
@@ -235,6 +239,33 @@ func (term *Terminal) LsDir(input string) []fs.DirEntry {
 	return entries
 }
 
+func (term *Terminal) StatFiles(pattern string) []*FileStat {
+	defer log.Trace(time.Now(), pattern)
+
+	if !filepath.IsAbs(pattern) {
+		pattern = filepath.Join(term.Pwd(), pattern)
+	}
+
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		log.Error(err)
+		return nil
+	}
+
+	files := make([]*FileStat, 0, len(matches))
+
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil {
+			continue
+		}
+
+		files = append(files, &FileStat{Path: match, ModTime: info.ModTime(), Size: info.Size()})
+	}
+
+	return files
+}
+
 func (term *Terminal) User() string {
 	defer log.Trace(time.Now())
 	user := os.Getenv("USER")
@@ -275,6 +306,7 @@ func (term *Terminal) Home() string {
 
 func (term *Terminal) RunCommand(command string, args ...string) (string, error) {
 	defer log.Trace(time.Now(), append([]string{command}, args...)...)
+	defer timing.Track(timing.Command, time.Now())
 
 	if cacheCommand, ok := term.cmdCache.Get(command); ok {
 		command = cacheCommand
@@ -289,6 +321,24 @@ func (term *Terminal) RunCommand(command string, args ...string) (string, error)
 	return output, err
 }
 
+// RunCommandWithInput runs the command with the input on stdin, it's killed after the timeout in milliseconds.
+func (term *Terminal) RunCommandWithInput(input string, timeout int, command string, args ...string) (string, error) {
+	defer log.Trace(time.Now(), append([]string{command}, args...)...)
+	defer timing.Track(timing.Command, time.Now())
+
+	if cacheCommand, ok := term.cmdCache.Get(command); ok {
+		command = cacheCommand
+	}
+
+	output, err := cmd.RunWithInput(input, time.Duration(timeout)*time.Millisecond, command, args...)
+	if err != nil {
+		log.Error(err)
+	}
+
+	log.Debug(output)
+	return output, err
+}
+
 func (term *Terminal) RunShellCommand(shell, command string) string {
 	defer log.Trace(time.Now())
 
@@ -301,7 +351,7 @@ func (term *Terminal) RunShellCommand(shell, command string) string {
 
 func (term *Terminal) CommandPath(command string) string {
 	defer log.Trace(time.Now(), command)
-	if cmdPath, ok := term.cmdCache.Get(command); ok {
+	if cmdPath, ok := term.cmdCache.Lookup(command); ok {
 		log.Debug(cmdPath)
 		return cmdPath
 	}
@@ -483,6 +533,25 @@ func (term *Terminal) StackCount() int {
 	return term.CmdFlags.StackCount
 }
 
+func (term *Terminal) Connection(connectionType ConnectionType) (*Connection, error) {
+	if term.networks == nil {
+		networks := term.getConnections()
+		if len(networks) == 0 {
+			return nil, errors.New("No connections found")
+		}
+		term.networks = networks
+	}
+
+	for _, network := range term.networks {
+		if network.Type == connectionType {
+			return network, nil
+		}
+	}
+
+	log.Error(fmt.Errorf("Network type '%s' not found", connectionType))
+	return nil, &NotImplemented{}
+}
+
 func (term *Terminal) Cache() cache.Cache {
 	return term.deviceCache
 }
@@ -614,6 +683,10 @@ func (term *Terminal) SystemInfo() (*SystemInfo, error) {
 	return s, nil
 }
 
+func (term *Terminal) Now() time.Time {
+	return time.Now()
+}
+
 func cleanHostName(hostName string) string {
 	garbage := []string{
 		".lan",
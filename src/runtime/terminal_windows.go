@@ -215,22 +215,3 @@ func (term *Terminal) DirIsWritable(input string) bool {
 	defer log.Trace(time.Now())
 	return term.isWriteable(input)
 }
-
-func (term *Terminal) Connection(connectionType ConnectionType) (*Connection, error) {
-	if term.networks == nil {
-		networks := term.getConnections()
-		if len(networks) == 0 {
-			return nil, errors.New("No connections found")
-		}
-		term.networks = networks
-	}
-
-	for _, network := range term.networks {
-		if network.Type == connectionType {
-			return network, nil
-		}
-	}
-
-	log.Error(fmt.Errorf("Network type '%s' not found", connectionType))
-	return nil, &NotImplemented{}
-}
@@ -0,0 +1,9 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package runtime
+
+import "time"
+
+func queryTerminal(_ string, _ time.Duration) (string, error) {
+	return "", &NotImplemented{}
+}
@@ -0,0 +1,18 @@
+//go:build linux || darwin || freebsd || netbsd
+
+package runtime
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+func simulateInput(fd int, char byte) error {
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), uintptr(unix.TIOCSTI), uintptr(unsafe.Pointer(&char)))
+	if errno != 0 {
+		return errno
+	}
+
+	return nil
+}
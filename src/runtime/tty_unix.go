@@ -0,0 +1,131 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package runtime
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+
+	"golang.org/x/sys/unix"
+)
+
+// the extra time to wait for an answer after the query timed out, so we consume it
+// instead of leaving it in the input of the shell
+const lateAnswerTimeout = 200 * time.Millisecond
+
+var (
+	// the answer to the primary device attributes query (DA1)
+	deviceAttributes = regexp.MustCompile(`\x1b\[\?[\d;]*c`)
+
+	// the answers of the terminal, OSC responses end with BEL or ST
+	terminalResponses = regexp.MustCompile(`\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b\[\?[\d;]*c`)
+)
+
+// queryTerminal writes the query to the terminal and returns its answer, up to and including
+// the device attributes the query has to end with
+func queryTerminal(query string, timeout time.Duration) (string, error) {
+	// use the file descriptor directly, the runtime poller would ignore the read timeout of the terminal
+	fd, err := unix.Open("/dev/tty", unix.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		return "", err
+	}
+
+	defer unix.Close(fd)
+
+	state, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if err != nil {
+		return "", err
+	}
+
+	// don't echo the answer and return from read calls after at most 100ms
+	raw := *state
+	raw.Lflag &^= unix.ICANON | unix.ECHO
+	raw.Cc[unix.VMIN] = 0
+	raw.Cc[unix.VTIME] = 1
+
+	if err := unix.IoctlSetTermios(fd, ioctlSetTermios, &raw); err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = unix.IoctlSetTermios(fd, ioctlSetTermios, state)
+	}()
+
+	// the user typed ahead, we can't tell their input from the answer
+	if inputPending(fd) {
+		return "", errors.New("input pending, not querying the terminal")
+	}
+
+	if _, err := unix.Write(fd, []byte(query)); err != nil {
+		return "", err
+	}
+
+	var response strings.Builder
+
+	// hand back whatever the user typed while we were waiting for the answer
+	defer func() {
+		pushInput(fd, terminalResponses.ReplaceAllString(response.String(), ""))
+	}()
+
+	buffer := make([]byte, 256)
+
+	// read returns true once the device attributes are in the response
+	read := func(deadline time.Time) (bool, error) {
+		for time.Now().Before(deadline) {
+			n, err := unix.Read(fd, buffer)
+			if err != nil && !errors.Is(err, unix.EINTR) {
+				return false, err
+			}
+
+			if n <= 0 {
+				continue
+			}
+
+			response.Write(buffer[:n])
+
+			if deviceAttributes.MatchString(response.String()) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	answered, err := read(time.Now().Add(timeout))
+	if answered || err != nil {
+		return response.String(), err
+	}
+
+	// we don't wait for a late answer, but it must not end up in the input of the shell
+	if _, err := read(time.Now().Add(lateAnswerTimeout)); err != nil {
+		return response.String(), err
+	}
+
+	return response.String(), errors.New("timeout waiting for the terminal to answer")
+}
+
+func inputPending(fd int) bool {
+	fds := []unix.PollFd{{Fd: int32(fd), Events: unix.POLLIN}}
+
+	n, err := unix.Poll(fds, 0)
+	if err != nil {
+		return false
+	}
+
+	return n > 0 && fds[0].Revents&unix.POLLIN != 0
+}
+
+// pushInput puts the input back into the input queue of the terminal, where the shell reads it.
+// Systems that restrict TIOCSTI (Linux 6.2+ with dev.tty.legacy_tiocsti=0, OpenBSD) drop the input.
+func pushInput(fd int, input string) {
+	for i := 0; i < len(input); i++ {
+		if err := simulateInput(fd, input[i]); err != nil {
+			log.Error(err)
+			return
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package runtime
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// FileState returns the modification time and size of the files matching the glob patterns,
+// relative patterns are resolved against the current working directory
+func FileState(env Environment, patterns []string) string {
+	var state strings.Builder
+
+	for _, pattern := range patterns {
+		if pattern == "~" || strings.HasPrefix(pattern, "~/") {
+			pattern = env.Home() + pattern[1:]
+		}
+
+		if !filepath.IsAbs(pattern) && !strings.HasPrefix(pattern, "/") {
+			pattern = filepath.Join(env.Pwd(), pattern)
+		}
+
+		for _, file := range env.StatFiles(pattern) {
+			fmt.Fprintf(&state, "%s|%d|%d\n", file.Path, file.ModTime.UnixNano(), file.Size)
+		}
+	}
+
+	return state.String()
+}
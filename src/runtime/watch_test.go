@@ -0,0 +1,47 @@
+package runtime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileState(t *testing.T) {
+	folder := t.TempDir()
+	packageJSON := filepath.Join(folder, "package.json")
+	nvmrc := filepath.Join(folder, ".nvmrc")
+
+	env := &Terminal{cwd: folder}
+
+	assert.Empty(t, FileState(env, []string{"package.json", ".nvmrc"}), "no files")
+
+	_ = os.WriteFile(packageJSON, []byte("{}"), 0o644)
+	state := FileState(env, []string{"*.json", ".nvmrc"})
+	assert.Contains(t, state, packageJSON)
+
+	_ = os.WriteFile(nvmrc, []byte("20"), 0o644)
+	assert.NotEqual(t, state, FileState(env, []string{"*.json", ".nvmrc"}), "new file")
+
+	state = FileState(env, []string{"*.json", nvmrc})
+	_ = os.WriteFile(nvmrc, []byte("20.1"), 0o644)
+	assert.NotEqual(t, state, FileState(env, []string{"*.json", nvmrc}), "size changed")
+
+	state = FileState(env, []string{"*.json", nvmrc})
+	future := time.Now().Add(time.Hour)
+	_ = os.Chtimes(packageJSON, future, future)
+	assert.NotEqual(t, state, FileState(env, []string{"*.json", nvmrc}), "modification time changed")
+}
+
+func TestScenarioFileState(t *testing.T) {
+	scenario := newTestScenario(t, testScenario, &Flags{})
+
+	modTime := time.Date(2024, 6, 1, 13, 37, 0, 0, time.UTC).UnixNano()
+	expected := fmt.Sprintf("/home/posh/code/project/go.mod|%d|14\n/home/posh/.config/app.yaml|%d|9\n", modTime, modTime)
+
+	state := FileState(scenario, []string{"*.mod", "~/.config/*.yaml", "package.json"})
+	assert.Equal(t, expected, state)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/jandedobbeleer/oh-my-posh/src/properties"
 	"github.com/jandedobbeleer/oh-my-posh/src/regex"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/git"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime/path"
 
 	"gopkg.in/ini.v1"
@@ -74,6 +75,10 @@ const (
 	FetchBareInfo properties.Property = "fetch_bare_info"
 	// FetchUser fetches the current user for the repo
 	FetchUser properties.Property = "fetch_user"
+	// Native reads the repository state from disk instead of using the git CLI
+	Native properties.Property = "native"
+	// DisableWithJj hides the segment in a repository colocated with Jujutsu so the jj segment takes priority
+	DisableWithJj properties.Property = "disable_with_jj"
 
 	// BranchIcon the icon to use as branch indicator
 	BranchIcon properties.Property = "branch_icon"
@@ -141,6 +146,7 @@ type Git struct {
 	Working        *GitStatus
 	Staging        *GitStatus
 	commit         *Commit
+	repository     *git.Repository
 	Rebase         *Rebase
 	RawUpstreamURL string
 	Ref            string
@@ -240,6 +246,10 @@ func (g *Git) Commit() *Commit {
 		Refs:      &Refs{},
 	}
 
+	if g.setNativeCommit() {
+		return g.commit
+	}
+
 	commitBody := g.getGitCommandOutput("log", "-1", "--pretty=format:an:%an%nae:%ae%ncn:%cn%nce:%ce%nat:%at%nsu:%s%nha:%H%nrf:%D", "--decorate=full")
 	splitted := strings.Split(strings.TrimSpace(commitBody), "\n")
 	for _, line := range splitted {
@@ -349,6 +359,10 @@ func (g *Git) shouldDisplay() bool {
 		return false
 	}
 
+	if g.props.GetBool(DisableWithJj, false) && g.env.HasFolder(filepath.Join(gitdir.ParentFolder, ".jj")) {
+		return false
+	}
+
 	g.setDir(gitdir.Path)
 
 	if !gitdir.IsDir {
@@ -593,20 +607,7 @@ func (g *Git) setGitStatus() {
 			return
 		}
 
-		// map conflicts separately when in a merge or rebase
-		if g.Rebase != nil || g.Merge {
-			conflict := "AA"
-			full := status[2:4]
-			if full == conflict {
-				g.Staging.add(conflict)
-				return
-			}
-		}
-
-		workingCode := status[3:4]
-		stagingCode := status[2:3]
-		g.Working.add(workingCode)
-		g.Staging.add(stagingCode)
+		g.addToStatus(status[2:4])
 	}
 
 	const (
@@ -623,6 +624,10 @@ func (g *Git) setGitStatus() {
 	g.Working = &GitStatus{ScmStatus: ScmStatus{Formats: statusFormats}}
 	g.Staging = &GitStatus{ScmStatus: ScmStatus{Formats: statusFormats}}
 
+	if g.setNativeGitStatus() {
+		return
+	}
+
 	untrackedMode := g.getUntrackedFilesMode()
 	args := []string{"status", untrackedMode, "--branch", "--porcelain=2"}
 	ignoreSubmodulesMode := g.getIgnoreSubmodulesMode()
@@ -667,6 +672,21 @@ func (g *Git) setGitStatus() {
 	}
 }
 
+// addToStatus adds the two letter staging and working code of a changed path
+func (g *Git) addToStatus(code string) {
+	// map conflicts separately when in a merge or rebase
+	if g.Rebase != nil || g.Merge {
+		const conflict = "AA"
+		if code == conflict {
+			g.Staging.add(conflict)
+			return
+		}
+	}
+
+	g.Staging.add(code[0:1])
+	g.Working.add(code[1:2])
+}
+
 func (g *Git) getGitCommandOutput(args ...string) string {
 	args = append([]string{"-C", g.realDir, "--no-optional-locks", "-c", "core.quotepath=false", "-c", "color.status=false"}, args...)
 	val, err := g.env.RunCommand(g.command, args...)
@@ -830,6 +850,11 @@ func (g *Git) hasGitFile(file string) bool {
 
 func (g *Git) getGitRefFileSymbolicName(refFile string) string {
 	ref := g.FileContents(g.workingDir, refFile)
+
+	if name := g.nativeBranchAt(ref); len(name) != 0 {
+		return name
+	}
+
 	return g.getGitCommandOutput("name-rev", "--name-only", "--exclude=tags/*", ref)
 }
 
@@ -853,7 +878,11 @@ func (g *Git) setPrettyHEADName() {
 	}
 
 	// check for tag
-	tagName := g.getGitCommandOutput("describe", "--tags", "--exact-match")
+	tagName, ok := g.nativeTag()
+	if !ok {
+		tagName = g.getGitCommandOutput("describe", "--tags", "--exact-match")
+	}
+
 	if len(tagName) > 0 {
 		g.Ref = tagName
 		g.HEAD = fmt.Sprintf("%s%s", g.props.GetString(TagIcon, "\uF412"), tagName)
@@ -0,0 +1,201 @@
+package segments
+
+import (
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/git"
+)
+
+// getRepository returns the repository to read natively from disk,
+// nil when native is disabled or the repository can't be opened.
+func (g *Git) getRepository() *git.Repository {
+	if !g.props.GetBool(Native, false) {
+		return nil
+	}
+
+	// the repository is read from disk and not through the environment,
+	// use the CLI so scenarios, replays and recordings cover the repository state
+	switch g.env.(type) {
+	case *runtime.Scenario, *runtime.Replay, *runtime.Recorder:
+		return nil
+	}
+
+	if g.repository != nil {
+		return g.repository
+	}
+
+	workTree := g.convertToLinuxPath(g.realDir)
+
+	repository, err := git.Open(workTree, g.workingDir, g.rootDir)
+	if err != nil {
+		log.Error(err)
+		return nil
+	}
+
+	g.repository = repository
+
+	return repository
+}
+
+// setNativeGitStatus sets the same information as git status, it returns false
+// when that isn't possible and we need to fall back to the CLI.
+func (g *Git) setNativeGitStatus() bool {
+	repository := g.getRepository()
+	if repository == nil {
+		return false
+	}
+
+	branch, head, err := repository.Head()
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+
+	options := git.StatusOptions{
+		UntrackedFiles:   strings.TrimPrefix(g.getUntrackedFilesMode(), "-u"),
+		IgnoreSubmodules: strings.TrimPrefix(g.getIgnoreSubmodulesMode(), "--ignore-submodules="),
+	}
+
+	status, err := repository.Status(head, options)
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+
+	var upstream string
+	var ahead, behind int
+	upstreamGone := true
+
+	if len(branch) != 0 {
+		var upstreamHash git.Hash
+		var found bool
+
+		upstream, upstreamHash, found = repository.Upstream(branch)
+
+		// git status doesn't compare with the upstream on an unborn branch
+		if found && !head.IsZero() {
+			ahead, behind, err = repository.AheadBehind(head, upstreamHash)
+			if err != nil {
+				log.Error(err)
+				return false
+			}
+
+			upstreamGone = false
+		}
+	}
+
+	g.Ref = branch
+	if len(branch) == 0 {
+		g.Ref = DETACHED
+	}
+
+	if !head.IsZero() {
+		g.Hash = head.String()
+		g.ShortHash = g.Hash[:7]
+	}
+
+	g.Upstream = upstream
+	g.UpstreamGone = upstreamGone
+	g.Ahead = ahead
+	g.Behind = behind
+
+	for _, change := range status.Changes {
+		g.addToStatus(string([]byte{change.Staging, change.Working}))
+	}
+
+	for range status.Untracked {
+		g.Working.add("?")
+	}
+
+	return true
+}
+
+// setNativeCommit sets the same information as git log -1
+func (g *Git) setNativeCommit() bool {
+	repository := g.getRepository()
+	if repository == nil {
+		return false
+	}
+
+	branch, head, err := repository.Head()
+	if err != nil || head.IsZero() {
+		return false
+	}
+
+	commit, err := repository.Commit(head)
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+
+	g.commit.Author.Name = commit.Author.Name
+	g.commit.Author.Email = commit.Author.Email
+	g.commit.Committer.Name = commit.Committer.Name
+	g.commit.Committer.Email = commit.Committer.Email
+	g.commit.Timestamp = commit.Author.When
+	g.commit.Subject = commit.Subject
+	g.commit.Sha = head.String()
+
+	for _, ref := range repository.RefsAt(head) {
+		switch {
+		case strings.HasSuffix(ref, "HEAD"):
+			continue
+		case strings.HasPrefix(ref, "refs/tags/"):
+			g.commit.Refs.Tags = append(g.commit.Refs.Tags, strings.TrimPrefix(ref, "refs/tags/"))
+		case strings.HasPrefix(ref, "refs/remotes/"):
+			g.commit.Refs.Remotes = append(g.commit.Refs.Remotes, strings.TrimPrefix(ref, "refs/remotes/"))
+		case strings.HasPrefix(ref, "refs/heads/"):
+			name := strings.TrimPrefix(ref, "refs/heads/")
+			// the checked out branch comes first
+			if name == branch {
+				g.commit.Refs.Heads = append([]string{name}, g.commit.Refs.Heads...)
+				continue
+			}
+
+			g.commit.Refs.Heads = append(g.commit.Refs.Heads, name)
+		}
+	}
+
+	return true
+}
+
+// nativeTag returns the tag pointing to HEAD, ok is false when we need to fall back to the CLI
+func (g *Git) nativeTag() (tag string, ok bool) {
+	repository := g.getRepository()
+	if repository == nil {
+		return "", false
+	}
+
+	_, head, err := repository.Head()
+	if err != nil {
+		return "", false
+	}
+
+	if head.IsZero() {
+		return "", true
+	}
+
+	tags := repository.TagsAt(head)
+	if len(tags) == 0 {
+		return "", true
+	}
+
+	return tags[0], true
+}
+
+// nativeBranchAt returns the branch pointing to the given commit, if any
+func (g *Git) nativeBranchAt(sha string) string {
+	repository := g.getRepository()
+	if repository == nil {
+		return ""
+	}
+
+	hash, err := git.ParseHash(sha)
+	if err != nil {
+		return ""
+	}
+
+	return repository.BranchAt(hash)
+}
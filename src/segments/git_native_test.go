@@ -0,0 +1,122 @@
+package segments
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/properties"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/mock"
+
+	"github.com/stretchr/testify/assert"
+	testify_ "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitNative(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "Jan De Dobbeleer")
+	t.Setenv("GIT_AUTHOR_EMAIL", "jan@ohmyposh.dev")
+	t.Setenv("GIT_COMMITTER_NAME", "Jan De Dobbeleer")
+	t.Setenv("GIT_COMMITTER_EMAIL", "jan@ohmyposh.dev")
+
+	root := t.TempDir()
+	remote := filepath.Join(root, "remote")
+	repo := filepath.Join(root, "repo")
+
+	run := func(dir string, args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+		return strings.TrimSpace(string(output))
+	}
+
+	write := func(file, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(repo, file), []byte(content), 0o644))
+	}
+
+	run(root, "init", "--quiet", "--bare", "--initial-branch", branchName, remote)
+	run(root, "clone", "--quiet", remote, repo)
+
+	write("README.md", "# posh")
+	write("main.go", "package main")
+	run(repo, "add", "--all")
+	run(repo, "commit", "--quiet", "--message", "initial commit")
+	run(repo, "push", "--quiet", "origin", branchName)
+	run(repo, "commit", "--quiet", "--allow-empty", "--message", "local commit")
+	run(repo, "tag", "--annotate", "--message", "release", "v1.0.0")
+
+	// someone else pushes two commits, the repository is behind after fetching them
+	other := filepath.Join(root, "other")
+	run(root, "clone", "--quiet", remote, other)
+	run(other, "commit", "--quiet", "--allow-empty", "--message", "remote commit")
+	run(other, "commit", "--quiet", "--allow-empty", "--message", "another remote commit")
+	run(other, "push", "--quiet", "origin", branchName)
+	run(repo, "fetch", "--quiet")
+
+	write("README.md", "# oh-my-posh")
+	write("new.go", "package main")
+	run(repo, "add", "new.go")
+	write("notes.txt", "notes")
+
+	env := new(mock.Environment)
+	env.On("HasFolder", testify_.Anything).Return(false)
+	env.On("HasFilesInDir", testify_.Anything, testify_.Anything).Return(false)
+
+	g := &Git{
+		scm: scm{
+			workingDir: filepath.Join(repo, ".git"),
+			rootDir:    filepath.Join(repo, ".git"),
+			realDir:    repo,
+			command:    GITCOMMAND,
+		},
+	}
+	g.Init(properties.Map{Native: true, FetchStatus: true}, env)
+
+	// the mock environment panics when we shell out to git
+	g.setGitStatus()
+	g.setGitHEADContext()
+	g.setBranchStatus()
+
+	assert.Equal(t, branchName, g.Ref)
+	assert.Equal(t, run(repo, "rev-parse", "HEAD"), g.Hash)
+	assert.Equal(t, "origin/main", g.Upstream)
+	assert.Equal(t, 1, g.Ahead)
+	assert.Equal(t, 2, g.Behind)
+	assert.Equal(t, "\u21911 \u21932", g.BranchStatus)
+	assert.False(t, g.UpstreamGone)
+	assert.Equal(t, "?1 ~1", g.Working.String())
+	assert.Equal(t, "+1", g.Staging.String())
+
+	commit := g.Commit()
+	assert.Equal(t, "local commit", commit.Subject)
+	assert.Equal(t, "jan@ohmyposh.dev", commit.Author.Email)
+	assert.Equal(t, []string{branchName}, commit.Refs.Heads)
+	assert.Equal(t, []string{"v1.0.0"}, commit.Refs.Tags)
+
+	run(repo, "checkout", "--quiet", "--detach", "v1.0.0")
+	env.On("FileContent", filepath.Join(repo, ".git")+"/HEAD").Return(run(repo, "rev-parse", "HEAD"))
+
+	g = &Git{
+		scm: scm{
+			workingDir: filepath.Join(repo, ".git"),
+			rootDir:    filepath.Join(repo, ".git"),
+			realDir:    repo,
+			command:    GITCOMMAND,
+		},
+	}
+	g.Init(properties.Map{Native: true}, env)
+	g.setPrettyHEADName()
+
+	assert.Equal(t, "v1.0.0", g.HEAD)
+}
@@ -62,6 +62,40 @@ func TestEnabledInWorkingDirectory(t *testing.T) {
 	assert.Equal(t, fileInfo.Path, g.workingDir)
 }
 
+func TestEnabledInJjRepository(t *testing.T) {
+	cases := []struct {
+		Case     string
+		Disable  bool
+		HasJj    bool
+		Expected bool
+	}{
+		{Case: "Colocated", HasJj: true, Expected: true},
+		{Case: "Colocated, disabled", Disable: true, HasJj: true},
+		{Case: "Git only, disabled", Disable: true, Expected: true},
+	}
+
+	fileInfo := &runtime.FileInfo{
+		Path:         "/dir/.git",
+		ParentFolder: "/dir",
+		IsDir:        true,
+	}
+
+	for _, tc := range cases {
+		env := new(mock.Environment)
+		env.On("InWSLSharedDrive").Return(false)
+		env.On("HasCommand", "git").Return(true)
+		env.On("GOOS").Return("")
+		env.On("HasParentFilePath", ".git", true).Return(fileInfo, nil)
+		env.On("HasFolder", filepath.Join("/dir", ".jj")).Return(tc.HasJj)
+		env.On("Home").Return(poshHome)
+
+		g := &Git{}
+		g.Init(properties.Map{DisableWithJj: tc.Disable}, env)
+
+		assert.Equal(t, tc.Expected, g.shouldDisplay(), tc.Case)
+	}
+}
+
 func TestResolveEmptyGitPath(t *testing.T) {
 	base := "base"
 	assert.Equal(t, base, resolveGitPath(base, ""))
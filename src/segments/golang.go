@@ -1,6 +1,10 @@
 package segments
 
 import (
+	"errors"
+	"path/filepath"
+	"strings"
+
 	"github.com/jandedobbeleer/oh-my-posh/src/properties"
 
 	"golang.org/x/mod/modfile"
@@ -26,9 +30,10 @@ func (g *Golang) Enabled() bool {
 			getVersion: g.getVersion,
 		},
 		{
-			executable: "go",
-			args:       []string{"version"},
-			regex:      `(?:go(?P<version>((?P<major>[0-9]+).(?P<minor>[0-9]+)(.(?P<patch>[0-9]+))?)))`,
+			executable:  "go",
+			args:        []string{"version"},
+			readVersion: g.readVersion,
+			regex:       `(?:go(?P<version>((?P<major>[0-9]+).(?P<minor>[0-9]+)(.(?P<patch>[0-9]+))?)))`,
 		},
 	}
 	g.versionURLTemplate = "https://golang.org/doc/go{{ .Major }}.{{ .Minor }}"
@@ -54,3 +59,21 @@ func (g *Golang) getVersion() (string, error) {
 
 	return file.Go.Version, nil
 }
+
+// readVersion reads the version from the VERSION file in GOROOT
+func (g *Golang) readVersion(executable string) (string, error) {
+	goRoot := g.language.env.Getenv("GOROOT")
+	if len(goRoot) == 0 {
+		goRoot = g.language.installFolder(executable)
+	}
+
+	content := g.language.env.FileContent(filepath.Join(goRoot, "VERSION"))
+	line, _, _ := strings.Cut(content, "\n")
+
+	version, found := strings.CutPrefix(strings.TrimSpace(line), "go")
+	if !found {
+		return "", errors.New("no go version found in " + goRoot)
+	}
+
+	return version, nil
+}
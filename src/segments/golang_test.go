@@ -4,9 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/jandedobbeleer/oh-my-posh/src/properties"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/mock"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -67,3 +70,36 @@ func TestGolang(t *testing.T) {
 		assert.Equal(t, tc.ExpectedString, renderTemplate(env, g.Template(), g), fmt.Sprintf("Failed in case: %s", tc.Case))
 	}
 }
+
+func TestGolangReadVersion(t *testing.T) {
+	cases := []struct {
+		Case            string
+		GoRoot          string
+		Content         string
+		ExpectedVersion string
+		ExpectedError   bool
+	}{
+		{Case: "GOROOT", GoRoot: "/usr/local/go", Content: "go1.22.3\ntime 2024-04-30T19:25:16Z\n", ExpectedVersion: "1.22.3"},
+		{Case: "Installation folder", Content: "go1.21.0", ExpectedVersion: "1.21.0"},
+		{Case: "No VERSION file", GoRoot: "/usr/local/go", ExpectedError: true},
+	}
+
+	for _, tc := range cases {
+		goRoot := tc.GoRoot
+		if len(goRoot) == 0 {
+			goRoot = filepath.Join("/opt", "homebrew", "Cellar", "go", "1.21.0", "libexec")
+		}
+
+		env := new(mock.Environment)
+		env.On("Getenv", "GOROOT").Return(tc.GoRoot)
+		env.On("ResolveSymlink", "/opt/homebrew/bin/go").Return(filepath.Join(goRoot, "bin", "go"), nil)
+		env.On("FileContent", filepath.Join(goRoot, "VERSION")).Return(tc.Content)
+
+		g := &Golang{}
+		g.Init(properties.Map{}, env)
+
+		version, err := g.readVersion("/opt/homebrew/bin/go")
+		assert.Equal(t, tc.ExpectedError, err != nil, tc.Case)
+		assert.Equal(t, tc.ExpectedVersion, version, tc.Case)
+	}
+}
@@ -0,0 +1,192 @@
+package segments
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	http2 "net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/properties"
+	"github.com/jandedobbeleer/oh-my-posh/src/template"
+)
+
+// HTTP requests a JSON document and exposes the values at the configured paths to the templates
+type HTTP struct {
+	base
+
+	Fields map[string]any
+}
+
+const (
+	// Method is the HTTP method to use
+	Method properties.Property = "method"
+	// RequestBody to send with the request
+	RequestBody properties.Property = "body"
+	// ResponseFields maps template field names to paths in the response
+	ResponseFields properties.Property = "fields"
+)
+
+func (h *HTTP) Template() string {
+	return " {{ .Text }} "
+}
+
+func (h *HTTP) Enabled() bool {
+	url, err := h.render(h.props.GetString(URL, ""))
+	if err != nil || len(url) == 0 {
+		log.Error(errors.New("no valid url configured for the http segment"))
+		return false
+	}
+
+	response, err := h.request(url)
+	if err != nil {
+		return false
+	}
+
+	var data any
+	if err := json.Unmarshal(response, &data); err != nil {
+		log.Error(err)
+		return false
+	}
+
+	h.Fields = h.extract(data)
+
+	return len(h.Fields) != 0
+}
+
+func (h *HTTP) request(url string) ([]byte, error) {
+	method := strings.ToUpper(h.props.GetString(Method, http2.MethodGet))
+
+	headers := make(map[string]string)
+	for key, value := range h.props.GetKeyValueMap(Headers, map[string]string{}) {
+		// header values can use templates to read secrets from environment variables
+		rendered, err := h.render(value)
+		if err != nil {
+			return nil, err
+		}
+
+		headers[http2.CanonicalHeaderKey(key)] = rendered
+	}
+
+	var body io.Reader
+	if content := h.props.GetString(RequestBody, ""); len(content) != 0 {
+		rendered, err := h.render(content)
+		if err != nil {
+			return nil, err
+		}
+
+		body = strings.NewReader(rendered)
+
+		if _, found := headers["Content-Type"]; !found {
+			headers["Content-Type"] = "application/json"
+		}
+	}
+
+	modifiers := func(request *http2.Request) {
+		request.Method = method
+
+		for key, value := range headers {
+			request.Header.Set(key, value)
+		}
+	}
+
+	httpTimeout := h.props.GetInt(properties.HTTPTimeout, properties.DefaultHTTPTimeout)
+
+	return h.env.HTTPRequest(url, body, httpTimeout, modifiers)
+}
+
+// extract returns the values at the configured paths, or the response itself
+// when no fields are configured and it's an object
+func (h *HTTP) extract(data any) map[string]any {
+	paths := h.props.GetKeyValueMap(ResponseFields, map[string]string{})
+
+	if len(paths) == 0 {
+		object, _ := data.(map[string]any)
+		return object
+	}
+
+	fields := make(map[string]any)
+
+	for name, path := range paths {
+		value, found := jsonPath(data, path)
+		if !found {
+			log.Debugf("no value found for %s at %s", name, path)
+			continue
+		}
+
+		fields[name] = value
+	}
+
+	return fields
+}
+
+func (h *HTTP) render(text string) (string, error) {
+	tmpl := &template.Text{
+		Template: text,
+		Context:  h,
+	}
+
+	return tmpl.Render()
+}
+
+// TemplateData exposes the extracted fields to the templates, next to
+// the rendered text for cross-segment references.
+func (h *HTTP) TemplateData() map[string]any {
+	return fieldsData(h.Fields, h.Output)
+}
+
+// jsonPath returns the value at a gjson style path. Keys are separated by dots, numbers
+// index arrays and # returns the length of an array. Use \. to match a dot in a key.
+func jsonPath(data any, path string) (any, bool) {
+	if len(path) == 0 {
+		return data, true
+	}
+
+	var keys []string
+	var key strings.Builder
+
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			key.WriteByte('.')
+			i++
+		case path[i] == '.':
+			keys = append(keys, key.String())
+			key.Reset()
+		default:
+			key.WriteByte(path[i])
+		}
+	}
+
+	keys = append(keys, key.String())
+
+	for _, key := range keys {
+		switch value := data.(type) {
+		case map[string]any:
+			child, found := value[key]
+			if !found {
+				return nil, false
+			}
+
+			data = child
+		case []any:
+			if key == "#" {
+				data = len(value)
+				continue
+			}
+
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(value) {
+				return nil, false
+			}
+
+			data = value[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return data, true
+}
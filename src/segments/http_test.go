@@ -0,0 +1,167 @@
+package segments
+
+import (
+	"io"
+	http2 "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/properties"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTP(t *testing.T) {
+	server := httptest.NewServer(http2.HandlerFunc(func(w http2.ResponseWriter, r *http2.Request) {
+		switch r.URL.Path {
+		case "/status":
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http2.StatusUnauthorized)
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"status": {"indicator": "minor", "description": "Partial outage"},
+				"components": [{"name": "api"}, {"name": "web"}], "build.version": "1.2.3"}`))
+		case "/deploy":
+			body, _ := io.ReadAll(r.Body)
+			if r.Method != http2.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+				w.WriteHeader(http2.StatusMethodNotAllowed)
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"request": ` + string(body) + `}`))
+		case "/list":
+			_, _ = w.Write([]byte(`[1, 2, 3]`))
+		case "/text":
+			_, _ = w.Write([]byte(`hello`))
+		default:
+			w.WriteHeader(http2.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		Case            string
+		Props           properties.Map
+		Template        string
+		ExpectedString  string
+		ExpectedEnabled bool
+	}{
+		{
+			Case: "Fields",
+			Props: properties.Map{
+				URL:     "{{ .Env.STATUS_URL }}/status",
+				Headers: map[string]any{"authorization": "Bearer {{ .Env.STATUS_TOKEN }}"},
+				ResponseFields: map[string]any{
+					"Indicator":  "status.indicator",
+					"Components": "components.#",
+					"First":      "components.0.name",
+					"Version":    `build\.version`,
+					"Missing":    "status.missing",
+				},
+			},
+			Template:        "{{ .Indicator }} {{ .Components }} {{ .First }} {{ .Version }}{{ .Missing }}",
+			ExpectedEnabled: true,
+			ExpectedString:  "minor 2 api 1.2.3",
+		},
+		{
+			Case:  "Unauthorized",
+			Props: properties.Map{URL: server.URL + "/status"},
+		},
+		{
+			Case: "Post with a body",
+			Props: properties.Map{
+				URL:         server.URL + "/deploy",
+				Method:      "post",
+				RequestBody: `{"Text": "{{ .Env.STATUS_TOKEN }}"}`,
+			},
+			Template:        "{{ .request.Text }}",
+			ExpectedEnabled: true,
+			ExpectedString:  "secret",
+		},
+		{
+			Case:            "Array with fields",
+			Props:           properties.Map{URL: server.URL + "/list", ResponseFields: map[string]any{"Text": "#"}},
+			ExpectedEnabled: true,
+			ExpectedString:  "3",
+		},
+		{
+			Case:  "Array without fields",
+			Props: properties.Map{URL: server.URL + "/list"},
+		},
+		{
+			Case:  "Invalid JSON",
+			Props: properties.Map{URL: server.URL + "/text"},
+		},
+		{
+			Case:  "Not found",
+			Props: properties.Map{URL: server.URL + "/missing"},
+		},
+		{
+			Case: "No URL",
+		},
+	}
+
+	for _, tc := range cases {
+		env := new(mock.Environment)
+		env.On("Getenv", "STATUS_URL").Return(server.URL)
+		env.On("Getenv", "STATUS_TOKEN").Return("secret")
+
+		// initializes the template environment
+		renderTemplate(env, "", nil)
+
+		if tc.Props == nil {
+			tc.Props = properties.Map{}
+		}
+
+		tc.Props[properties.HTTPTimeout] = 1000
+
+		h := &HTTP{}
+		h.Init(tc.Props, &runtime.Terminal{CmdFlags: &runtime.Flags{}})
+
+		assert.Equal(t, tc.ExpectedEnabled, h.Enabled(), tc.Case)
+		if !tc.ExpectedEnabled {
+			continue
+		}
+
+		if len(tc.Template) == 0 {
+			tc.Template = h.Template()
+		}
+
+		assert.Equal(t, tc.ExpectedString, renderTemplate(env, tc.Template, h), tc.Case)
+	}
+}
+
+func TestJSONPath(t *testing.T) {
+	data := map[string]any{
+		"a": map[string]any{
+			"b": []any{"x", map[string]any{"c": true}},
+		},
+		"d.e": 1.5,
+	}
+
+	cases := []struct {
+		Expected any
+		Case     string
+		Path     string
+		Found    bool
+	}{
+		{Case: "Root", Path: "", Expected: data, Found: true},
+		{Case: "Array index", Path: "a.b.0", Expected: "x", Found: true},
+		{Case: "Nested", Path: "a.b.1.c", Expected: true, Found: true},
+		{Case: "Array length", Path: "a.b.#", Expected: 2, Found: true},
+		{Case: "Escaped dot", Path: `d\.e`, Expected: 1.5, Found: true},
+		{Case: "Unknown key", Path: "a.x"},
+		{Case: "Index out of range", Path: "a.b.2"},
+		{Case: "Key on array", Path: "a.b.c"},
+		{Case: "Key on value", Path: "a.b.0.x"},
+	}
+
+	for _, tc := range cases {
+		value, found := jsonPath(data, tc.Path)
+		assert.Equal(t, tc.Found, found, tc.Case)
+		assert.Equal(t, tc.Expected, value, tc.Case)
+	}
+}
@@ -1,7 +1,10 @@
 package segments
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
 )
 
 type Java struct {
@@ -21,9 +24,10 @@ func (j *Java) Enabled() bool {
 func (j *Java) init() {
 	javaRegex := `(?: JRE)(?: \(.*\))? \((?P<version>(?P<major>[0-9]+)(?:\.(?P<minor>[0-9]+))?(?:\.(?P<patch>[0-9]+))?).*\),`
 	javaCmd := &cmd{
-		executable: "java",
-		args:       []string{"-Xinternalversion"},
-		regex:      javaRegex,
+		executable:  "java",
+		args:        []string{"-Xinternalversion"},
+		regex:       javaRegex,
+		readVersion: j.readVersion,
 	}
 
 	j.extensions = []string{
@@ -47,9 +51,10 @@ func (j *Java) init() {
 		java := fmt.Sprintf("%s/bin/java", javaHome)
 		j.commands = []*cmd{
 			{
-				executable: java,
-				args:       []string{"-Xinternalversion"},
-				regex:      javaRegex,
+				executable:  java,
+				args:        []string{"-Xinternalversion"},
+				regex:       javaRegex,
+				readVersion: j.readVersion,
 			},
 			javaCmd,
 		}
@@ -58,3 +63,25 @@ func (j *Java) init() {
 
 	j.commands = []*cmd{javaCmd}
 }
+
+// readVersion reads the JAVA_VERSION from the release file of the JDK or JRE
+func (j *Java) readVersion(executable string) (string, error) {
+	javaHome := j.env.Getenv("JAVA_HOME")
+	if len(javaHome) == 0 {
+		javaHome = j.installFolder(executable)
+	}
+
+	release := j.env.FileContent(filepath.Join(javaHome, "release"))
+	for _, line := range strings.Split(release, "\n") {
+		value, found := strings.CutPrefix(strings.TrimSpace(line), "JAVA_VERSION=")
+		if !found {
+			continue
+		}
+
+		// Java 8 and older use 1.8.0_392
+		version, _, _ := strings.Cut(strings.Trim(value, `"`), "_")
+		return version, nil
+	}
+
+	return "", errors.New("no java version found in " + javaHome)
+}
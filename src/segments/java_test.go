@@ -2,8 +2,12 @@ package segments
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
 
+	"github.com/jandedobbeleer/oh-my-posh/src/properties"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/mock"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -75,3 +79,37 @@ func TestJava(t *testing.T) {
 		assert.Equal(t, tc.ExpectedString, renderTemplate(env, j.Template(), j), fmt.Sprintf("Failed in case: %s", tc.Case))
 	}
 }
+
+func TestJavaReadVersion(t *testing.T) {
+	cases := []struct {
+		Case            string
+		JavaHome        string
+		Release         string
+		ExpectedVersion string
+		ExpectedError   bool
+	}{
+		{Case: "JAVA_HOME", JavaHome: "/usr/lib/jvm/temurin-21", Release: "IMPLEMENTOR=\"Eclipse Adoptium\"\nJAVA_VERSION=\"21.0.1\"\n", ExpectedVersion: "21.0.1"},
+		{Case: "Java 8", JavaHome: "/usr/lib/jvm/java-8", Release: "JAVA_VERSION=\"1.8.0_392\"", ExpectedVersion: "1.8.0"},
+		{Case: "Installation folder", Release: "JAVA_VERSION=\"17.0.9\"", ExpectedVersion: "17.0.9"},
+		{Case: "No release file", JavaHome: "/usr/lib/jvm/temurin-21", ExpectedError: true},
+	}
+
+	for _, tc := range cases {
+		javaHome := tc.JavaHome
+		if len(javaHome) == 0 {
+			javaHome = filepath.Join("/usr", "lib", "jvm", "java-17")
+		}
+
+		env := new(mock.Environment)
+		env.On("Getenv", "JAVA_HOME").Return(tc.JavaHome)
+		env.On("ResolveSymlink", "/usr/bin/java").Return(filepath.Join(javaHome, "bin", "java"), nil)
+		env.On("FileContent", filepath.Join(javaHome, "release")).Return(tc.Release)
+
+		j := &Java{}
+		j.Init(properties.Map{}, env)
+
+		version, err := j.readVersion("/usr/bin/java")
+		assert.Equal(t, tc.ExpectedError, err != nil, tc.Case)
+		assert.Equal(t, tc.ExpectedVersion, version, tc.Case)
+	}
+}
@@ -0,0 +1,196 @@
+package segments
+
+import (
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/path"
+)
+
+// JjStatus represents part of the status of a Jujutsu repository
+type JjStatus struct {
+	ScmStatus
+}
+
+func (s *JjStatus) add(code string) {
+	// M = modified
+	// A = added
+	// D = deleted
+	// R = renamed
+	// C = copied
+	switch code {
+	case "M":
+		s.Modified++
+	case "A", "C":
+		s.Added++
+	case "D":
+		s.Deleted++
+	case "R":
+		s.Moved++
+	}
+}
+
+const (
+	JJCOMMAND = "jj"
+	// JJLOGTEMPLATE prints the working copy change, one value per line prefixed with an anchor
+	JJLOGTEMPLATE = `"ci:" ++ change_id ++ "\n" ++ ` +
+		`"cs:" ++ change_id.shortest(8) ++ "\n" ++ ` +
+		`"co:" ++ commit_id ++ "\n" ++ ` +
+		`"os:" ++ commit_id.shortest(8) ++ "\n" ++ ` +
+		`"bm:" ++ bookmarks.map(|b| b.name()).join(",") ++ "\n" ++ ` +
+		`"cf:" ++ if(conflict, "1") ++ "\n" ++ ` +
+		`"em:" ++ if(empty, "1") ++ "\n" ++ ` +
+		`"dv:" ++ if(divergent, "1") ++ "\n" ++ ` +
+		`"un:" ++ author.name() ++ "\n" ++ ` +
+		`"dn:" ++ description.first_line() ++ "\n"`
+)
+
+type Jj struct {
+	Working       *JjStatus
+	ChangeID      string
+	ShortChangeID string
+	CommitID      string
+	ShortCommitID string
+	Author        string
+	Description   string
+	Bookmarks     []string
+	scm
+	Conflict  bool
+	Empty     bool
+	Divergent bool
+}
+
+func (jj *Jj) Template() string {
+	return " {{ if .Bookmarks }}\uf097 {{ join \" \" .Bookmarks }}{{ else }}\ue729 {{ .ShortChangeID }}{{ end }}{{ if .Conflict }} \uf071{{ end }}{{ if .Working.Changed }} \uf044 {{ .Working.String }}{{ end }} " //nolint: lll
+}
+
+func (jj *Jj) Enabled() bool {
+	if !jj.shouldDisplay() {
+		return false
+	}
+
+	jj.setHeadContext()
+
+	return true
+}
+
+func (jj *Jj) shouldDisplay() bool {
+	if !jj.hasCommand(JJCOMMAND) {
+		return false
+	}
+
+	jjDir, err := jj.env.HasParentFilePath(".jj", false)
+	if err != nil || !jjDir.IsDir {
+		return false
+	}
+
+	jj.workingDir = jjDir.Path
+	jj.rootDir = jjDir.Path
+	// convert the worktree file path to a windows one when in a WSL shared folder
+	jj.realDir = strings.TrimSuffix(jj.convertToWindowsPath(jjDir.Path), "/.jj")
+	jj.RepoName = path.Base(jj.convertToLinuxPath(jj.realDir))
+	jj.setDir(jjDir.Path)
+
+	return true
+}
+
+func (jj *Jj) CacheKey() (string, bool) {
+	dir, err := jj.env.HasParentFilePath(".jj", true)
+	if err != nil {
+		return "", false
+	}
+
+	return dir.Path, true
+}
+
+func (jj *Jj) setDir(dir string) {
+	dir = path.ReplaceHomeDirPrefixWithTilde(dir) // align with template PWD
+
+	if jj.env.GOOS() == runtime.WINDOWS {
+		jj.Dir = strings.TrimSuffix(dir, `\.jj`)
+		return
+	}
+
+	jj.Dir = strings.TrimSuffix(dir, "/.jj")
+}
+
+func (jj *Jj) setHeadContext() {
+	jj.setChangeContext()
+
+	statusFormats := jj.props.GetKeyValueMap(StatusFormats, map[string]string{})
+	jj.Working = &JjStatus{ScmStatus: ScmStatus{Formats: statusFormats}}
+
+	displayStatus := jj.props.GetBool(FetchStatus, true)
+	if !displayStatus {
+		return
+	}
+
+	// the working copy was snapshotted by the log command, no need to do that twice
+	changes := jj.getJjCommandOutput("diff", "--summary", "--ignore-working-copy", "--color", "never")
+	if len(changes) == 0 {
+		return
+	}
+
+	lines := strings.Split(changes, "\n")
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		jj.Working.add(line[0:1])
+	}
+}
+
+func (jj *Jj) setChangeContext() {
+	body := jj.getJjCommandOutput("log", "--revisions", "@", "--no-graph", "--color", "never", "--template", JJLOGTEMPLATE)
+	if len(body) == 0 {
+		return
+	}
+
+	splitted := strings.Split(body, "\n")
+	for _, line := range splitted {
+		line = strings.TrimSpace(line)
+		if len(line) <= 3 {
+			continue
+		}
+
+		anchor := line[:3]
+		line = line[3:]
+
+		switch anchor {
+		case "ci:":
+			jj.ChangeID = line
+		case "cs:":
+			jj.ShortChangeID = line
+		case "co:":
+			jj.CommitID = line
+		case "os:":
+			jj.ShortCommitID = line
+		case "bm:":
+			for _, bookmark := range strings.Split(line, ",") {
+				jj.Bookmarks = append(jj.Bookmarks, jj.formatBranch(bookmark))
+			}
+		case "cf:":
+			jj.Conflict = true
+		case "em:":
+			jj.Empty = true
+		case "dv:":
+			jj.Divergent = true
+		case "un:":
+			jj.Author = line
+		case "dn:":
+			jj.Description = line
+		}
+	}
+}
+
+func (jj *Jj) getJjCommandOutput(command string, args ...string) string {
+	args = append([]string{command}, args...)
+	val, err := jj.env.RunCommand(jj.command, args...)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(val)
+}
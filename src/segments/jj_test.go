@@ -0,0 +1,151 @@
+package segments
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/properties"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJjShouldDisplay(t *testing.T) {
+	cases := []struct {
+		Case     string
+		HasJj    bool
+		InRepo   bool
+		Expected bool
+	}{
+		{Case: "Jujutsu not installed"},
+		{Case: "Jujutsu installed, not in repo", HasJj: true},
+		{Case: "Jujutsu installed, in repo", HasJj: true, InRepo: true, Expected: true},
+	}
+
+	fileInfo := &runtime.FileInfo{
+		Path:         "/jj/repo/.jj",
+		ParentFolder: "/jj/repo",
+		IsDir:        true,
+	}
+
+	for _, tc := range cases {
+		env := new(mock.Environment)
+		env.On("HasCommand", "jj").Return(tc.HasJj)
+		env.On("InWSLSharedDrive").Return(false)
+		env.On("GOOS").Return(runtime.LINUX)
+		env.On("Home").Return("/usr/home/jj")
+
+		if tc.InRepo {
+			env.On("HasParentFilePath", ".jj", false).Return(fileInfo, nil)
+		} else {
+			env.On("HasParentFilePath", ".jj", false).Return(&runtime.FileInfo{}, errors.New("error"))
+		}
+
+		jj := &Jj{}
+		jj.Init(properties.Map{}, env)
+
+		got := jj.shouldDisplay()
+		assert.Equal(t, tc.Expected, got, tc.Case)
+
+		if !tc.Expected {
+			continue
+		}
+
+		assert.Equal(t, "/jj/repo/.jj", jj.workingDir, tc.Case)
+		assert.Equal(t, "/jj/repo", jj.realDir, tc.Case)
+		assert.Equal(t, "/jj/repo", jj.Dir, tc.Case)
+		assert.Equal(t, "repo", jj.RepoName, tc.Case)
+	}
+}
+
+func TestJjSetHeadContext(t *testing.T) {
+	cases := []struct {
+		Case              string
+		Log               string
+		Diff              string
+		ExpectedStatus    string
+		ExpectedBookmarks []string
+		FetchStatus       bool
+		ExpectedConflict  bool
+		ExpectedEmpty     bool
+		ExpectedDivergent bool
+	}{
+		{
+			Case: "No output",
+		},
+		{
+			Case: "Empty change",
+			Log: `
+			ci:kkmpptxzrspxrzommnulwmwkkqwworpl
+			cs:kkmpptxz
+			co:fd1bb3b1e0a4c6d1bc7cc3b1e4a1d1e8ff0f1c2a
+			os:fd1bb3b1
+			bm:
+			cf:
+			em:1
+			dv:
+			un:Jan De Dobbeleer
+			dn:
+			`,
+			ExpectedEmpty: true,
+		},
+		{
+			Case: "Conflicted, divergent with bookmarks and changes",
+			Log: `
+			ci:kkmpptxzrspxrzommnulwmwkkqwworpl
+			cs:kkmpptxz
+			co:fd1bb3b1e0a4c6d1bc7cc3b1e4a1d1e8ff0f1c2a
+			os:fd1bb3b1
+			bm:main,feature/jj-segment
+			cf:1
+			em:
+			dv:1
+			un:Jan De Dobbeleer
+			dn:add the jj segment
+			`,
+			Diff: `
+			M src/segments/jj.go
+			M src/segments/git.go
+			A src/segments/jj_test.go
+			C src/segments/copy.go
+			D src/segments/old.go
+			R src/segments/{a.go => b.go}
+			`,
+			FetchStatus:       true,
+			ExpectedStatus:    "+2 ~2 -1 >1",
+			ExpectedBookmarks: []string{"main", "feature/jj-segment"},
+			ExpectedConflict:  true,
+			ExpectedDivergent: true,
+		},
+	}
+
+	for _, tc := range cases {
+		env := new(mock.Environment)
+		env.On("RunCommand", "jj", []string{"log", "--revisions", "@", "--no-graph", "--color", "never", "--template", JJLOGTEMPLATE}).Return(tc.Log, nil)
+		env.On("RunCommand", "jj", []string{"diff", "--summary", "--ignore-working-copy", "--color", "never"}).Return(tc.Diff, nil)
+
+		jj := &Jj{
+			scm: scm{
+				command: JJCOMMAND,
+			},
+		}
+		jj.Init(properties.Map{FetchStatus: tc.FetchStatus}, env)
+
+		jj.setHeadContext()
+
+		assert.Equal(t, tc.ExpectedStatus, jj.Working.String(), tc.Case)
+		assert.Equal(t, tc.ExpectedBookmarks, jj.Bookmarks, tc.Case)
+		assert.Equal(t, tc.ExpectedConflict, jj.Conflict, tc.Case)
+		assert.Equal(t, tc.ExpectedEmpty, jj.Empty, tc.Case)
+		assert.Equal(t, tc.ExpectedDivergent, jj.Divergent, tc.Case)
+
+		if len(tc.Log) == 0 {
+			continue
+		}
+
+		assert.Equal(t, "kkmpptxz", jj.ShortChangeID, tc.Case)
+		assert.Equal(t, "fd1bb3b1", jj.ShortCommitID, tc.Case)
+		assert.Equal(t, "Jan De Dobbeleer", jj.Author, tc.Case)
+	}
+}
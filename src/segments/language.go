@@ -27,6 +27,9 @@ type inContext func() bool
 type getVersion func() (string, error)
 type matchesVersionFile func() (string, bool)
 
+// readVersion reads the version of the executable at the given path from its installation files
+type readVersion func(executable string) (string, error)
+
 type version struct {
 	Full          string
 	Major         string
@@ -41,6 +44,7 @@ type version struct {
 
 type cmd struct {
 	getVersion         getVersion
+	readVersion        readVersion
 	executable         string
 	regex              string
 	versionURLTemplate string
@@ -64,6 +68,23 @@ func (c *cmd) parse(versionInfo string) (*version, error) {
 	return version, nil
 }
 
+// parseVersion parses a plain version like 1.22.3 or 21.0.1+12
+func parseVersion(value string) (*version, bool) {
+	values := regex.FindNamedRegexMatch(plainVersionRegex, value)
+	if len(values) == 0 {
+		return nil, false
+	}
+
+	return &version{
+		Full:          values["version"],
+		Major:         values["major"],
+		Minor:         values["minor"],
+		Patch:         values["patch"],
+		Prerelease:    values["prerelease"],
+		BuildMetadata: values["buildmetadata"],
+	}, true
+}
+
 type language struct {
 	base
 
@@ -76,7 +97,11 @@ type language struct {
 	Error              string
 	versionURLTemplate string
 	name               string
+	pinnedVersion      string
+	tool               string
+	fileState          string
 	commands           []*cmd
+	watch              []string
 	projectFiles       []string
 	folders            []string
 	extensions         []string
@@ -104,8 +129,19 @@ const (
 	LanguageExtensions properties.Property = "extensions"
 	// LanguageFolders the list of folders to validate
 	LanguageFolders properties.Property = "folders"
+	// VersionStrategy sets how to resolve the version (execute, files)
+	VersionStrategy properties.Property = "version_strategy"
+	// VersionStrategyExecute executes the binary to get the version
+	VersionStrategyExecute string = "execute"
+	// VersionStrategyFiles reads the version from the installation files, and executes the binary when that fails
+	VersionStrategyFiles string = "files"
 )
 
+// Watch invalidates the version cache when the files matching the patterns change
+func (l *language) Watch(patterns []string) {
+	l.watch = patterns
+}
+
 func (l *language) getName() string {
 	_, file, _, _ := runtime_.Caller(2)
 	base := filepath.Base(file)
@@ -158,18 +194,14 @@ func (l *language) Enabled() bool {
 		return enabled
 	}
 
+	l.pinnedVersion, l.tool = l.toolVersion()
+
 	err := l.setVersion()
 	if err != nil {
 		l.Error = err.Error()
 	}
 
-	if l.matchesVersionFile != nil {
-		expected, match := l.matchesVersionFile()
-		if !match {
-			l.Mismatch = true
-			l.Expected = expected
-		}
-	}
+	l.checkExpectedVersion()
 
 	return enabled
 }
@@ -209,7 +241,11 @@ func (l *language) setVersion() error {
 
 	cacheKey := fmt.Sprintf("version_%s", l.name)
 
-	if versionCache, OK := l.env.Cache().Get(cacheKey); OK {
+	if len(l.watch) != 0 {
+		l.fileState = runtime.FileState(l.env, l.watch)
+	}
+
+	if versionCache, OK := l.env.Cache().Get(cacheKey); OK && !l.watchedFilesChanged(cacheKey) {
 		var version version
 		err := json.Unmarshal([]byte(versionCache), &version)
 		if err == nil {
@@ -219,7 +255,32 @@ func (l *language) setVersion() error {
 		}
 	}
 
+	if l.props.GetBool(ToolManagerVersion, false) && len(l.pinnedVersion) != 0 {
+		if version, ok := l.installedToolVersion(l.tool, l.pinnedVersion); ok {
+			l.version = *version
+			l.buildVersionURL()
+
+			for _, command := range l.commands {
+				if len(command.executable) != 0 {
+					l.version.Executable = command.executable
+					break
+				}
+			}
+
+			return nil
+		}
+	}
+
+	readFiles := l.props.GetString(VersionStrategy, VersionStrategyExecute) == VersionStrategyFiles
+
 	for _, command := range l.commands {
+		if readFiles {
+			if version, ok := l.readVersion(command); ok {
+				l.setCommandVersion(command, version, cacheKey)
+				return nil
+			}
+		}
+
 		versionStr, err := l.runCommand(command)
 		if err != nil {
 			lastError = err
@@ -232,19 +293,7 @@ func (l *language) setVersion() error {
 			continue
 		}
 
-		l.version = *version
-		if command.versionURLTemplate != "" {
-			l.versionURLTemplate = command.versionURLTemplate
-		}
-
-		l.buildVersionURL()
-		l.version.Executable = command.executable
-
-		if marchalled, err := json.Marshal(l.version); err == nil {
-			duration := l.props.GetString(properties.CacheDuration, string(cache.NONE))
-			l.env.Cache().Set(cacheKey, string(marchalled), cache.Duration(duration))
-		}
-
+		l.setCommandVersion(command, version, cacheKey)
 		return nil
 	}
 
@@ -255,6 +304,68 @@ func (l *language) setVersion() error {
 	return errors.New(l.props.GetString(MissingCommandText, ""))
 }
 
+func (l *language) setCommandVersion(command *cmd, version *version, cacheKey string) {
+	l.version = *version
+	if command.versionURLTemplate != "" {
+		l.versionURLTemplate = command.versionURLTemplate
+	}
+
+	l.buildVersionURL()
+	l.version.Executable = command.executable
+
+	if marchalled, err := json.Marshal(l.version); err == nil {
+		duration := l.props.GetString(properties.CacheDuration, string(cache.NONE))
+		l.env.Cache().Set(cacheKey, string(marchalled), cache.Duration(duration))
+
+		if len(l.watch) != 0 {
+			cache.SetFileState(l.env.Cache(), cacheKey, l.fileState, cache.Duration(duration))
+		}
+	}
+}
+
+func (l *language) watchedFilesChanged(cacheKey string) bool {
+	if len(l.watch) == 0 {
+		return false
+	}
+
+	if cache.FileStateChanged(l.env.Cache(), cacheKey, l.fileState) {
+		log.Debugf("watched files changed for %s", l.name)
+		return true
+	}
+
+	return false
+}
+
+// readVersion reads the version from the installation files of the command's executable
+func (l *language) readVersion(command *cmd) (*version, bool) {
+	if command.readVersion == nil {
+		return nil, false
+	}
+
+	executable := l.env.CommandPath(command.executable)
+	if len(executable) == 0 {
+		return nil, false
+	}
+
+	versionStr, err := command.readVersion(executable)
+	if err != nil {
+		log.Error(err)
+		return nil, false
+	}
+
+	return parseVersion(versionStr)
+}
+
+// installFolder returns the folder the executable is installed in, package managers
+// like Homebrew link the executable from their bin folder to the installation.
+func (l *language) installFolder(executable string) string {
+	if target, err := l.env.ResolveSymlink(executable); err == nil {
+		executable = target
+	}
+
+	return filepath.Dir(filepath.Dir(executable))
+}
+
 func (l *language) runCommand(command *cmd) (string, error) {
 	if command.getVersion == nil {
 		if !l.env.HasCommand(command.executable) {
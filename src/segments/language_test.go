@@ -1,9 +1,11 @@
 package segments
 
 import (
+	"errors"
 	"path/filepath"
 	"testing"
 
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
 	cache_ "github.com/jandedobbeleer/oh-my-posh/src/cache/mock"
 	"github.com/jandedobbeleer/oh-my-posh/src/properties"
 	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
@@ -41,6 +43,13 @@ func (l *languageArgs) hasvalue(value string, list []string) bool {
 	return false
 }
 
+// mockNoToolVersionFiles mocks a folder without mise or asdf files
+func mockNoToolVersionFiles(env *mock.Environment) {
+	for _, file := range toolVersionFiles {
+		env.On("HasParentFilePath", file, false).Return(&runtime.FileInfo{}, errors.New("no match at root level"))
+	}
+}
+
 func bootStrapLanguageTest(args *languageArgs) *language {
 	env := new(mock.Environment)
 
@@ -61,6 +70,7 @@ func bootStrapLanguageTest(args *languageArgs) *language {
 
 	env.On("Pwd").Return(cwd)
 	env.On("Home").Return(home)
+	mockNoToolVersionFiles(env)
 
 	cache := &cache_.Cache{}
 	cache.On("Get", mock_.Anything).Return("", false)
@@ -548,6 +558,7 @@ func getMockedLanguageEnv(params *mockedLanguageParams) (*mock.Environment, prop
 	env.On("HasFiles", params.extension).Return(true)
 	env.On("Pwd").Return("/usr/home/project")
 	env.On("Home").Return("/usr/home")
+	mockNoToolVersionFiles(env)
 
 	cache := &cache_.Cache{}
 	cache.On("Get", mock_.Anything).Return("", false)
@@ -595,3 +606,132 @@ func TestNodePackageVersion(t *testing.T) {
 		assert.Equal(t, tc.Version, got, tc.Case)
 	}
 }
+
+func TestLanguageVersionStrategy(t *testing.T) {
+	cases := []struct {
+		ReadErr          error
+		Case             string
+		Strategy         string
+		ReadVersion      string
+		ExpectedVersion  string
+		ExpectedCommands bool
+	}{
+		{Case: "Execute by default", ReadVersion: "2.0.0", ExpectedVersion: universion, ExpectedCommands: true},
+		{Case: "Read from files", Strategy: VersionStrategyFiles, ReadVersion: "2.0.0", ExpectedVersion: "2.0.0"},
+		{Case: "Fallback when the files are missing", Strategy: VersionStrategyFiles, ReadErr: errors.New("no files"), ExpectedVersion: universion, ExpectedCommands: true},
+		{Case: "Fallback when the version is invalid", Strategy: VersionStrategyFiles, ReadVersion: "unicorn", ExpectedVersion: universion, ExpectedCommands: true},
+	}
+
+	for _, tc := range cases {
+		props := properties.Map{}
+		if len(tc.Strategy) != 0 {
+			props[VersionStrategy] = tc.Strategy
+		}
+
+		var readFrom string
+
+		args := &languageArgs{
+			commands: []*cmd{
+				{
+					executable: "unicorn",
+					args:       []string{"--version"},
+					regex:      "(?P<version>.*)",
+					readVersion: func(executable string) (string, error) {
+						readFrom = executable
+						return tc.ReadVersion, tc.ReadErr
+					},
+				},
+			},
+			extensions:        []string{uni},
+			enabledExtensions: []string{uni},
+			enabledCommands:   []string{"unicorn"},
+			version:           universion,
+			properties:        props,
+		}
+		lang := bootStrapLanguageTest(args)
+
+		env := lang.env.(*mock.Environment)
+		env.On("CommandPath", "unicorn").Return("/usr/bin/unicorn")
+
+		assert.True(t, lang.Enabled(), tc.Case)
+		assert.Equal(t, tc.ExpectedVersion, lang.Full, tc.Case)
+		assert.Equal(t, "unicorn", lang.Executable, tc.Case)
+
+		if len(tc.Strategy) != 0 {
+			assert.Equal(t, "/usr/bin/unicorn", readFrom, tc.Case)
+		}
+
+		if tc.ExpectedCommands {
+			env.AssertCalled(t, "RunCommand", "unicorn", []string{"--version"})
+			continue
+		}
+
+		env.AssertNotCalled(t, "RunCommand", "unicorn", []string{"--version"})
+	}
+}
+
+func TestLanguageWatch(t *testing.T) {
+	cases := []struct {
+		Case            string
+		Watch           []string
+		Modify          bool
+		ExpectedCommand bool
+	}{
+		{Case: "No watch", Modify: true},
+		{Case: "Unchanged files", Watch: []string{".unirc"}},
+		{Case: "Changed files", Watch: []string{".unirc"}, Modify: true, ExpectedCommand: true},
+	}
+
+	for _, tc := range cases {
+		folder := "/usr/home/project"
+		unirc := filepath.Join(folder, ".unirc")
+		modified := []*runtime.FileStat{{Path: unirc, Size: 3}}
+		if tc.Modify {
+			modified = []*runtime.FileStat{{Path: unirc, Size: 7}}
+		}
+
+		store := &cache.Store{}
+		store.Init(filepath.Join(t.TempDir(), cache.FileName), false)
+
+		env := new(mock.Environment)
+		env.On("Pwd").Return(folder)
+		env.On("Home").Return("/usr/home")
+		env.On("StatFiles", unirc).Return([]*runtime.FileStat{{Path: unirc, Size: 3}}).Once()
+		env.On("StatFiles", unirc).Return(modified)
+		env.On("HasFiles", uni).Return(true)
+		env.On("HasCommand", "unicorn").Return(true)
+		env.On("RunCommand", "unicorn", []string{"--version"}).Return(universion, nil)
+		env.On("Cache").Return(store)
+		mockNoToolVersionFiles(env)
+
+		newLanguage := func() *language {
+			l := &language{
+				extensions: []string{uni},
+				commands: []*cmd{
+					{
+						executable: "unicorn",
+						args:       []string{"--version"},
+						regex:      "(?P<version>.*)",
+					},
+				},
+			}
+			l.Init(properties.Map{properties.CacheDuration: cache.ONEDAY}, env)
+			l.Watch(tc.Watch)
+			return l
+		}
+
+		assert.True(t, newLanguage().Enabled(), tc.Case)
+		env.AssertNumberOfCalls(t, "RunCommand", 1)
+
+		lang := newLanguage()
+		assert.True(t, lang.Enabled(), tc.Case)
+		assert.Equal(t, universion, lang.Full, tc.Case)
+
+		expectedCalls := 1
+		if tc.ExpectedCommand {
+			expectedCalls = 2
+		}
+
+		env.AssertNumberOfCalls(t, "RunCommand", expectedCalls)
+	}
+}
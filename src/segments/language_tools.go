@@ -0,0 +1,230 @@
+package segments
+
+import (
+	"bufio"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/properties"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+
+	toml "github.com/pelletier/go-toml/v2"
+)
+
+const (
+	// ToolManagerVersion reads the version from the mise or asdf install directory instead of executing the binary
+	ToolManagerVersion properties.Property = "tool_manager_version"
+
+	plainVersionRegex = `^(?P<version>(?P<major>[0-9]+)(?:\.(?P<minor>[0-9]+))?(?:\.(?P<patch>[0-9]+))?(?:-(?P<prerelease>[0-9A-Za-z\-\.]+))?(?:\+(?P<buildmetadata>[0-9A-Za-z\-\.]+))?)$` //nolint:lll
+)
+
+// the files mise and asdf pin versions in, in order of precedence
+var toolVersionFiles = []string{
+	"mise.toml",
+	".mise.toml",
+	".tool-versions",
+}
+
+// languageTools contains the mise and asdf tool names of segments named differently
+var languageTools = map[string][]string{
+	"az_functions": {"azure-functions-core-tools"},
+	"dotnet":       {"dotnet", "dotnet-core"},
+	"golang":       {"go", "golang"},
+	"haskell":      {"ghc", "haskell"},
+	"mvn":          {"maven"},
+	"node":         {"node", "nodejs"},
+}
+
+// toolVersion returns the version and tool name pinned in the closest mise or asdf file
+func (l *language) toolVersion() (string, string) {
+	tools, ok := languageTools[l.name]
+	if !ok {
+		tools = []string{l.name}
+	}
+
+	var files []*runtime.FileInfo
+
+	for _, name := range toolVersionFiles {
+		file, err := l.env.HasParentFilePath(name, false)
+		if err != nil {
+			continue
+		}
+
+		files = append(files, file)
+	}
+
+	// the closest file takes precedence, mise merges the files in parent folders
+	slices.SortStableFunc(files, func(a, b *runtime.FileInfo) int {
+		return len(b.ParentFolder) - len(a.ParentFolder)
+	})
+
+	for _, file := range files {
+		content := l.env.FileContent(file.Path)
+
+		var version, tool string
+		if filepath.Base(file.Path) == ".tool-versions" {
+			version, tool = parseToolVersions(content, tools)
+		} else {
+			version, tool = parseMiseConfig(content, tools)
+		}
+
+		if len(version) != 0 {
+			return version, tool
+		}
+	}
+
+	return "", ""
+}
+
+// parseToolVersions reads the first version of the tool from a .tool-versions file
+func parseToolVersions(content string, tools []string) (string, string) {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !slices.Contains(tools, fields[0]) {
+			continue
+		}
+
+		return fields[1], fields[0]
+	}
+
+	return "", ""
+}
+
+// parseMiseConfig reads the version of the tool from the tools section of a mise.toml file,
+// the value is either a version, a list of versions or a table with a version.
+func parseMiseConfig(content string, tools []string) (string, string) {
+	var config struct {
+		Tools map[string]any `toml:"tools"`
+	}
+
+	if err := toml.Unmarshal([]byte(content), &config); err != nil {
+		log.Error(err)
+		return "", ""
+	}
+
+	for _, tool := range tools {
+		switch value := config.Tools[tool].(type) {
+		case string:
+			return value, tool
+		case []any:
+			if len(value) == 0 {
+				continue
+			}
+
+			if version, ok := value[0].(string); ok {
+				return version, tool
+			}
+		case map[string]any:
+			if version, ok := value["version"].(string); ok {
+				return version, tool
+			}
+		}
+	}
+
+	return "", ""
+}
+
+// checkExpectedVersion compares the version to the one in the language's version file,
+// or the one pinned for mise or asdf when the language has no version file
+func (l *language) checkExpectedVersion() {
+	if l.matchesVersionFile != nil {
+		expected, match := l.matchesVersionFile()
+		if len(expected) != 0 {
+			l.Mismatch = !match
+			if l.Mismatch {
+				l.Expected = expected
+			}
+
+			return
+		}
+	}
+
+	expected := normalizeToolVersion(l.pinnedVersion)
+	if len(expected) == 0 || len(l.version.Full) == 0 {
+		return
+	}
+
+	if !matchesToolVersion(l.version.Full, expected) {
+		l.Mismatch = true
+		l.Expected = expected
+	}
+}
+
+// normalizeToolVersion removes the prefix of versions like v20.1.0 or temurin-21.0.1,
+// versions without a number like latest, system or lts can't be compared.
+func normalizeToolVersion(version string) string {
+	version = strings.TrimPrefix(version, "v")
+
+	startsWithDigit := func(value string) bool {
+		return len(value) != 0 && value[0] >= '0' && value[0] <= '9'
+	}
+
+	if startsWithDigit(version) {
+		return version
+	}
+
+	if index := strings.LastIndex(version, "-"); index != -1 && startsWithDigit(version[index+1:]) {
+		return version[index+1:]
+	}
+
+	return ""
+}
+
+// matchesToolVersion checks if the version is the expected one, or when
+// only the major or minor version is pinned, one of its releases
+func matchesToolVersion(version, expected string) bool {
+	if !strings.HasPrefix(version, expected) {
+		return false
+	}
+
+	if len(version) == len(expected) {
+		return true
+	}
+
+	next := version[len(expected)]
+	return next < '0' || next > '9'
+}
+
+// installedToolVersion returns the version when the tool manager installed it,
+// mise links partial versions like 20 to the latest installed release.
+func (l *language) installedToolVersion(tool, pinned string) (*version, bool) {
+	home := l.env.Home()
+
+	var dataDirs []string
+
+	if dir := l.env.Getenv("MISE_DATA_DIR"); len(dir) != 0 {
+		dataDirs = append(dataDirs, dir)
+	} else if dir := l.env.Getenv("XDG_DATA_HOME"); len(dir) != 0 {
+		dataDirs = append(dataDirs, filepath.Join(dir, "mise"))
+	} else {
+		dataDirs = append(dataDirs, filepath.Join(home, ".local", "share", "mise"))
+	}
+
+	if dir := l.env.Getenv("ASDF_DATA_DIR"); len(dir) != 0 {
+		dataDirs = append(dataDirs, dir)
+	} else {
+		dataDirs = append(dataDirs, filepath.Join(home, ".asdf"))
+	}
+
+	for _, dataDir := range dataDirs {
+		installDir := filepath.Join(dataDir, "installs", tool, pinned)
+		if !l.env.HasFolder(installDir) {
+			continue
+		}
+
+		if target, err := l.env.ResolveSymlink(installDir); err == nil {
+			installDir = target
+		}
+
+		if version, ok := parseVersion(normalizeToolVersion(filepath.Base(installDir))); ok {
+			return version, true
+		}
+	}
+
+	return nil, false
+}
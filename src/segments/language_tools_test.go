@@ -0,0 +1,196 @@
+package segments
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	cache_ "github.com/jandedobbeleer/oh-my-posh/src/cache/mock"
+	"github.com/jandedobbeleer/oh-my-posh/src/properties"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/mock"
+
+	"github.com/stretchr/testify/assert"
+	mock_ "github.com/stretchr/testify/mock"
+)
+
+func TestLanguageToolVersion(t *testing.T) {
+	cases := []struct {
+		Files            map[string]string
+		Installed        map[string]string
+		Case             string
+		ExpectedExpected string
+		ExpectedVersion  string
+		ExpectedMismatch bool
+		ToolManager      bool
+		CommandRuns      bool
+	}{
+		{
+			Case:            "No files",
+			ExpectedVersion: "1.22.3",
+			CommandRuns:     true,
+		},
+		{
+			Case:            "Matching .tool-versions",
+			Files:           map[string]string{"/usr/home/project/.tool-versions": "nodejs 20.1.0\ngolang 1.22.3 1.21.0 # pinned"},
+			ExpectedVersion: "1.22.3",
+			CommandRuns:     true,
+		},
+		{
+			Case:            "Matching minor version",
+			Files:           map[string]string{"/usr/home/project/.tool-versions": "golang 1.22"},
+			ExpectedVersion: "1.22.3",
+			CommandRuns:     true,
+		},
+		{
+			Case:             "Mismatching .tool-versions",
+			Files:            map[string]string{"/usr/home/.tool-versions": "golang 1.2"},
+			ExpectedVersion:  "1.22.3",
+			ExpectedExpected: "1.2",
+			ExpectedMismatch: true,
+			CommandRuns:      true,
+		},
+		{
+			Case: "Closest file wins",
+			Files: map[string]string{
+				"/usr/home/project/mise.toml": "[tools]\ngo = \"1.21.0\"",
+				"/usr/home/.tool-versions":    "golang 1.22.3",
+			},
+			ExpectedVersion:  "1.22.3",
+			ExpectedExpected: "1.21.0",
+			ExpectedMismatch: true,
+			CommandRuns:      true,
+		},
+		{
+			Case: "Parent file when the closest one doesn't pin the tool",
+			Files: map[string]string{
+				"/usr/home/project/.tool-versions": "nodejs 20.1.0",
+				"/usr/home/.mise.toml":             "[tools]\ngo = { version = \"1.21\" }",
+			},
+			ExpectedVersion:  "1.22.3",
+			ExpectedExpected: "1.21",
+			ExpectedMismatch: true,
+			CommandRuns:      true,
+		},
+		{
+			Case:            "Version without a number",
+			Files:           map[string]string{"/usr/home/project/mise.toml": "[tools]\ngo = [\"latest\"]"},
+			ExpectedVersion: "1.22.3",
+			CommandRuns:     true,
+		},
+		{
+			Case:            "Installed by mise",
+			Files:           map[string]string{"/usr/home/project/mise.toml": "[tools]\ngo = \"1.21\""},
+			Installed:       map[string]string{"/usr/home/.local/share/mise/installs/go/1.21": "/usr/home/.local/share/mise/installs/go/1.21.8"},
+			ToolManager:     true,
+			ExpectedVersion: "1.21.8",
+		},
+		{
+			Case:            "Installed by asdf",
+			Files:           map[string]string{"/usr/home/project/.tool-versions": "golang 1.21.8"},
+			Installed:       map[string]string{"/usr/home/.asdf/installs/golang/1.21.8": ""},
+			ToolManager:     true,
+			ExpectedVersion: "1.21.8",
+		},
+		{
+			Case:             "Not installed",
+			Files:            map[string]string{"/usr/home/project/.tool-versions": "golang 1.21.8"},
+			ToolManager:      true,
+			ExpectedVersion:  "1.22.3",
+			ExpectedExpected: "1.21.8",
+			ExpectedMismatch: true,
+			CommandRuns:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		env := new(mock.Environment)
+		env.On("HasFiles", "*.go").Return(true)
+		env.On("Pwd").Return("/usr/home/project")
+		env.On("Home").Return("/usr/home")
+		env.On("Getenv", mock_.Anything).Return("")
+		env.On("HasCommand", "go").Return(true)
+		env.On("RunCommand", "go", []string{"version"}).Return("go version go1.22.3 linux/amd64", nil)
+
+		for _, name := range toolVersionFiles {
+			var file *runtime.FileInfo
+
+			for _, folder := range []string{"/usr/home/project", "/usr/home"} {
+				path := filepath.Join(folder, name)
+				if content, ok := tc.Files[path]; ok {
+					file = &runtime.FileInfo{ParentFolder: folder, Path: path}
+					env.On("FileContent", path).Return(content)
+					break
+				}
+			}
+
+			if file == nil {
+				env.On("HasParentFilePath", name, false).Return(&runtime.FileInfo{}, errors.New("no match at root level"))
+				continue
+			}
+
+			env.On("HasParentFilePath", name, false).Return(file, nil)
+		}
+
+		for folder, target := range tc.Installed {
+			env.On("HasFolder", folder).Return(true)
+
+			if len(target) == 0 {
+				env.On("ResolveSymlink", folder).Return("", errors.New("not a symlink"))
+				continue
+			}
+
+			env.On("ResolveSymlink", folder).Return(target, nil)
+		}
+
+		env.On("HasFolder", mock_.Anything).Return(false)
+
+		cache := &cache_.Cache{}
+		cache.On("Get", mock_.Anything).Return("", false)
+		cache.On("Set", mock_.Anything, mock_.Anything, mock_.Anything).Return(nil)
+		env.On("Cache").Return(cache)
+
+		g := &Golang{}
+		g.Init(properties.Map{ToolManagerVersion: tc.ToolManager}, env)
+
+		assert.True(t, g.Enabled(), tc.Case)
+		assert.Equal(t, tc.ExpectedVersion, g.Full, tc.Case)
+		assert.Equal(t, tc.ExpectedExpected, g.Expected, tc.Case)
+		assert.Equal(t, tc.ExpectedMismatch, g.Mismatch, tc.Case)
+		assert.Equal(t, "go", g.Executable, tc.Case)
+
+		if tc.CommandRuns {
+			env.AssertCalled(t, "RunCommand", "go", []string{"version"})
+			continue
+		}
+
+		env.AssertNotCalled(t, "RunCommand", "go", []string{"version"})
+	}
+}
+
+func TestNormalizeToolVersion(t *testing.T) {
+	cases := []struct {
+		Version  string
+		Expected string
+	}{
+		{Version: "20.1.0", Expected: "20.1.0"},
+		{Version: "v20", Expected: "20"},
+		{Version: "temurin-21.0.1+12", Expected: "21.0.1+12"},
+		{Version: "lts", Expected: ""},
+		{Version: "system", Expected: ""},
+		{Version: "ref:main", Expected: ""},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.Expected, normalizeToolVersion(tc.Version), tc.Version)
+	}
+}
+
+func TestMatchesToolVersion(t *testing.T) {
+	assert.True(t, matchesToolVersion("20.1.0", "20.1.0"))
+	assert.True(t, matchesToolVersion("20.1.0", "20"))
+	assert.True(t, matchesToolVersion("21.0.1+12", "21.0.1"))
+	assert.False(t, matchesToolVersion("20.1.0", "2"))
+	assert.False(t, matchesToolVersion("20.11.0", "20.1"))
+	assert.False(t, matchesToolVersion("18.0.0", "20"))
+}
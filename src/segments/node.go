@@ -1,7 +1,9 @@
 package segments
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/properties"
@@ -32,9 +34,10 @@ func (n *Node) Enabled() bool {
 	n.extensions = []string{"*.js", "*.ts", "package.json", ".nvmrc", "pnpm-workspace.yaml", ".pnpmfile.cjs", ".vue"}
 	n.commands = []*cmd{
 		{
-			executable: "node",
-			args:       []string{"--version"},
-			regex:      `(?:v(?P<version>((?P<major>[0-9]+).(?P<minor>[0-9]+).(?P<patch>[0-9]+))))`,
+			executable:  "node",
+			args:        []string{"--version"},
+			readVersion: n.readVersion,
+			regex:       `(?:v(?P<version>((?P<major>[0-9]+).(?P<minor>[0-9]+).(?P<patch>[0-9]+))))`,
 		},
 	}
 	n.versionURLTemplate = "https://github.com/nodejs/node/blob/master/doc/changelogs/CHANGELOG_V{{ .Major }}.md#{{ .Full }}"
@@ -106,3 +109,28 @@ func (n *Node) matchesVersionFile() (string, bool) {
 
 	return version, regex.MatchString(re, fileVersion)
 }
+
+// readVersion reads the version from the node_version.h header installed next to the executable
+func (n *Node) readVersion(executable string) (string, error) {
+	header := filepath.Join(n.language.installFolder(executable), "include", "node", "node_version.h")
+	content := n.language.env.FileContent(header)
+
+	parts := make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 || fields[0] != "#define" {
+			continue
+		}
+
+		switch fields[1] {
+		case "NODE_MAJOR_VERSION", "NODE_MINOR_VERSION", "NODE_PATCH_VERSION":
+			parts[fields[1]] = fields[2]
+		}
+	}
+
+	if len(parts) != 3 {
+		return "", errors.New("no node version found in " + header)
+	}
+
+	return fmt.Sprintf("%s.%s.%s", parts["NODE_MAJOR_VERSION"], parts["NODE_MINOR_VERSION"], parts["NODE_PATCH_VERSION"]), nil
+}
@@ -1,6 +1,7 @@
 package segments
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/properties"
@@ -96,3 +97,37 @@ func TestNodeInContext(t *testing.T) {
 		assert.Equal(t, tc.ExpectedString, node.PackageManagerIcon, tc.Case)
 	}
 }
+
+func TestNodeReadVersion(t *testing.T) {
+	header := `#define NODE_MAJOR_VERSION 20
+#define NODE_MINOR_VERSION 14
+#define NODE_PATCH_VERSION 0
+
+#define NODE_VERSION_IS_LTS 1
+`
+
+	cases := []struct {
+		Case            string
+		Header          string
+		ExpectedVersion string
+		ExpectedError   bool
+	}{
+		{Case: "Header", Header: header, ExpectedVersion: "20.14.0"},
+		{Case: "No header", ExpectedError: true},
+	}
+
+	for _, tc := range cases {
+		installFolder := filepath.Join("/usr", "home", ".nvm", "versions", "node", "v20.14.0")
+
+		env := new(mock.Environment)
+		env.On("ResolveSymlink", "/usr/local/bin/node").Return(filepath.Join(installFolder, "bin", "node"), nil)
+		env.On("FileContent", filepath.Join(installFolder, "include", "node", "node_version.h")).Return(tc.Header)
+
+		n := &Node{}
+		n.Init(properties.Map{}, env)
+
+		version, err := n.readVersion("/usr/local/bin/node")
+		assert.Equal(t, tc.ExpectedError, err != nil, tc.Case)
+		assert.Equal(t, tc.ExpectedVersion, version, tc.Case)
+	}
+}
@@ -0,0 +1,123 @@
+package segments
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/log"
+	"github.com/jandedobbeleer/oh-my-posh/src/properties"
+)
+
+// Plugin runs an executable which receives a JSON request on stdin and answers with a JSON object.
+// The fields of that object are available in the templates.
+type Plugin struct {
+	base
+
+	Fields map[string]any
+}
+
+const (
+	// PluginExecutable is the plugin to run
+	PluginExecutable properties.Property = "executable"
+	// Arguments to pass to the executable
+	Arguments properties.Property = "arguments"
+	// EnvVars are the environment variables to include in the request
+	EnvVars properties.Property = "env_vars"
+	// CommandTimeout is the time in milliseconds the executable can run
+	CommandTimeout properties.Property = "command_timeout"
+
+	defaultPluginTimeout = 500
+)
+
+type pluginRequest struct {
+	Properties properties.Map    `json:"properties"`
+	Env        map[string]string `json:"env"`
+	PWD        string            `json:"pwd"`
+	Shell      string            `json:"shell"`
+	ExitCode   int               `json:"exit_code"`
+}
+
+func (p *Plugin) Template() string {
+	return " {{ .Text }} "
+}
+
+func (p *Plugin) Enabled() bool {
+	executable := p.props.GetString(PluginExecutable, "")
+	if len(executable) == 0 {
+		log.Error(errors.New("no executable configured for the plugin"))
+		return false
+	}
+
+	request, err := json.Marshal(p.request())
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+
+	timeout := p.props.GetInt(CommandTimeout, defaultPluginTimeout)
+	args := p.props.GetStringArray(Arguments, []string{})
+
+	output, err := p.env.RunCommandWithInput(string(request), timeout, executable, args...)
+	if err != nil {
+		return false
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(output), &fields); err != nil {
+		log.Error(err)
+		return false
+	}
+
+	// an empty object hides the segment
+	if len(fields) == 0 {
+		return false
+	}
+
+	p.Fields = fields
+
+	return true
+}
+
+func (p *Plugin) request() *pluginRequest {
+	code, _ := p.env.StatusCodes()
+
+	request := &pluginRequest{
+		PWD:      p.env.Pwd(),
+		Shell:    p.env.Shell(),
+		ExitCode: code,
+		Env:      make(map[string]string),
+	}
+
+	for _, name := range p.props.GetStringArray(EnvVars, []string{}) {
+		request.Env[name] = p.env.Getenv(name)
+	}
+
+	switch props := p.props.(type) {
+	case *properties.Wrapper:
+		request.Properties = props.Properties
+	case properties.Map:
+		request.Properties = props
+	}
+
+	return request
+}
+
+// TemplateData exposes the fields of the response to the templates, next to
+// the rendered text for cross-segment references.
+func (p *Plugin) TemplateData() map[string]any {
+	return fieldsData(p.Fields, p.Output)
+}
+
+func fieldsData(fields map[string]any, text string) map[string]any {
+	data := maps.Clone(fields)
+	if data == nil {
+		data = make(map[string]any)
+	}
+
+	if _, found := data["Text"]; !found && len(text) != 0 {
+		data["Text"] = text
+	}
+
+	return data
+}
@@ -0,0 +1,111 @@
+package segments
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/properties"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlugin(t *testing.T) {
+	cases := []struct {
+		Case            string
+		Output          string
+		Error           error
+		Template        string
+		ExpectedString  string
+		ExpectedEnabled bool
+	}{
+		{
+			Case:            "Default template",
+			Output:          `{"Text": "hello"}`,
+			ExpectedEnabled: true,
+			ExpectedString:  "hello",
+		},
+		{
+			Case:            "Fields",
+			Output:          `{"branch": "main", "ahead": 2, "dirty": true}`,
+			Template:        "{{ .branch }}{{ if .dirty }}*{{ end }} {{ .ahead }}",
+			ExpectedEnabled: true,
+			ExpectedString:  "main* 2",
+		},
+		{
+			Case:            "Nested fields",
+			Output:          `{"cluster": {"name": "prod"}}`,
+			Template:        "{{ .cluster.name }}",
+			ExpectedEnabled: true,
+			ExpectedString:  "prod",
+		},
+		{
+			Case:            "Missing field",
+			Output:          `{"branch": "main"}`,
+			Template:        "{{ .branch }}{{ .missing }}",
+			ExpectedEnabled: true,
+			ExpectedString:  "main",
+		},
+		{Case: "Empty object", Output: `{}`},
+		{Case: "Invalid JSON", Output: "hello"},
+		{Case: "Error", Output: `{"Text": "hello"}`, Error: errors.New("exit status 1")},
+	}
+
+	for _, tc := range cases {
+		env := new(mock.Environment)
+		env.On("Pwd").Return("/home/jan/code")
+		env.On("Shell").Return("zsh")
+		env.On("StatusCodes").Return(1, "1")
+		env.On("Getenv", "PLUGIN_TOKEN").Return("secret")
+
+		props := properties.Map{
+			PluginExecutable: "omp-plugin",
+			Arguments:        []string{"--json"},
+			EnvVars:          []string{"PLUGIN_TOKEN"},
+			CommandTimeout:   200,
+		}
+
+		request := `{"properties":{"arguments":["--json"],"command_timeout":200,"env_vars":["PLUGIN_TOKEN"],"executable":"omp-plugin"},` +
+			`"env":{"PLUGIN_TOKEN":"secret"},"pwd":"/home/jan/code","shell":"zsh","exit_code":1}`
+		env.On("RunCommandWithInput", request, 200, "omp-plugin", []string{"--json"}).Return(tc.Output, tc.Error)
+
+		plugin := &Plugin{}
+		plugin.Init(props, env)
+
+		assert.Equal(t, tc.ExpectedEnabled, plugin.Enabled(), tc.Case)
+		if !tc.ExpectedEnabled {
+			continue
+		}
+
+		if len(tc.Template) == 0 {
+			tc.Template = plugin.Template()
+		}
+
+		assert.Equal(t, tc.ExpectedString, renderTemplate(env, tc.Template, plugin), tc.Case)
+	}
+}
+
+func TestPluginWithoutExecutable(t *testing.T) {
+	plugin := &Plugin{}
+	plugin.Init(properties.Map{}, new(mock.Environment))
+	assert.False(t, plugin.Enabled())
+}
+
+func TestPluginTemplateData(t *testing.T) {
+	plugin := &Plugin{
+		Fields: map[string]any{"branch": "main"},
+	}
+
+	plugin.SetText("main")
+	assert.Equal(t, map[string]any{"branch": "main", "Text": "main"}, plugin.TemplateData())
+	assert.Equal(t, map[string]any{"branch": "main"}, plugin.Fields)
+
+	// the segment cache restores the fields
+	data, err := json.Marshal(plugin)
+	assert.NoError(t, err)
+
+	restored := &Plugin{}
+	assert.NoError(t, json.Unmarshal(data, restored))
+	assert.Equal(t, plugin.TemplateData(), restored.TemplateData())
+}
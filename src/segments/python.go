@@ -38,14 +38,16 @@ func (p *Python) Enabled() bool {
 			regex:      `(?P<version>((?P<major>[0-9]+).(?P<minor>[0-9]+).(?P<patch>[0-9]+)))`,
 		},
 		{
-			executable: "python",
-			args:       []string{"--version"},
-			regex:      `(?:Python (?P<version>((?P<major>[0-9]+).(?P<minor>[0-9]+).(?P<patch>[0-9]+))))`,
+			executable:  "python",
+			args:        []string{"--version"},
+			readVersion: p.readVersion,
+			regex:       `(?:Python (?P<version>((?P<major>[0-9]+).(?P<minor>[0-9]+).(?P<patch>[0-9]+))))`,
 		},
 		{
-			executable: "python3",
-			args:       []string{"--version"},
-			regex:      `(?:Python (?P<version>((?P<major>[0-9]+).(?P<minor>[0-9]+).(?P<patch>[0-9]+))))`,
+			executable:  "python3",
+			args:        []string{"--version"},
+			readVersion: p.readVersion,
+			regex:       `(?:Python (?P<version>((?P<major>[0-9]+).(?P<minor>[0-9]+).(?P<patch>[0-9]+))))`,
 		},
 		{
 			executable: "py",
@@ -210,3 +212,26 @@ func (p *Python) pyvenvCfgPrompt() string {
 
 	return ""
 }
+
+// readVersion reads the version from the pyvenv.cfg file of the virtual env the executable is in
+func (p *Python) readVersion(executable string) (string, error) {
+	// bin/python on Unix, Scripts\python.exe on Windows, both relative to the virtual env
+	venv := filepath.Dir(filepath.Dir(executable))
+
+	pyvenvCfg := p.language.env.FileContent(filepath.Join(venv, "pyvenv.cfg"))
+	for _, line := range strings.Split(pyvenvCfg, "\n") {
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+
+		// venv writes version, virtualenv and uv write version_info like 3.12.1.final.0
+		switch strings.TrimSpace(key) {
+		case "version", "version_info":
+			parts := strings.SplitN(strings.TrimSpace(value), ".", 4)
+			return strings.Join(parts[:min(len(parts), 3)], "."), nil
+		}
+	}
+
+	return "", fmt.Errorf("no pyvenv.cfg found in %s", venv)
+}
@@ -243,3 +243,28 @@ func TestPythonVirtualEnvIgnoreCustomVenvNames(t *testing.T) {
 		assert.Equal(t, tc.Expected, python.Venv)
 	}
 }
+
+func TestPythonReadVersion(t *testing.T) {
+	cases := []struct {
+		Case            string
+		PyvenvCfg       string
+		ExpectedVersion string
+		ExpectedError   bool
+	}{
+		{Case: "venv", PyvenvCfg: "home = /usr/bin\ninclude-system-site-packages = false\nversion = 3.12.1\n", ExpectedVersion: "3.12.1"},
+		{Case: "virtualenv", PyvenvCfg: "home = /usr/bin\nimplementation = CPython\nversion_info = 3.11.7.final.0\n", ExpectedVersion: "3.11.7"},
+		{Case: "Not a virtual env", ExpectedError: true},
+	}
+
+	for _, tc := range cases {
+		env := new(mock.Environment)
+		env.On("FileContent", filepath.Join("/usr/home/project/.venv", "pyvenv.cfg")).Return(tc.PyvenvCfg)
+
+		p := &Python{}
+		p.Init(properties.Map{}, env)
+
+		version, err := p.readVersion(filepath.Join("/usr/home/project/.venv", "bin", "python"))
+		assert.Equal(t, tc.ExpectedError, err != nil, tc.Case)
+		assert.Equal(t, tc.ExpectedVersion, version, tc.Case)
+	}
+}
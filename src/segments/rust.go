@@ -1,5 +1,13 @@
 package segments
 
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	toml "github.com/pelletier/go-toml/v2"
+)
+
 type Rust struct {
 	language
 }
@@ -12,11 +20,138 @@ func (r *Rust) Enabled() bool {
 	r.extensions = []string{"*.rs", "Cargo.toml", "Cargo.lock"}
 	r.commands = []*cmd{
 		{
-			executable: "rustc",
-			args:       []string{"--version"},
-			regex:      `rustc (?P<version>((?P<major>[0-9]+).(?P<minor>[0-9]+).(?P<patch>[0-9]+))(-(?P<prerelease>[a-z]+))?)(( \((?P<buildmetadata>[0-9a-f]+ [0-9]+-[0-9]+-[0-9]+)\))?)`,
+			executable:  "rustc",
+			args:        []string{"--version"},
+			readVersion: r.readVersion,
+			regex:       `rustc (?P<version>((?P<major>[0-9]+).(?P<minor>[0-9]+).(?P<patch>[0-9]+))(-(?P<prerelease>[a-z]+))?)(( \((?P<buildmetadata>[0-9a-f]+ [0-9]+-[0-9]+-[0-9]+)\))?)`,
 		},
 	}
 
 	return r.language.Enabled()
 }
+
+// readVersion reads the version of the active rustup toolchain from its channel manifest
+func (r *Rust) readVersion(_ string) (string, error) {
+	rustupHome := r.env.Getenv("RUSTUP_HOME")
+	if len(rustupHome) == 0 {
+		rustupHome = filepath.Join(r.env.Home(), ".rustup")
+	}
+
+	toolchain := r.toolchain(rustupHome)
+	if len(toolchain) == 0 {
+		return "", errors.New("no rustup toolchain found")
+	}
+
+	// toolchains are installed with the host triple, like stable-x86_64-unknown-linux-gnu or 1.75.0-x86_64-apple-darwin
+	var folder string
+	for _, entry := range r.env.LsDir(filepath.Join(rustupHome, "toolchains")) {
+		if entry.Name() == toolchain || strings.HasPrefix(entry.Name(), toolchain+"-") {
+			folder = entry.Name()
+			break
+		}
+	}
+
+	if len(folder) == 0 {
+		return "", errors.New("rustup toolchain " + toolchain + " is not installed")
+	}
+
+	// a full version pins the release, a channel like stable or 1.75 needs the manifest
+	if version, ok := parseVersion(toolchain); ok && len(version.Patch) != 0 {
+		return toolchain, nil
+	}
+
+	manifestPath := filepath.Join(rustupHome, "toolchains", folder, "lib", "rustlib", "multirust-channel-manifest.toml")
+
+	var manifest struct {
+		Pkg struct {
+			Rustc struct {
+				Version string `toml:"version"`
+			} `toml:"rustc"`
+		} `toml:"pkg"`
+	}
+
+	if err := toml.Unmarshal([]byte(r.env.FileContent(manifestPath)), &manifest); err != nil {
+		return "", err
+	}
+
+	// 1.75.0 (82e1608df 2023-12-21)
+	version, _, _ := strings.Cut(manifest.Pkg.Rustc.Version, " ")
+	return version, nil
+}
+
+// toolchain returns the toolchain rustup uses in the current folder, in order of precedence:
+// the RUSTUP_TOOLCHAIN environment variable, the closest directory override or rust-toolchain file
+// and the default toolchain.
+func (r *Rust) toolchain(rustupHome string) string {
+	if toolchain := r.env.Getenv("RUSTUP_TOOLCHAIN"); len(toolchain) != 0 {
+		return toolchain
+	}
+
+	var settings struct {
+		Overrides        map[string]string `toml:"overrides"`
+		DefaultToolchain string            `toml:"default_toolchain"`
+	}
+
+	// without valid settings there's no directory override or default toolchain
+	_ = toml.Unmarshal([]byte(r.env.FileContent(filepath.Join(rustupHome, "settings.toml"))), &settings)
+
+	overrideFolder, override := r.directoryOverride(settings.Overrides)
+
+	for _, name := range []string{"rust-toolchain.toml", "rust-toolchain"} {
+		file, err := r.env.HasParentFilePath(name, false)
+		if err != nil {
+			continue
+		}
+
+		// both are found walking up from the current folder, an override for the same folder wins
+		if len(override) != 0 && len(overrideFolder) >= len(file.ParentFolder) {
+			return override
+		}
+
+		content := r.env.FileContent(file.Path)
+
+		var config struct {
+			Toolchain struct {
+				Channel string `toml:"channel"`
+			} `toml:"toolchain"`
+		}
+
+		// the legacy rust-toolchain file only contains the channel
+		if err := toml.Unmarshal([]byte(content), &config); err != nil {
+			return strings.TrimSpace(content)
+		}
+
+		if len(config.Toolchain.Channel) != 0 {
+			return config.Toolchain.Channel
+		}
+	}
+
+	if len(override) != 0 {
+		return override
+	}
+
+	return settings.DefaultToolchain
+}
+
+// directoryOverride returns the folder and toolchain of the closest override set
+// using rustup override set, starting from the current folder
+func (r *Rust) directoryOverride(overrides map[string]string) (string, string) {
+	if len(overrides) == 0 {
+		return "", ""
+	}
+
+	folder := r.env.Pwd()
+
+	for {
+		if toolchain, OK := overrides[folder]; OK {
+			return folder, toolchain
+		}
+
+		parent := filepath.Dir(folder)
+		if parent == folder {
+			return "", ""
+		}
+
+		folder = parent
+	}
+}
@@ -1,10 +1,18 @@
 package segments
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"path/filepath"
 	"testing"
 
+	"github.com/jandedobbeleer/oh-my-posh/src/properties"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/mock"
+
 	"github.com/stretchr/testify/assert"
+	mock_ "github.com/stretchr/testify/mock"
 )
 
 func TestRust(t *testing.T) {
@@ -31,3 +39,113 @@ func TestRust(t *testing.T) {
 		assert.Equal(t, tc.ExpectedString, renderTemplate(env, r.Template(), r), fmt.Sprintf("Failed in case: %s", tc.Case))
 	}
 }
+
+func TestRustReadVersion(t *testing.T) {
+	manifest := "manifest-version = \"2\"\n\n[pkg.rustc]\nversion = \"1.75.0 (82e1608df 2023-12-21)\"\n"
+
+	cases := []struct {
+		Files           map[string]string
+		Case            string
+		Toolchain       string
+		ExpectedVersion string
+		ExpectedError   bool
+	}{
+		{
+			Case:            "Default toolchain",
+			Files:           map[string]string{"settings.toml": "default_toolchain = \"stable-x86_64-unknown-linux-gnu\""},
+			ExpectedVersion: "1.75.0",
+		},
+		{
+			Case:            "Default channel",
+			Files:           map[string]string{"settings.toml": "default_toolchain = \"stable\""},
+			ExpectedVersion: "1.75.0",
+		},
+		{
+			Case:            "RUSTUP_TOOLCHAIN",
+			Toolchain:       "1.70.0",
+			ExpectedVersion: "1.70.0",
+		},
+		{
+			Case:            "rust-toolchain.toml",
+			Files:           map[string]string{"rust-toolchain.toml": "[toolchain]\nchannel = \"1.70.0\"\ncomponents = [\"clippy\"]"},
+			ExpectedVersion: "1.70.0",
+		},
+		{
+			Case:            "Legacy rust-toolchain",
+			Files:           map[string]string{"rust-toolchain": "1.70.0\n"},
+			ExpectedVersion: "1.70.0",
+		},
+		{
+			Case:            "Directory override",
+			Files:           map[string]string{"settings.toml": "default_toolchain = \"1.70.0\"\n\n[overrides]\n\"/usr/home/project\" = \"stable-x86_64-unknown-linux-gnu\"\n"},
+			ExpectedVersion: "1.75.0",
+		},
+		{
+			Case:            "Parent directory override",
+			Files:           map[string]string{"settings.toml": "default_toolchain = \"1.70.0\"\n\n[overrides]\n\"/usr/home\" = \"stable-x86_64-unknown-linux-gnu\"\n\"/usr/home/other\" = \"1.70.0\"\n"},
+			ExpectedVersion: "1.75.0",
+		},
+		{
+			Case: "Directory override over rust-toolchain.toml",
+			Files: map[string]string{
+				"settings.toml":       "[overrides]\n\"/usr/home/project\" = \"stable-x86_64-unknown-linux-gnu\"\n",
+				"rust-toolchain.toml": "[toolchain]\nchannel = \"1.70.0\"",
+			},
+			ExpectedVersion: "1.75.0",
+		},
+		{
+			Case: "rust-toolchain.toml over parent directory override",
+			Files: map[string]string{
+				"settings.toml":       "[overrides]\n\"/usr/home\" = \"stable-x86_64-unknown-linux-gnu\"\n",
+				"rust-toolchain.toml": "[toolchain]\nchannel = \"1.70.0\"",
+			},
+			ExpectedVersion: "1.70.0",
+		},
+		{
+			Case:          "Toolchain not installed",
+			Toolchain:     "nightly",
+			ExpectedError: true,
+		},
+		{
+			Case:          "No rustup",
+			ExpectedError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		rustupHome := filepath.Join("/usr/home", ".rustup")
+
+		env := new(mock.Environment)
+		env.On("Home").Return("/usr/home")
+		env.On("Pwd").Return("/usr/home/project")
+		env.On("Getenv", "RUSTUP_HOME").Return("")
+		env.On("Getenv", "RUSTUP_TOOLCHAIN").Return(tc.Toolchain)
+		env.On("LsDir", filepath.Join(rustupHome, "toolchains")).Return([]fs.DirEntry{
+			&MockDirEntry{name: "stable-x86_64-unknown-linux-gnu", isDir: true},
+			&MockDirEntry{name: "1.70.0-x86_64-unknown-linux-gnu", isDir: true},
+		})
+		env.On("FileContent", filepath.Join(rustupHome, "toolchains", "stable-x86_64-unknown-linux-gnu", "lib", "rustlib", "multirust-channel-manifest.toml")).Return(manifest)
+
+		for _, name := range []string{"rust-toolchain.toml", "rust-toolchain"} {
+			content, ok := tc.Files[name]
+			if !ok {
+				env.On("HasParentFilePath", name, false).Return(&runtime.FileInfo{}, errors.New("no match at root level"))
+				continue
+			}
+
+			path := filepath.Join("/usr/home/project", name)
+			env.On("HasParentFilePath", name, false).Return(&runtime.FileInfo{Path: path, ParentFolder: "/usr/home/project"}, nil)
+			env.On("FileContent", path).Return(content)
+		}
+
+		env.On("FileContent", filepath.Join(rustupHome, "settings.toml")).Return(tc.Files["settings.toml"])
+		env.On("FileContent", mock_.Anything).Return("")
+
+		r := &Rust{}
+		r.Init(properties.Map{}, env)
+
+		version, err := r.readVersion("/usr/home/.cargo/bin/rustc")
+		assert.Equal(t, tc.ExpectedError, err != nil, tc.Case)
+		assert.Equal(t, tc.ExpectedVersion, version, tc.Case)
+	}
+}
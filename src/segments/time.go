@@ -26,7 +26,7 @@ func (t *Time) Enabled() bool {
 	// if no date set, use now(unit testing)
 	t.Format = t.props.GetString(TimeFormat, "15:04:05")
 	if t.CurrentDate.IsZero() {
-		t.CurrentDate = time.Now()
+		t.CurrentDate = t.env.Now()
 	}
 	return true
 }
@@ -19,6 +19,8 @@ func (f Feature) Bash() Code {
 		return unixUpgrade
 	case Notice:
 		return unixNotice
+	case Daemon:
+		return unixDaemon
 	case PromptMark, RPrompt, PoshGit, Azure, LineError, Jobs, Tooltips, Transient:
 		fallthrough
 	default:
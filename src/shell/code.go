@@ -9,6 +9,7 @@ const (
 	unixCursorPositioning Code = "_omp_cursor_positioning=1"
 	unixUpgrade           Code = `"$_omp_executable" upgrade`
 	unixNotice            Code = `"$_omp_executable" notice`
+	unixDaemon            Code = `("$_omp_executable" daemon --pid=$$ >/dev/null 2>&1 &)`
 )
 
 func (c Code) Indent(spaces int) Code {
@@ -13,7 +13,7 @@ func (f Feature) Elvish() Code {
 		return "$_omp_executable upgrade"
 	case Notice:
 		return "$_omp_executable notice"
-	case PromptMark, RPrompt, PoshGit, Azure, LineError, Jobs, CursorPositioning, Tooltips, Transient, FTCSMarks:
+	case PromptMark, RPrompt, PoshGit, Azure, LineError, Jobs, CursorPositioning, Tooltips, Transient, FTCSMarks, Daemon:
 		fallthrough
 	default:
 		return ""
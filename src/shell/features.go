@@ -15,6 +15,7 @@ const (
 	PromptMark
 	RPrompt
 	CursorPositioning
+	Daemon
 )
 
 type Features []Feature
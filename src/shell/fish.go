@@ -23,6 +23,8 @@ func (f Feature) Fish() Code {
 		return unixUpgrade
 	case Notice:
 		return unixNotice
+	case Daemon:
+		return `"$_omp_executable" daemon --pid=$fish_pid >/dev/null 2>&1 & disown`
 	case RPrompt, PoshGit, Azure, LineError, Jobs, CursorPositioning:
 		fallthrough
 	default:
@@ -16,7 +16,8 @@ set --global _omp_transient_prompt 1
 set --global _omp_ftcs_marks 1
 "$_omp_executable" upgrade
 "$_omp_executable" notice
-set --global _omp_prompt_mark 1`
+set --global _omp_prompt_mark 1
+"$_omp_executable" daemon --pid=$fish_pid >/dev/null 2>&1 & disown`
 
 	assert.Equal(t, want, got)
 }
@@ -15,7 +15,7 @@ func (f Feature) Tcsh() Code {
 		return `"$_omp_executable" upgrade;`
 	case Notice:
 		return `"$_omp_executable" notice;`
-	case PromptMark, RPrompt, PoshGit, Azure, LineError, Jobs, Tooltips, Transient, FTCSMarks, CursorPositioning:
+	case PromptMark, RPrompt, PoshGit, Azure, LineError, Jobs, Tooltips, Transient, FTCSMarks, CursorPositioning, Daemon:
 		fallthrough
 	default:
 		return ""
@@ -15,7 +15,7 @@ func (f Feature) Xonsh() Code {
 		return "@(_omp_executable) upgrade"
 	case Notice:
 		return "@(_omp_executable) notice"
-	case PromptMark, RPrompt, PoshGit, Azure, LineError, Jobs, Tooltips, Transient, CursorPositioning, FTCSMarks:
+	case PromptMark, RPrompt, PoshGit, Azure, LineError, Jobs, Tooltips, Transient, CursorPositioning, FTCSMarks, Daemon:
 		fallthrough
 	default:
 		return ""
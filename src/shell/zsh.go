@@ -21,6 +21,8 @@ func (f Feature) Zsh() Code {
 		return unixUpgrade
 	case Notice:
 		return unixNotice
+	case Daemon:
+		return unixDaemon
 	case PromptMark, RPrompt, PoshGit, Azure, LineError, Jobs:
 		fallthrough
 	default:
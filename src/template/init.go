@@ -55,6 +55,8 @@ func Init(environment runtime.Environment, vars maps.Simple) {
 		"Var",
 		"Data",
 		"Jobs",
+		"TerminalBackground",
+		"TerminalBackgroundIsDark",
 	}
 
 	if Cache != nil {
@@ -0,0 +1,36 @@
+package template
+
+import (
+	"strconv"
+	"strings"
+)
+
+// TerminalBackground is the background color reported by the terminal, empty when unknown.
+// It's a method so we only query the terminal when a template needs it.
+func (c *context) TerminalBackground() string {
+	return env.TerminalBackground()
+}
+
+func (c *context) TerminalBackgroundIsDark() bool {
+	return TerminalBackgroundIsDark()
+}
+
+// TerminalBackgroundIsDark reports whether the terminal has a dark background,
+// terminals that don't report their background are assumed to be dark.
+func TerminalBackgroundIsDark() bool {
+	if env == nil {
+		return true
+	}
+
+	background := strings.TrimPrefix(env.TerminalBackground(), "#")
+
+	value, err := strconv.ParseUint(background, 16, 32)
+	if len(background) != 6 || err != nil {
+		return true
+	}
+
+	r, g, b := float64(value>>16&0xff), float64(value>>8&0xff), float64(value&0xff)
+
+	// perceived brightness
+	return 0.299*r+0.587*g+0.114*b < 128
+}
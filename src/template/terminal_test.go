@@ -0,0 +1,42 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/cache"
+	"github.com/jandedobbeleer/oh-my-posh/src/runtime/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerminalBackground(t *testing.T) {
+	cases := []struct {
+		Case       string
+		Background string
+		Template   string
+		Expected   string
+	}{
+		{Case: "dark", Background: "#1e1e2e", Template: "{{ if .TerminalBackgroundIsDark }}dark{{ else }}light{{ end }}", Expected: "dark"},
+		{Case: "light", Background: "#eff1f5", Template: "{{ if .TerminalBackgroundIsDark }}dark{{ else }}light{{ end }}", Expected: "light"},
+		{Case: "unknown", Template: "{{ if .TerminalBackgroundIsDark }}dark{{ else }}light{{ end }}", Expected: "dark"},
+		{Case: "color", Background: "#eff1f5", Template: "{{ .TerminalBackground }}", Expected: "#eff1f5"},
+	}
+
+	for _, tc := range cases {
+		env := &mock.Environment{}
+		env.On("Shell").Return("foo")
+		env.On("TerminalBackground").Return(tc.Background)
+
+		Cache = &cache.Template{}
+		Init(env, nil)
+
+		tmpl := &Text{
+			Template: tc.Template,
+			Context:  struct{ Text string }{Text: "posh"},
+		}
+
+		text, err := tmpl.Render()
+		assert.NoError(t, err, tc.Case)
+		assert.Equal(t, tc.Expected, text, tc.Case)
+	}
+}
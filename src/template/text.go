@@ -3,11 +3,15 @@ package template
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
+	"text/template"
+	"text/template/parse"
 	"time"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/log"
 	"github.com/jandedobbeleer/oh-my-posh/src/regex"
+	"github.com/jandedobbeleer/oh-my-posh/src/timing"
 )
 
 type Text struct {
@@ -15,13 +19,24 @@ type Text struct {
 	Template string
 }
 
+// Dynamic is implemented by data that only knows its properties at runtime, like the response of a plugin.
+// Templates use the returned map instead of the data itself.
+type Dynamic interface {
+	TemplateData() map[string]any
+}
+
 func (t *Text) Render() (string, error) {
 	defer log.Trace(time.Now(), t.Template)
+	defer timing.Track(timing.Template, time.Now())
 
 	if !strings.Contains(t.Template, "{{") || !strings.Contains(t.Template, "}}") {
 		return t.Template, nil
 	}
 
+	if dynamic, ok := t.Context.(Dynamic); ok {
+		t.Context = dynamic.TemplateData()
+	}
+
 	t.patchTemplate()
 
 	renderer := renderPool.Get().(*renderer)
@@ -30,6 +45,57 @@ func (t *Text) Render() (string, error) {
 	return renderer.execute(t)
 }
 
+// Parse checks the syntax of the template and the functions it uses without rendering it.
+func (t *Text) Parse() error {
+	_, err := template.New("parse").Funcs(funcMap()).Parse(t.Template)
+	return err
+}
+
+// Literals returns the plain text every branch of the template can output, trimmed and without duplicates.
+// Dynamic output, like the value of a property, is not included.
+func (t *Text) Literals() ([]string, error) {
+	tree := parse.New("literals")
+	tree.Mode = parse.SkipFuncCheck
+
+	if _, err := tree.Parse(t.Template, "{{", "}}", make(map[string]*parse.Tree)); err != nil {
+		return nil, err
+	}
+
+	var literals []string
+
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch node := node.(type) {
+		case *parse.ListNode:
+			if node == nil {
+				return
+			}
+
+			for _, child := range node.Nodes {
+				walk(child)
+			}
+		case *parse.TextNode:
+			text := strings.TrimSpace(string(node.Text))
+			if len(text) != 0 && !slices.Contains(literals, text) {
+				literals = append(literals, text)
+			}
+		case *parse.IfNode:
+			walk(node.List)
+			walk(node.ElseList)
+		case *parse.RangeNode:
+			walk(node.List)
+			walk(node.ElseList)
+		case *parse.WithNode:
+			walk(node.List)
+			walk(node.ElseList)
+		}
+	}
+
+	walk(tree.Root)
+
+	return literals, nil
+}
+
 func (t *Text) patchTemplate() {
 	isKnownVariable := func(variable string) bool {
 		variable = strings.TrimPrefix(variable, ".")
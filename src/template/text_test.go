@@ -10,6 +10,14 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+type dynamic struct {
+	fields map[string]any
+}
+
+func (d *dynamic) TemplateData() map[string]any {
+	return d.fields
+}
+
 func TestRenderTemplate(t *testing.T) {
 	type Me struct {
 		Name string
@@ -153,6 +161,12 @@ func TestRenderTemplate(t *testing.T) {
 				Text2: "world",
 			},
 		},
+		{
+			Case:     "dynamic data",
+			Expected: "main 2!",
+			Template: "{{ .branch }} {{ .ahead }}{{ .missing }}{{ index .Data \"my-field\" }}",
+			Context:  &dynamic{fields: map[string]any{"branch": "main", "ahead": 2, "my-field": "!"}},
+		},
 	}
 
 	for _, tc := range cases {
@@ -262,6 +276,37 @@ func TestRenderTemplateEnvVar(t *testing.T) {
 	}
 }
 
+func TestLiterals(t *testing.T) {
+	cases := []struct {
+		Case        string
+		Template    string
+		Expected    []string
+		ShouldError bool
+	}{
+		{Case: "plain text", Template: "#ffffff", Expected: []string{"#ffffff"}},
+		{Case: "if else", Template: "{{ if .Error }}p:red{{ else if .Warning }} p:yellow {{ else }}p:green{{ end }}", Expected: []string{"p:red", "p:yellow", "p:green"}},
+		{Case: "unknown function", Template: "{{ if gt (myFunc .Count) 0 }}#ff0000{{ end }}", Expected: []string{"#ff0000"}},
+		{Case: "duplicates and nested", Template: "{{ with .Env }}{{ range . }}#000{{ else }}#000{{ end }}{{ end }}", Expected: []string{"#000"}},
+		{Case: "dynamic only", Template: "{{ .Color }}"},
+		{Case: "invalid", Template: "{{ if .Error }}#ff0000", ShouldError: true},
+	}
+
+	for _, tc := range cases {
+		tmpl := &Text{
+			Template: tc.Template,
+		}
+
+		literals, err := tmpl.Literals()
+		if tc.ShouldError {
+			assert.Error(t, err, tc.Case)
+			continue
+		}
+
+		assert.NoError(t, err, tc.Case)
+		assert.Equal(t, tc.Expected, literals, tc.Case)
+	}
+}
+
 func TestPatchTemplate(t *testing.T) {
 	cases := []struct {
 		Case     string
@@ -0,0 +1,169 @@
+package terminal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/regex"
+	"github.com/jandedobbeleer/oh-my-posh/src/shell"
+)
+
+const (
+	// Tmux renders the tmux status line format, #[fg=red,bg=default]
+	Tmux = "tmux"
+	// Zellij renders the zjstatus plugin format, which uses the same syntax with numeric ANSI colors
+	Zellij = "zellij"
+
+	sgrRegex = "\x1b\\[(?P<PARAMS>[\\d;]*)m"
+)
+
+var (
+	// Markup is the status line format of the terminal multiplexer we render for, ANSI when empty
+	Markup string
+
+	colorNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+	sgrAttributes = map[int]string{
+		0:  "default",
+		1:  "bold",
+		2:  "dim",
+		3:  "italics",
+		4:  "underscore",
+		5:  "blink",
+		7:  "reverse",
+		9:  "strikethrough",
+		22: "nobold,nodim",
+		23: "noitalics",
+		24: "nounderscore",
+		25: "noblink",
+		27: "noreverse",
+		29: "nostrikethrough",
+		39: "fg=default",
+		49: "bg=default",
+		53: "overline",
+		55: "nooverline",
+	}
+)
+
+// InitMarkup switches the writer from ANSI escape sequences to the status line markup
+// of the given terminal multiplexer. Shell specific escaping does not apply there.
+func InitMarkup(target string) {
+	Markup = target
+
+	// the multiplexer translates colors when the terminal lacks true color support
+	color.TerminalProfile = color.TrueColor
+
+	formats = &shell.Formats{
+		Escape:          "%s",
+		EscapeSequences: map[rune]string{},
+	}
+
+	if Markup == Tmux {
+		formats.EscapeSequences['#'] = "##"
+	}
+}
+
+// toMarkup converts the SGR escape sequences in text to status line markup
+func toMarkup(text string) string {
+	var builder strings.Builder
+
+	for _, match := range regex.FindAllNamedRegexMatch(sgrRegex, text) {
+		attributes := sgrToAttributes(match["PARAMS"])
+		if len(attributes) == 0 {
+			continue
+		}
+
+		builder.WriteString(fmt.Sprintf("#[%s]", strings.Join(attributes, ",")))
+	}
+
+	return builder.String()
+}
+
+func sgrToAttributes(params string) []string {
+	var codes []int
+
+	for _, param := range strings.Split(params, ";") {
+		code, err := strconv.Atoi(param)
+		if err != nil {
+			code = 0
+		}
+
+		codes = append(codes, code)
+	}
+
+	var attributes []string
+
+	for i := 0; i < len(codes); i++ {
+		code := codes[i]
+
+		switch {
+		case code == 38 || code == 48:
+			value, skip := extendedColor(codes[i+1:])
+			i += skip
+
+			if len(value) == 0 {
+				continue
+			}
+
+			attributes = append(attributes, colorAttribute(code == 48, value))
+		case code >= 30 && code <= 37:
+			attributes = append(attributes, colorAttribute(false, namedColor(code-30, false)))
+		case code >= 40 && code <= 47:
+			attributes = append(attributes, colorAttribute(true, namedColor(code-40, false)))
+		case code >= 90 && code <= 97:
+			attributes = append(attributes, colorAttribute(false, namedColor(code-90, true)))
+		case code >= 100 && code <= 107:
+			attributes = append(attributes, colorAttribute(true, namedColor(code-100, true)))
+		default:
+			if attribute, ok := sgrAttributes[code]; ok {
+				attributes = append(attributes, attribute)
+			}
+		}
+	}
+
+	return attributes
+}
+
+// extendedColor parses the arguments of a 256 color (5;n) or true color (2;r;g;b) code
+// and returns the color together with the number of arguments it consumed
+func extendedColor(args []int) (string, int) {
+	if len(args) >= 2 && args[0] == 5 {
+		if Markup == Zellij {
+			return strconv.Itoa(args[1]), 2
+		}
+
+		return fmt.Sprintf("colour%d", args[1]), 2
+	}
+
+	if len(args) >= 4 && args[0] == 2 {
+		return fmt.Sprintf("#%02x%02x%02x", args[1], args[2], args[3]), 4
+	}
+
+	return "", len(args)
+}
+
+func namedColor(index int, bright bool) string {
+	if Markup == Zellij {
+		if bright {
+			index += 8
+		}
+
+		return strconv.Itoa(index)
+	}
+
+	if bright {
+		return "bright" + colorNames[index]
+	}
+
+	return colorNames[index]
+}
+
+func colorAttribute(isBackground bool, value string) string {
+	if isBackground {
+		return "bg=" + value
+	}
+
+	return "fg=" + value
+}
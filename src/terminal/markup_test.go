@@ -0,0 +1,81 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/jandedobbeleer/oh-my-posh/src/color"
+	"github.com/jandedobbeleer/oh-my-posh/src/shell"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteMarkup(t *testing.T) {
+	cases := []struct {
+		Colors   *color.Set
+		Case     string
+		Markup   string
+		Input    string
+		Expected string
+	}{
+		{
+			Case:     "Tmux",
+			Markup:   Tmux,
+			Input:    "test",
+			Expected: "#[bg=white]#[fg=black]test#[default]",
+			Colors:   &color.Set{Foreground: "black", Background: "white"},
+		},
+		{
+			Case:     "Tmux escape",
+			Markup:   Tmux,
+			Input:    "#1",
+			Expected: "#[fg=brightred]##1#[default]",
+			Colors:   &color.Set{Foreground: "lightRed", Background: color.Transparent},
+		},
+		{
+			Case:     "Tmux override and style",
+			Markup:   Tmux,
+			Input:    "<b>a</b><#ff5733>b</>",
+			Expected: "#[bold]#[bg=colour202]#[fg=#ffffff]a#[nobold,nodim]#[fg=#ff5733]b#[default]",
+			Colors:   &color.Set{Foreground: "#ffffff", Background: "202"},
+		},
+		{
+			Case:     "Tmux link",
+			Markup:   Tmux,
+			Input:    "<LINK>https://ohmyposh.dev<TEXT>docs</TEXT></LINK>",
+			Expected: "#[fg=white]docs#[default]",
+			Colors:   &color.Set{Background: color.Transparent},
+		},
+		{
+			Case:     "Tmux transparent",
+			Markup:   Tmux,
+			Input:    "test",
+			Expected: "#[default]#[fg=blue,bg=default]#[reverse]test#[default]",
+			Colors:   &color.Set{Foreground: color.Transparent, Background: "blue"},
+		},
+		{
+			Case:     "Zellij",
+			Markup:   Zellij,
+			Input:    "#1",
+			Expected: "#[bg=9]#[fg=202]#1#[default]",
+			Colors:   &color.Set{Foreground: "202", Background: "lightRed"},
+		},
+	}
+
+	for _, tc := range cases {
+		Init(shell.BASH)
+		InitMarkup(tc.Markup)
+		ParentColors = []*color.Set{}
+		CurrentColors = tc.Colors
+		Colors = &color.Defaults{}
+
+		Write(tc.Colors.Background, tc.Colors.Foreground, tc.Input)
+
+		got, _ := String()
+
+		assert.Equal(t, tc.Expected, got, tc.Case)
+	}
+
+	Init(shell.GENERIC)
+	CurrentColors = nil
+	ParentColors = nil
+}
@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/jandedobbeleer/oh-my-posh/src/color"
 	"github.com/jandedobbeleer/oh-my-posh/src/log"
 	"github.com/jandedobbeleer/oh-my-posh/src/regex"
 	"github.com/jandedobbeleer/oh-my-posh/src/shell"
+	"github.com/jandedobbeleer/oh-my-posh/src/timing"
 	"github.com/mattn/go-runewidth"
 )
 
@@ -105,8 +107,7 @@ func Init(sh string) {
 	log.Debug("terminal program:", Program)
 	log.Debug("terminal shell:", Shell)
 
-	color.TrueColor = Program != AppleTerminal
-
+	Markup = ""
 	formats = shell.GetFormats(Shell)
 }
 
@@ -379,6 +380,11 @@ func writeEscapedAnsiString(text string) {
 		return
 	}
 
+	if len(Markup) != 0 {
+		builder.WriteString(toMarkup(text))
+		return
+	}
+
 	if len(formats.Escape) != 0 {
 		text = fmt.Sprintf(formats.Escape, text)
 	}
@@ -392,7 +398,11 @@ func write(s rune) {
 	}
 
 	if isHyperlink {
-		builder.WriteRune(s)
+		// status lines can't render hyperlinks, only keep the text
+		if len(Markup) == 0 {
+			builder.WriteRune(s)
+		}
+
 		return
 	}
 
@@ -595,6 +605,8 @@ func endColorOverride(position int) int {
 }
 
 func asAnsiColors(background, foreground color.Ansi) (color.Ansi, color.Ansi) {
+	defer timing.Track(timing.Color, time.Now())
+
 	if len(background) == 0 {
 		background = color.Background
 	}
@@ -614,6 +626,11 @@ func asAnsiColors(background, foreground color.Ansi) (color.Ansi, color.Ansi) {
 		foreground = fg
 	}
 
+	if foreground == color.Auto {
+		terminalBackground, _ := Colors.Resolve(BackgroundColor)
+		foreground = color.AutoForeground(background, terminalBackground)
+	}
+
 	inverted := foreground == color.Transparent && len(background) != 0
 
 	background = Colors.ToAnsi(background, !inverted)
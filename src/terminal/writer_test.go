@@ -219,6 +219,12 @@ func TestWriteANSIColors(t *testing.T) {
 			Expected: "\x1b[33mhello \x1b[48;2;130;170;255m\x1b[38;2;1;22;39mnew\x1b[49m\x1b[33m world\x1b[0m",
 			Colors:   &color.Set{Foreground: "yellow", Background: "transparent"},
 		},
+		{
+			Case:     "Auto foreground",
+			Input:    "hello <auto,#ffffff>world</>",
+			Expected: "\x1b[47m\x1b[30mhello \x1b[48;2;255;255;255m\x1b[38;2;0;0;0mworld\x1b[0m",
+			Colors:   &color.Set{Foreground: "black", Background: "white"},
+		},
 	}
 
 	for _, tc := range cases {
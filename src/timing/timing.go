@@ -0,0 +1,55 @@
+package timing
+
+import (
+	"sync"
+	"time"
+)
+
+// Category groups the work we measure while rendering a prompt
+type Category string
+
+const (
+	// Template is the time spent rendering templates
+	Template Category = "template"
+	// Color is the time spent resolving colors to escape sequences
+	Color Category = "color"
+	// Command is the time spent running external commands
+	Command Category = "command"
+)
+
+var (
+	enabled bool
+	lock    sync.Mutex
+	totals  = make(map[Category]time.Duration)
+)
+
+// Enable starts collecting timings, this is disabled by default as it's only used to benchmark
+func Enable() {
+	enabled = true
+}
+
+// Track adds the time elapsed since start to the category.
+// Work done in parallel adds up, so the total can exceed the wall-clock time.
+func Track(category Category, start time.Time) {
+	if !enabled {
+		return
+	}
+
+	elapsed := time.Since(start)
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	totals[category] += elapsed
+}
+
+// Reset returns the timings collected so far and starts over
+func Reset() map[Category]time.Duration {
+	lock.Lock()
+	defer lock.Unlock()
+
+	result := totals
+	totals = make(map[Category]time.Duration)
+
+	return result
+}
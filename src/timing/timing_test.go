@@ -0,0 +1,26 @@
+package timing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrack(t *testing.T) {
+	Track(Template, time.Now().Add(-time.Second))
+	assert.Empty(t, Reset(), "disabled")
+
+	Enable()
+	defer func() { enabled = false }()
+
+	Track(Template, time.Now().Add(-time.Second))
+	Track(Template, time.Now().Add(-time.Second))
+	Track(Command, time.Now().Add(-time.Second))
+
+	got := Reset()
+	assert.GreaterOrEqual(t, got[Template], 2*time.Second)
+	assert.GreaterOrEqual(t, got[Command], time.Second)
+	assert.NotContains(t, got, Color)
+	assert.Empty(t, Reset(), "after reset")
+}
@@ -48,6 +48,7 @@ module.exports = {
         "configuration/transient",
         "configuration/line-error",
         "configuration/tooltips",
+        "configuration/status-line",
       ],
     },
     {
@@ -167,6 +168,7 @@ module.exports = {
           items: [
             "segments/scm/fossil",
             "segments/scm/git",
+            "segments/scm/jj",
             "segments/scm/mercurial",
             "segments/scm/plastic",
             "segments/scm/sapling",
@@ -184,6 +186,7 @@ module.exports = {
             "segments/system/executiontime",
             "segments/system/os",
             "segments/system/path",
+            "segments/system/plugin",
             "segments/system/project",
             "segments/system/root",
             "segments/system/session",
@@ -203,6 +206,7 @@ module.exports = {
           items: [
             "segments/web/brewfather",
             "segments/web/carbonintensity",
+            "segments/web/http",
             "segments/web/ipify",
             "segments/web/nba",
             "segments/web/owm",